@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/devtron-labs/central-api/api"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg"
 	"go.uber.org/zap"
 	"net/http"
 	"os"
@@ -11,35 +13,53 @@ import (
 )
 
 type App struct {
-	MuxRouter *api.MuxRouter
-	Logger    *zap.SugaredLogger
-	server    *http.Server
+	MuxRouter          *api.MuxRouter
+	Logger             *zap.SugaredLogger
+	ReleaseNoteService pkg.ReleaseNoteService
+	server             *http.Server
 }
 
-func NewApp(MuxRouter *api.MuxRouter, Logger *zap.SugaredLogger) *App {
+func NewApp(MuxRouter *api.MuxRouter, Logger *zap.SugaredLogger, ReleaseNoteService pkg.ReleaseNoteService) *App {
 	return &App{
-		MuxRouter: MuxRouter,
-		Logger:    Logger,
+		MuxRouter:          MuxRouter,
+		Logger:             Logger,
+		ReleaseNoteService: ReleaseNoteService,
 	}
 }
 
 func (app *App) Start() {
 	port := 8080 //TODO: extract from environment variable
 	app.Logger.Infow("starting server on ", "port", port)
+	err := util.DefaultMetricsRegistry.Register(app.ReleaseNoteService.GetMetricsCollector())
+	if err != nil {
+		app.Logger.Errorw("error in registering release metrics", "err", err)
+	}
 	app.MuxRouter.Init()
+	app.ReleaseNoteService.Start(context.Background())
 	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: app.MuxRouter.Router}
 	app.server = server
-	err := server.ListenAndServe()
+	err = server.ListenAndServe()
 	if err != nil {
 		app.Logger.Errorw("error in startup", "err", err)
 		os.Exit(2)
 	}
 }
 
+// ReloadConfig applies the config which can change without a restart, it is triggered by SIGHUP
+func (app *App) ReloadConfig() {
+	app.Logger.Infow("reloading config")
+	err := app.MuxRouter.ReloadConfig()
+	if err != nil {
+		app.Logger.Errorw("error in reloading config, keeping the current one", "err", err)
+	}
+}
+
 func (app *App) Stop() {
 	app.Logger.Infow("lens shutdown initiating")
 	timeoutContext, _ := context.WithTimeout(context.Background(), 5*time.Second)
 	app.Logger.Infow("stopping nats")
+	app.Logger.Infow("stopping release refresh")
+	app.ReleaseNoteService.Stop()
 
 	app.Logger.Infow("closing router")
 	err := app.server.Shutdown(timeoutContext)
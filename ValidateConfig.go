@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/logger"
+	"github.com/devtron-labs/central-api/pkg"
+	"io"
+	"os"
+)
+
+const OutputJson = "json"
+
+// validateConfig checks the configuration without starting the server and prints the report,
+// it returns the exit code, non zero when any check failed
+func validateConfig(output string, checkConnectivity bool) int {
+	sugaredLogger, err := logger.NewSugardLogger()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in creating logger, %v\n", err)
+		return 2
+	}
+	report := pkg.ValidateConfiguration(sugaredLogger, checkConnectivity)
+	if output == OutputJson {
+		err = printJsonReport(os.Stdout, report)
+	} else {
+		printTextReport(os.Stdout, report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in printing report, %v\n", err)
+		return 2
+	}
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
+
+func printJsonReport(writer io.Writer, report *common.ConfigValidationReport) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func printTextReport(writer io.Writer, report *common.ConfigValidationReport) {
+	for _, check := range report.Checks {
+		if len(check.Message) > 0 {
+			fmt.Fprintf(writer, "[%s] %s: %s\n", check.Status, check.Source, check.Message)
+		} else {
+			fmt.Fprintf(writer, "[%s] %s\n", check.Status, check.Source)
+		}
+	}
+	if report.Valid {
+		fmt.Fprintln(writer, "configuration is valid")
+	} else {
+		fmt.Fprintln(writer, "configuration is invalid")
+	}
+}
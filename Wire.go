@@ -8,6 +8,7 @@ import (
 	util "github.com/devtron-labs/central-api/client"
 	"github.com/devtron-labs/central-api/internal/logger"
 	"github.com/devtron-labs/central-api/pkg"
+	"github.com/devtron-labs/central-api/pkg/adminState"
 	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
 	"github.com/google/wire"
 )
@@ -31,9 +32,27 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(pkg.WebhookSecretValidator), new(*pkg.WebhookSecretValidatorImpl)),
 		util.NewModuleConfig,
 		util.NewBlobConfig,
+		util.NewReleaseCacheConfig,
+		util.NewSupportPolicyConfig,
+		util.NewAdminConfig,
+		util.NewResponseConfig,
+		util.NewMetricsConfig,
+		util.NewReleaseLintConfig,
+		util.NewReleaseSourceConfig,
+		util.NewReleaseBodyConfig,
+		util.NewReleaseChannelConfig,
+		util.NewClientVersionConfig,
+		util.NewResponseSigningConfig,
+		adminState.NewAdminStateRepository,
 
 		pkg.NewCiBuildMetadataServiceImpl,
 		wire.Bind(new(pkg.CiBuildMetadataService), new(*pkg.CiBuildMetadataServiceImpl)),
+		util.NewTelemetryConfig,
+		pkg.NewTelemetryServiceImpl,
+		wire.Bind(new(pkg.TelemetryService), new(*pkg.TelemetryServiceImpl)),
+		util.NewReleaseAssetConfig,
+		pkg.NewReleaseAssetServiceImpl,
+		wire.Bind(new(pkg.ReleaseAssetService), new(*pkg.ReleaseAssetServiceImpl)),
 	)
 	return &App{}, nil
 }
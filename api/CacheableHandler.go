@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const CacheControlHeaderValue = "public, max-age=60"
+
+// bufferedResponseWriter keeps the response so that headers derived from the body can be set
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// cacheableContent is what the etag is computed over, the catalog profile keeps variants apart
+type cacheableContent struct {
+	Profile string
+	Body    []byte
+}
+
+func (impl *RestHandlerImpl) GetLastModified() time.Time {
+	return impl.releaseNoteService.GetLastModified()
+}
+
+// cacheable serves GET and HEAD with etag and cache headers, answering a matching If-None-Match with 304
+func (r MuxRouter) cacheable(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		buffered := &bufferedResponseWriter{header: w.Header()}
+		request := req
+		if req.Method == http.MethodHead {
+			// handlers only know GET, the response is the same apart from the body
+			request = req.Clone(req.Context())
+			request.Method = http.MethodGet
+		}
+		handler(buffered, request)
+		if buffered.status == 0 {
+			buffered.status = http.StatusOK
+		}
+		body := buffered.body.Bytes()
+		if buffered.status == http.StatusOK {
+			etag, err := util2.GetContentHash(&cacheableContent{Profile: w.Header().Get(CatalogProfileHeader), Body: body})
+			if err != nil {
+				r.logger.Errorw("error in computing etag, sending the response without it", "path", req.URL.Path, "err", err)
+			} else {
+				w.Header().Add("Vary", "Accept-Encoding")
+				if r.compressor.shouldCompress(req, body) {
+					compressed, err := r.compressor.compress(etag, body)
+					if err != nil {
+						r.logger.Errorw("error in compressing response, sending it uncompressed", "path", req.URL.Path, "err", err)
+					} else {
+						body = compressed
+						etag = etag + "-" + GzipEncoding
+						w.Header().Set("Content-Encoding", GzipEncoding)
+					}
+				}
+				etag = "\"" + etag + "\""
+				w.Header().Set("ETag", etag)
+				if lastModified := r.restHandler.GetLastModified(); !lastModified.IsZero() {
+					w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+				}
+				w.Header().Set("Cache-Control", CacheControlHeaderValue)
+				if isETagMatched(req.Header.Get("If-None-Match"), etag) {
+					w.Header().Del("Content-Length")
+					w.Header().Del("Content-Encoding")
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(buffered.status)
+		if req.Method != http.MethodHead {
+			_, _ = w.Write(body)
+		}
+	}
+}
+
+func isETagMatched(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheableServesHeadWithHeadersOfGet(t *testing.T) {
+	lastModified := time.Date(2024, 3, 18, 12, 7, 10, 0, time.FixedZone("IST", 19800))
+	releaseNoteService := &fakeReleaseNoteService{releases: []*common.Release{{TagName: "v0.7.1"}, {TagName: "v0.7.0"}}, lastModified: lastModified}
+	for _, gzipEnabled := range []bool{false, true} {
+		router := MuxRouter{logger: zap.NewNop().Sugar(), restHandler: newTestRestHandler(releaseNoteService),
+			compressor: newResponseCompressor(&util.ResponseConfig{GzipEnabled: gzipEnabled})}
+		handler := router.cacheable(router.restHandler.GetReleases)
+		serve := func(method string, header http.Header) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, "/release/notes", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			for name, values := range header {
+				req.Header[name] = values
+			}
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+			return recorder
+		}
+
+		get, head := serve(http.MethodGet, nil), serve(http.MethodHead, nil)
+		if get.Code != http.StatusOK || head.Code != http.StatusOK {
+			t.Fatalf("expected both to succeed, got %d and %d", get.Code, head.Code)
+		}
+		for _, name := range []string{"ETag", "Last-Modified", "Cache-Control", "Content-Length", "Content-Encoding", "Vary"} {
+			if get.Header().Get(name) != head.Header().Get(name) {
+				t.Errorf("expected the same %s with gzip %v, got %q and %q", name, gzipEnabled, get.Header().Get(name), head.Header().Get(name))
+			}
+		}
+		if len(get.Header().Get("ETag")) == 0 || get.Body.Len() == 0 || head.Body.Len() != 0 {
+			t.Errorf("expected an etag and a body for GET only, got %q, %d and %d bytes", get.Header().Get("ETag"), get.Body.Len(), head.Body.Len())
+		}
+		if modified := get.Header().Get("Last-Modified"); modified != "Mon, 18 Mar 2024 06:37:10 GMT" {
+			t.Errorf("expected the last modified time in GMT, got %q", modified)
+		}
+		if gzipEnabled != (get.Header().Get("Content-Encoding") == GzipEncoding) {
+			t.Errorf("expected gzip %v, got encoding %q", gzipEnabled, get.Header().Get("Content-Encoding"))
+		}
+
+		notModified := serve(http.MethodHead, http.Header{"If-None-Match": []string{get.Header().Get("ETag")}})
+		if notModified.Code != http.StatusNotModified || notModified.Header().Get("ETag") != get.Header().Get("ETag") {
+			t.Errorf("expected a matching etag to be answered with 304, got %d", notModified.Code)
+		}
+	}
+}
+
+func TestCacheableEtagDependsOnCatalogProfile(t *testing.T) {
+	router := MuxRouter{logger: zap.NewNop().Sugar(), restHandler: newTestRestHandler(&fakeReleaseNoteService{}),
+		compressor: newResponseCompressor(&util.ResponseConfig{})}
+	serve := func(profile string) *httptest.ResponseRecorder {
+		handler := router.cacheable(func(w http.ResponseWriter, r *http.Request) {
+			if len(profile) > 0 {
+				w.Header().Set(CatalogProfileHeader, profile)
+			}
+			_, _ = w.Write([]byte(`{"code": 200, "result": []}`))
+		})
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodGet, "/modules", nil))
+		return recorder
+	}
+	oss, enterprise := serve("oss"), serve("enterprise")
+	if oss.Header().Get("ETag") == enterprise.Header().Get("ETag") {
+		t.Errorf("expected the catalog profiles to have their own etag")
+	}
+	if oss.Header().Get("ETag") != serve("oss").Header().Get("ETag") {
+		t.Errorf("expected the same content to keep its etag")
+	}
+	if modified := oss.Header().Get("Last-Modified"); len(modified) > 0 {
+		t.Errorf("expected no last modified time when it is not known, got %q", modified)
+	}
+}
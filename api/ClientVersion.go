@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+)
+
+const ClientVersionHeader = "X-Client-Version"
+
+// clientVersionExemptPaths are called by probes, scrapers and github, which send no client version
+var clientVersionExemptPaths = map[string]bool{
+	"/health":          true,
+	"/status":          true,
+	"/metrics":         true,
+	"/signing-keys":    true,
+	"/release/webhook": true,
+}
+
+// clientVersionExemptGroups are the endpoint groups called by operators rather than devtron clients
+var clientVersionExemptGroups = map[string]bool{
+	"admin": true,
+}
+
+// endpointGroup is the first segment of the path, skipping an api version like v2
+func endpointGroup(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && len(segments[0]) > 1 && segments[0][0] == 'v' && strings.Trim(segments[0][1:], "0123456789") == "" {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+// enforceClientVersion answers clients older than the minimum version of the endpoint group with 426
+func (r MuxRouter) enforceClientVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		group := endpointGroup(req.URL.Path)
+		if clientVersionExemptPaths[req.URL.Path] || clientVersionExemptGroups[group] || req.Method == http.MethodOptions {
+			next.ServeHTTP(w, req)
+			return
+		}
+		policy := r.clientVersionConfig.GetPolicy()
+		minVersion := policy.MinClientVersions[group]
+		clientVersionHeader := req.Header.Get(ClientVersionHeader)
+		if len(clientVersionHeader) == 0 {
+			if policy.ClientVersionConfig.ClientVersionRequired {
+				setupResponse(&w, req)
+				writeUpgradeRequired(w, policy, clientVersionHeader, minVersion, fmt.Sprintf("missing %s header", ClientVersionHeader))
+				return
+			}
+			next.ServeHTTP(w, req)
+			return
+		}
+		clientVersion, err := util2.ParseSemanticVersion(clientVersionHeader)
+		if err != nil {
+			setupResponse(&w, req)
+			writeJsonResp(w, err, fmt.Sprintf("invalid %s header", ClientVersionHeader), http.StatusBadRequest)
+			return
+		}
+		if minVersion != nil && clientVersion.Compare(minVersion) < 0 {
+			setupResponse(&w, req)
+			writeUpgradeRequired(w, policy, clientVersionHeader, minVersion, fmt.Sprintf("client version %s is below the minimum version of %s endpoints", clientVersionHeader, group))
+			return
+		}
+		if deprecatedVersion, ok := policy.DeprecatedClientVersions[group]; ok && clientVersion.Compare(deprecatedVersion) < 0 {
+			w.Header().Set("Warning", fmt.Sprintf("299 - \"client version %s is deprecated, upgrade to %s or later\"", clientVersionHeader, deprecatedVersion.String()))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func writeUpgradeRequired(w http.ResponseWriter, policy *util.ClientVersionPolicy, clientVersion string, minVersion *util2.SemanticVersion, message string) {
+	upgradeRequired := &common.ClientUpgradeRequired{
+		ClientVersion: clientVersion,
+		DocsLink:      policy.ClientVersionConfig.ClientUpgradeDocsLink,
+	}
+	if minVersion != nil {
+		upgradeRequired.MinimumVersion = minVersion.String()
+	}
+	writeJsonResp(w, &util2.ApiError{HttpStatusCode: http.StatusUpgradeRequired, InternalMessage: message, UserMessage: upgradeRequired}, nil, http.StatusUpgradeRequired)
+}
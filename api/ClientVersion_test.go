@@ -0,0 +1,78 @@
+package api
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newClientVersionTestHandler(t *testing.T) (http.Handler, *util.ClientVersionConfig) {
+	t.Setenv("CLIENT_VERSION_REQUIRED", "true")
+	t.Setenv("CLIENT_MIN_VERSIONS", "release=0.6.0")
+	clientVersionConfig, err := util.NewClientVersionConfig(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := MuxRouter{clientVersionConfig: clientVersionConfig}
+	return router.enforceClientVersion(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})), clientVersionConfig
+}
+
+func serveWithClientVersion(handler http.Handler, path string, clientVersion string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if len(clientVersion) > 0 {
+		req.Header.Set(ClientVersionHeader, clientVersion)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestEnforceClientVersionRejectsWithCorsHeaders(t *testing.T) {
+	handler, _ := newClientVersionTestHandler(t)
+	for _, clientVersion := range []string{"", "0.5.0", "not-a-version"} {
+		recorder := serveWithClientVersion(handler, "/release/notes", clientVersion)
+		if recorder.Code != http.StatusUpgradeRequired && recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected client version %q to be rejected, got %d", clientVersion, recorder.Code)
+		}
+		if recorder.Header().Get("Access-Control-Allow-Origin") != "*" {
+			t.Errorf("expected the rejection of client version %q to carry the cors headers", clientVersion)
+		}
+	}
+	if recorder := serveWithClientVersion(handler, "/release/notes", "0.6.1"); recorder.Code != http.StatusOK {
+		t.Errorf("expected a recent client to be served, got %d", recorder.Code)
+	}
+}
+
+func TestEnforceClientVersionExemptsOperationalEndpoints(t *testing.T) {
+	handler, _ := newClientVersionTestHandler(t)
+	for _, path := range []string{"/health", "/status", "/metrics", "/signing-keys", "/release/webhook", "/admin/config"} {
+		if recorder := serveWithClientVersion(handler, path, ""); recorder.Code != http.StatusOK {
+			t.Errorf("expected %s to be served without client version, got %d", path, recorder.Code)
+		}
+	}
+}
+
+func TestEnforceClientVersionAppliesReloadedPolicy(t *testing.T) {
+	handler, clientVersionConfig := newClientVersionTestHandler(t)
+	t.Setenv("CLIENT_MIN_VERSIONS", "release=0.7.0")
+	err := clientVersionConfig.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if recorder := serveWithClientVersion(handler, "/release/notes", "0.6.1"); recorder.Code != http.StatusUpgradeRequired {
+		t.Errorf("expected the reloaded minimum version to apply, got %d", recorder.Code)
+	}
+
+	// an invalid policy keeps the current one
+	t.Setenv("CLIENT_MIN_VERSIONS", "release")
+	if clientVersionConfig.Reload() == nil {
+		t.Errorf("expected the invalid policy to be rejected")
+	}
+	if recorder := serveWithClientVersion(handler, "/release/notes", "0.7.0"); recorder.Code != http.StatusOK {
+		t.Errorf("expected the current policy to be kept, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"time"
+)
+
+// requestMetrics are labeled by route pattern so that paths don't grow the number of series
+type requestMetrics struct {
+	excludedRoutes map[string]bool
+	duration       *prometheus.HistogramVec
+	responseSize   *prometheus.HistogramVec
+}
+
+func newRequestMetrics(metricsConfig *util.MetricsConfig) *requestMetrics {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "central_api_request_duration_seconds",
+		Help:    "Latency of the requests served.",
+		Buckets: metricsConfig.LatencyBuckets,
+	}, []string{"route", "status"})
+	responseSize := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "central_api_response_size_bytes",
+		Help:    "Size of the response bodies written.",
+		Buckets: metricsConfig.SizeBuckets,
+	}, []string{"route", "status"})
+	excludedRoutes := make(map[string]bool, len(metricsConfig.ExcludedRoutes))
+	for _, route := range metricsConfig.ExcludedRoutes {
+		excludedRoutes[route] = true
+	}
+	return &requestMetrics{excludedRoutes: excludedRoutes, duration: duration, responseSize: responseSize}
+}
+
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *metricsResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *metricsResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+func (r MuxRouter) instrumentRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := getRoutePattern(req)
+		if r.requestMetrics == nil || r.requestMetrics.excludedRoutes[route] {
+			next.ServeHTTP(w, req)
+			return
+		}
+		recorder := &metricsResponseWriter{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(recorder, req)
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		statusClass := fmt.Sprintf("%dxx", status/100)
+		r.requestMetrics.duration.WithLabelValues(route, statusClass).Observe(time.Since(start).Seconds())
+		r.requestMetrics.responseSize.WithLabelValues(route, statusClass).Observe(float64(recorder.size))
+	})
+}
+
+// getRoutePattern returns the path template of the matched route, like /release-note/{tag}/known-issues
+func getRoutePattern(req *http.Request) string {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return ""
+	}
+	pattern, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return pattern
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstrumentRequestsLabelsByRoutePattern(t *testing.T) {
+	metricsConfig, err := util.NewMetricsConfig(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := MuxRouter{Router: mux.NewRouter(), requestMetrics: newRequestMetrics(metricsConfig)}
+	router.Router.Use(router.instrumentRequests)
+	router.Router.Path("/release-note/{tag}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	})
+	router.Router.Path("/health").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(router.requestMetrics.duration, router.requestMetrics.responseSize)
+
+	for _, path := range []string{"/release-note/v0.7.0", "/release-note/v0.7.1", "/health"} {
+		router.Router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(families) != 2 {
+		t.Fatalf("expected the duration and size histograms, got %d families", len(families))
+	}
+	for _, family := range families {
+		if len(family.GetMetric()) != 1 {
+			t.Errorf("expected %s to have a single series for the route pattern, got %d", family.GetName(), len(family.GetMetric()))
+			continue
+		}
+		metric := family.GetMetric()[0]
+		labels := make(map[string]string)
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["route"] != "/release-note/{tag}" || labels["status"] != "2xx" {
+			t.Errorf("expected %s to be labeled with the route pattern, got %v", family.GetName(), labels)
+		}
+		if metric.GetHistogram().GetSampleCount() != 2 {
+			t.Errorf("expected %s to count both tags, got %d", family.GetName(), metric.GetHistogram().GetSampleCount())
+		}
+	}
+}
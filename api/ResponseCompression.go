@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	util "github.com/devtron-labs/central-api/client"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const GzipEncoding = "gzip"
+
+// responseCompressor caches the gzipped bodies by the etag of the uncompressed body
+type responseCompressor struct {
+	config  *util.ResponseConfig
+	mutex   sync.Mutex
+	entries map[string][]byte
+	order   []string
+}
+
+func newResponseCompressor(config *util.ResponseConfig) *responseCompressor {
+	return &responseCompressor{config: config, entries: make(map[string][]byte)}
+}
+
+// shouldCompress tells whether the body is to be sent compressed to the client of the request
+func (c *responseCompressor) shouldCompress(r *http.Request, body []byte) bool {
+	if !c.config.GzipEnabled || len(body) < c.config.GzipMinBytes {
+		return false
+	}
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding = strings.TrimSpace(strings.SplitN(encoding, ";", 2)[0])
+		if encoding == GzipEncoding {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *responseCompressor) compress(etag string, body []byte) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if compressed, ok := c.entries[etag]; ok {
+		return compressed, nil
+	}
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	compressed := buffer.Bytes()
+	if c.config.GzipCacheSize > 0 {
+		if len(c.order) >= c.config.GzipCacheSize {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.entries[etag] = compressed
+		c.order = append(c.order, etag)
+	}
+	return compressed, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompressedResponseDecodesToUncompressedBody(t *testing.T) {
+	releaseNoteService := &fakeReleaseNoteService{releases: []*common.Release{{TagName: "v0.7.1", Body: "## Fixes"}, {TagName: "v0.7.0", Body: "## Features"}}}
+	router := MuxRouter{logger: zap.NewNop().Sugar(), restHandler: newTestRestHandler(releaseNoteService),
+		compressor: newResponseCompressor(&util.ResponseConfig{GzipEnabled: true, GzipCacheSize: 2})}
+	handler := router.cacheable(router.restHandler.GetReleases)
+	serve := func(acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/release/notes", nil)
+		if len(acceptEncoding) > 0 {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	decode := func(recorder *httptest.ResponseRecorder) []byte {
+		t.Helper()
+		if recorder.Header().Get("Content-Encoding") != GzipEncoding {
+			t.Fatalf("expected a gzipped response, got encoding %q", recorder.Header().Get("Content-Encoding"))
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(recorder.Body.Bytes()))
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return body
+	}
+
+	identity := serve("")
+	if len(identity.Header().Get("Content-Encoding")) > 0 {
+		t.Fatalf("expected an uncompressed response without Accept-Encoding, got %q", identity.Header().Get("Content-Encoding"))
+	}
+	// the second request is served from the cached compressed body
+	for i := 0; i < 2; i++ {
+		compressed := serve("br, gzip;q=0.8")
+		if body := decode(compressed); !bytes.Equal(body, identity.Body.Bytes()) {
+			t.Errorf("expected the gzipped body to decode to %s, got %s", identity.Body.String(), body)
+		}
+		if compressed.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("expected the gzipped response to vary by Accept-Encoding, got %q", compressed.Header().Get("Vary"))
+		}
+	}
+	if identity.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("expected the uncompressed response to vary by Accept-Encoding, got %q", identity.Header().Get("Vary"))
+	}
+
+	releaseNoteService.releases = releaseNoteService.releases[:1]
+	updated := serve("")
+	if body := decode(serve("gzip")); !bytes.Equal(body, updated.Body.Bytes()) {
+		t.Errorf("expected the gzipped body to follow the updated releases, got %s", body)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	util "github.com/devtron-labs/central-api/client"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// SignatureHeader carries the detached JWS of the response body, the keys are served on /signing-keys
+const SignatureHeader = "X-Signature"
+
+// responseSigner caches the signatures by the hash of the body
+type responseSigner struct {
+	config          *util.ResponseSigningConfig
+	protectedHeader string
+	mutex           sync.Mutex
+	entries         map[string]string
+	order           []string
+}
+
+type jwsHeader struct {
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid"`
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyId     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	X         string `json:"x"`
+}
+
+type jsonWebKeySet struct {
+	Keys []*jsonWebKey `json:"keys"`
+}
+
+func newResponseSigner(config *util.ResponseSigningConfig) *responseSigner {
+	signer := &responseSigner{config: config, entries: make(map[string]string)}
+	if config.ResponseSigningConfig != nil && config.ResponseSigningConfig.SigningEnabled {
+		header, _ := json.Marshal(&jwsHeader{Algorithm: "EdDSA", KeyId: config.ResponseSigningConfig.SigningKeyId})
+		signer.protectedHeader = base64.RawURLEncoding.EncodeToString(header)
+	}
+	return signer
+}
+
+func (s *responseSigner) enabled() bool {
+	return len(s.protectedHeader) > 0
+}
+
+// sign returns the detached JWS of the body
+func (s *responseSigner) sign(body []byte) string {
+	hash := sha256.Sum256(body)
+	key := hex.EncodeToString(hash[:])
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if signature, ok := s.entries[key]; ok {
+		return signature
+	}
+	signingInput := s.protectedHeader + "." + base64.RawURLEncoding.EncodeToString(body)
+	signature := s.protectedHeader + ".." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(s.config.PrivateKey, []byte(signingInput)))
+	if cacheSize := s.config.ResponseSigningConfig.SignatureCacheSize; cacheSize > 0 {
+		if len(s.order) >= cacheSize {
+			delete(s.entries, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.entries[key] = signature
+		s.order = append(s.order, key)
+	}
+	return signature
+}
+
+// signed signs the uncompressed body, so it is wrapped by cacheable and not the other way round
+func (r MuxRouter) signed(handler http.HandlerFunc) http.HandlerFunc {
+	if !r.signer.enabled() {
+		return handler
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		buffered := &bufferedResponseWriter{header: w.Header()}
+		handler(buffered, req)
+		if buffered.status == 0 {
+			buffered.status = http.StatusOK
+		}
+		body := buffered.body.Bytes()
+		if buffered.status == http.StatusOK {
+			w.Header().Set(SignatureHeader, r.signer.sign(body))
+		}
+		w.WriteHeader(buffered.status)
+		_, _ = w.Write(body)
+	}
+}
+
+// getSigningKeys serves the public keys responses are signed with as a JSON web key set
+func (r MuxRouter) getSigningKeys(w http.ResponseWriter, req *http.Request) {
+	keySet := &jsonWebKeySet{Keys: []*jsonWebKey{}}
+	for keyId, publicKey := range r.signer.config.PublicKeys {
+		keySet.Keys = append(keySet.Keys, &jsonWebKey{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			KeyId:     keyId,
+			Use:       "sig",
+			Algorithm: "EdDSA",
+			X:         base64.RawURLEncoding.EncodeToString(publicKey),
+		})
+	}
+	sort.Slice(keySet.Keys, func(i, j int) bool {
+		return keySet.Keys[i].KeyId < keySet.Keys[j].KeyId
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(keySet)
+}
@@ -1,35 +1,95 @@
 package api
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"fmt"
 	util "github.com/devtron-labs/central-api/client"
 	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
 	"github.com/devtron-labs/central-api/pkg"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type RestHandler interface {
 	GetReleases(w http.ResponseWriter, r *http.Request)
+	GetReleaseTags(w http.ResponseWriter, r *http.Request)
+	GetLatestRelease(w http.ResponseWriter, r *http.Request)
+	CheckRelease(w http.ResponseWriter, r *http.Request)
+	GetReleasesGroupedByMinor(w http.ResponseWriter, r *http.Request)
+	GetLatestPatch(w http.ResponseWriter, r *http.Request)
+	GetPrerequisiteSummary(w http.ResponseWriter, r *http.Request)
+	GetReleasesPartitioned(w http.ResponseWriter, r *http.Request)
+	GetUnacknowledgedReleases(w http.ResponseWriter, r *http.Request)
+	GetUnacknowledgedCount(w http.ResponseWriter, r *http.Request)
+	GetSupportPolicy(w http.ResponseWriter, r *http.Request)
+	SetLineEolDate(w http.ResponseWriter, r *http.Request)
+	RemoveLineEolDate(w http.ResponseWriter, r *http.Request)
+	SetRolloutPercentage(w http.ResponseWriter, r *http.Request)
+	GetReleaseRollouts(w http.ResponseWriter, r *http.Request)
+	BlockRelease(w http.ResponseWriter, r *http.Request)
+	CheckIn(w http.ResponseWriter, r *http.Request)
+	GetTokenScopes(w http.ResponseWriter, r *http.Request)
+	GetEffectiveConfig(w http.ResponseWriter, r *http.Request)
+	GetReleaseSourceMeta(w http.ResponseWriter, r *http.Request)
+	GetModuleAdoptionStats(w http.ResponseWriter, r *http.Request)
+	UnblockRelease(w http.ResponseWriter, r *http.Request)
+	GetRecentReleases(w http.ResponseWriter, r *http.Request)
+	GetReleasesByTags(w http.ResponseWriter, r *http.Request)
+	GetReleaseComponents(w http.ResponseWriter, r *http.Request)
+	GetReleaseImages(w http.ResponseWriter, r *http.Request)
+	GetReleaseFeatureFlags(w http.ResponseWriter, r *http.Request)
+	GetReleaseKnownIssues(w http.ResponseWriter, r *http.Request)
+	GetReleaseByTag(w http.ResponseWriter, r *http.Request)
+	GetFeatureFlags(w http.ResponseWriter, r *http.Request)
+	GetStatus(w http.ResponseWriter, r *http.Request)
+	GetReleaseAttestations(w http.ResponseWriter, r *http.Request)
+	DownloadReleaseAsset(w http.ResponseWriter, r *http.Request)
+	GetComponentVersions(w http.ResponseWriter, r *http.Request)
+	GetReleaseLintReport(w http.ResponseWriter, r *http.Request)
+	GetModuleLintReport(w http.ResponseWriter, r *http.Request)
+	GetVersionGaps(w http.ResponseWriter, r *http.Request)
+	GetMergedChangelog(w http.ResponseWriter, r *http.Request)
+	GetChangelogDocument(w http.ResponseWriter, r *http.Request)
+	QueryReleases(w http.ResponseWriter, r *http.Request)
+	GetRepositoryReadme(w http.ResponseWriter, r *http.Request)
+	GetReleasesDelta(w http.ResponseWriter, r *http.Request)
+	GetReleaseFrequency(w http.ResponseWriter, r *http.Request)
 	ReleaseWebhookHandler(w http.ResponseWriter, r *http.Request)
+	DryRunWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhookStatus(w http.ResponseWriter, r *http.Request)
 	GetModules(w http.ResponseWriter, r *http.Request)
 	GetModulesV2(w http.ResponseWriter, r *http.Request)
 	GetModuleByName(w http.ResponseWriter, r *http.Request)
+	GetModuleDisableImpact(w http.ResponseWriter, r *http.Request)
+	GetModuleUninstallInfo(w http.ResponseWriter, r *http.Request)
+	GetNewModulesBetween(w http.ResponseWriter, r *http.Request)
 	GetDockerfileTemplateMetadata(w http.ResponseWriter, r *http.Request)
 	GetBuildpackMetadata(w http.ResponseWriter, r *http.Request)
+	GetLastModified() time.Time
 }
 
 func NewRestHandlerImpl(logger *zap.SugaredLogger, releaseNoteService pkg.ReleaseNoteService,
-	webhookSecretValidator pkg.WebhookSecretValidator, client *util.GitHubClient, ciBuildMetadataService pkg.CiBuildMetadataService) *RestHandlerImpl {
+	webhookSecretValidator pkg.WebhookSecretValidator, client *util.GitHubClient, ciBuildMetadataService pkg.CiBuildMetadataService,
+	adminConfig *util.AdminConfig, telemetryService pkg.TelemetryService, releaseAssetService pkg.ReleaseAssetService) *RestHandlerImpl {
 	return &RestHandlerImpl{
 		logger:                 logger,
 		releaseNoteService:     releaseNoteService,
 		webhookSecretValidator: webhookSecretValidator,
 		client:                 client,
 		ciBuildMetadataService: ciBuildMetadataService,
+		adminConfig:            adminConfig,
+		telemetryService:       telemetryService,
+		releaseAssetService:    releaseAssetService,
 	}
 }
 
@@ -39,12 +99,28 @@ type RestHandlerImpl struct {
 	webhookSecretValidator pkg.WebhookSecretValidator
 	client                 *util.GitHubClient
 	ciBuildMetadataService pkg.CiBuildMetadataService
+	adminConfig            *util.AdminConfig
+	telemetryService       pkg.TelemetryService
+	releaseAssetService    pkg.ReleaseAssetService
 }
 
+// GitHubDeliveryHeader carries the id github shows for a webhook delivery in its delivery dashboard
+const GitHubDeliveryHeader = "X-GitHub-Delivery"
+
+// WebhookFormContentType is the content type of a hook delivering its json payload in a form
+const WebhookFormContentType = "application/x-www-form-urlencoded"
+
+// CatalogProfileHeader is honoured only along with its signature in CatalogProfileSignatureHeader
+const CatalogProfileHeader = "X-Catalog-Profile"
+const CatalogProfileSignatureHeader = "X-Catalog-Profile-Signature"
+
+// MaxModuleRecentChanges bounds the recent changes attached to the module detail
+const MaxModuleRecentChanges = 10
+
 func setupResponse(w *http.ResponseWriter, req *http.Request) {
 	(*w).Header().Set("Access-Control-Allow-Origin", "*")
 	(*w).Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-	(*w).Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	(*w).Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Client-Version, X-Catalog-Profile, X-Catalog-Profile-Signature")
 	(*w).Header().Set("Content-Type", "text/html; charset=utf-8")
 }
 
@@ -72,6 +148,47 @@ func (impl RestHandlerImpl) WriteJsonResp(w http.ResponseWriter, err error, resp
 	w.Write(b)
 }
 
+// writeServiceErrorResp writes the error with the status and user message it carries, unknown errors are internal server errors
+func (impl RestHandlerImpl) writeServiceErrorResp(w http.ResponseWriter, err error) {
+	if apiErr, ok := err.(*util2.ApiError); ok && apiErr.HttpStatusCode != 0 {
+		impl.WriteJsonResp(w, err, apiErr.UserMessage, apiErr.HttpStatusCode)
+		return
+	}
+	impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+}
+
+// isAdminAuthorized writes the error response itself, admin apis are disabled without an admin token
+func (impl RestHandlerImpl) isAdminAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if len(impl.adminConfig.AdminApiToken) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("admin api disabled"), "admin api is disabled", http.StatusForbidden)
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(impl.adminConfig.AdminApiToken)) != 1 {
+		impl.WriteJsonResp(w, fmt.Errorf("unauthorized"), "invalid admin token", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// orderModules applies the order query param, "dependency" lists every module after the modules it depends on
+func (impl *RestHandlerImpl) orderModules(r *http.Request, modules []*common.Module) ([]*common.Module, error) {
+	order := r.URL.Query().Get("order")
+	switch order {
+	case "":
+		return modules, nil
+	case pkg.ModuleOrderDependency:
+		sorted, err := pkg.SortModulesByDependency(modules)
+		if err != nil {
+			impl.logger.Errorw("error in sorting modules by dependency", "err", err)
+			return nil, err
+		}
+		return sorted, nil
+	default:
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid order %s", order), UserMessage: "order should be dependency"}
+	}
+}
+
 func (impl *RestHandlerImpl) GetModules(w http.ResponseWriter, r *http.Request) {
 	impl.logger.Debug("get all modules")
 	setupResponse(&w, r)
@@ -80,6 +197,17 @@ func (impl *RestHandlerImpl) GetModules(w http.ResponseWriter, r *http.Request)
 		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	modules = pkg.FilterVisibleModules(modules, pkg.ParseFeatureFlags(r.URL.Query().Get("featureFlags")))
+	modules, err = pkg.FilterCompatibleModules(modules, r.URL.Query().Get("serverVersion"), r.URL.Query().Get("k8sVersion"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	modules, err = impl.orderModules(r, modules)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
 	impl.WriteJsonResp(w, nil, modules, http.StatusOK)
 	return
 }
@@ -87,9 +215,29 @@ func (impl *RestHandlerImpl) GetModules(w http.ResponseWriter, r *http.Request)
 func (impl *RestHandlerImpl) GetModulesV2(w http.ResponseWriter, r *http.Request) {
 	impl.logger.Debug("get all modules")
 	setupResponse(&w, r)
-	modules, err := impl.releaseNoteService.GetModulesV2()
+	w.Header().Add("Vary", CatalogProfileHeader)
+	profile, err := impl.releaseNoteService.ResolveCatalogProfile(r.Header.Get(CatalogProfileHeader), r.Header.Get(CatalogProfileSignatureHeader))
 	if err != nil {
-		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	modules, err := impl.releaseNoteService.GetModulesForProfile(profile)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	if len(profile) > 0 {
+		w.Header().Set(CatalogProfileHeader, profile)
+	}
+	modules = pkg.FilterVisibleModules(modules, pkg.ParseFeatureFlags(r.URL.Query().Get("featureFlags")))
+	modules, err = pkg.FilterCompatibleModules(modules, r.URL.Query().Get("serverVersion"), r.URL.Query().Get("k8sVersion"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	modules, err = impl.orderModules(r, modules)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
 		return
 	}
 	impl.WriteJsonResp(w, nil, modules, http.StatusOK)
@@ -99,37 +247,36 @@ func (impl *RestHandlerImpl) GetModulesV2(w http.ResponseWriter, r *http.Request
 func (impl *RestHandlerImpl) GetReleases(w http.ResponseWriter, r *http.Request) {
 	setupResponse(&w, r)
 	impl.logger.Debug("get all releases")
-	offset := 0
-	size := 10
-	var err error
-	offsetQueryParam := r.URL.Query().Get("offset")
-	if len(offsetQueryParam) > 0 {
-		offset, err = strconv.Atoi(offsetQueryParam)
-		if err != nil {
-			impl.WriteJsonResp(w, err, "invalid offset", http.StatusBadRequest)
-			return
-		}
+	query := r.URL.Query()
+	filter := &common.ReleaseListFilter{
+		Channel: query.Get("channel"),
+		After:   query.Get("after"),
+		Before:  query.Get("before"),
 	}
-	sizeQueryParam := r.URL.Query().Get("size")
-	if len(sizeQueryParam) > 0 {
-		size, err = strconv.Atoi(sizeQueryParam)
-		if err != nil {
-			impl.WriteJsonResp(w, err, "invalid size", http.StatusBadRequest)
-			return
-		}
+	var fieldErrors []*common.FieldError
+	offset, fieldErrors := parseNonNegativeInt(query, "offset", 0, fieldErrors)
+	size, fieldErrors := parseNonNegativeInt(query, "size", 10, fieldErrors)
+	filter.Prerequisite, fieldErrors = parseStrictBool(query, "prerequisite", fieldErrors)
+	filter.Breaking, fieldErrors = parseStrictBool(query, "breaking", fieldErrors)
+	if len(fieldErrors) > 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("invalid release filter"), fieldErrors, http.StatusBadRequest)
+		return
 	}
 	//will fetch all the releases from cache and later apply size and offset filter
-	response, err := impl.releaseNoteService.GetReleases()
+	response, err := impl.releaseNoteService.GetReleaseList(filter)
 	if err != nil {
-		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		impl.writeServiceErrorResp(w, err)
 		return
 	}
 
 	if size > 0 {
 		if offset+size <= len(response) {
 			response = response[offset : offset+size]
-		} else {
+		} else if offset < len(response) {
 			response = response[offset:]
+		} else {
+			// filters can leave fewer releases than the offset skips
+			response = []*common.Release{}
 		}
 	}
 
@@ -137,6 +284,735 @@ func (impl *RestHandlerImpl) GetReleases(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// GetReleaseTags lists only the tags of the releases, for installers which don't need the release bodies
+func (impl *RestHandlerImpl) GetReleaseTags(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release tags")
+	tags, err := impl.releaseNoteService.GetReleaseTags(r.URL.Query().Get("channel"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, tags, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetLatestRelease(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get latest release")
+	release, err := impl.releaseNoteService.GetLatestReleaseForInstallation(r.URL.Query().Get("installationId"))
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if release == nil {
+		impl.WriteJsonResp(w, fmt.Errorf("no release available"), "no release available", http.StatusNotFound)
+		return
+	}
+	impl.WriteJsonResp(w, nil, release, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) CheckRelease(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("check release")
+	version := r.URL.Query().Get("version")
+	if len(version) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("version is required"), "version is required", http.StatusBadRequest)
+		return
+	}
+	releaseCheck, err := impl.releaseNoteService.CheckReleaseForInstallation(version, r.URL.Query().Get("installationId"))
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, releaseCheck, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleasesGroupedByMinor(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get releases grouped by minor line")
+	minorLines, err := impl.releaseNoteService.GetReleasesGroupedByMinor()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, minorLines, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetLatestPatch(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get latest patch of minor line")
+	version := r.URL.Query().Get("version")
+	if len(version) == 0 {
+		version = r.URL.Query().Get("line")
+	}
+	if len(version) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("version or line is required"), "version or line is required", http.StatusBadRequest)
+		return
+	}
+	release, err := impl.releaseNoteService.GetLatestPatch(version)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, release, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetPrerequisiteSummary(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get prerequisite summary of upgrade path")
+	fromVersion := r.URL.Query().Get("fromVersion")
+	if len(fromVersion) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("fromVersion is required"), "fromVersion is required", http.StatusBadRequest)
+		return
+	}
+	summary, err := impl.releaseNoteService.GetPrerequisiteSummary(fromVersion, r.URL.Query().Get("toVersion"), getInstalledModules(r))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, summary, http.StatusOK)
+	return
+}
+
+// getInstalledModules returns nil when the param is absent so that every prerequisite is kept
+func getInstalledModules(r *http.Request) []string {
+	values, ok := r.URL.Query()["installedModules"]
+	if !ok {
+		return nil
+	}
+	installedModules := []string{}
+	for _, value := range values {
+		for _, module := range strings.Split(value, ",") {
+			if module = strings.TrimSpace(module); len(module) > 0 {
+				installedModules = append(installedModules, module)
+			}
+		}
+	}
+	return installedModules
+}
+
+func (impl *RestHandlerImpl) GetReleasesPartitioned(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get releases of upgrade path partitioned by prerequisite")
+	fromTag := r.URL.Query().Get("fromTag")
+	if len(fromTag) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("fromTag is required"), "fromTag is required", http.StatusBadRequest)
+		return
+	}
+	partitioned, err := impl.releaseNoteService.GetReleasesPartitioned(fromTag, r.URL.Query().Get("toTag"), getInstalledModules(r))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, partitioned, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetUnacknowledgedReleases(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get unacknowledged releases")
+	acknowledgedTag := r.URL.Query().Get("acknowledgedTag")
+	releases, err := impl.releaseNoteService.GetUnacknowledgedReleases(acknowledgedTag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, releases, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetUnacknowledgedCount(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get unacknowledged releases count")
+	acknowledgedTag := r.URL.Query().Get("acknowledgedTag")
+	count, err := impl.releaseNoteService.GetUnacknowledgedCount(acknowledgedTag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, count, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetSupportPolicy(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get support policy")
+	supportPolicy, err := impl.releaseNoteService.GetSupportPolicy()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, supportPolicy, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) SetLineEolDate(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("set eol date of minor line")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	line := mux.Vars(r)["line"]
+	request := &common.LineEolDateRequest{}
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		impl.WriteJsonResp(w, err, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	err = impl.releaseNoteService.SetLineEolDate(line, request.EolDate)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) RemoveLineEolDate(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("remove eol date of minor line")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	line := mux.Vars(r)["line"]
+	err := impl.releaseNoteService.RemoveLineEolDate(line)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) SetRolloutPercentage(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("set rollout percentage of release")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	tag := mux.Vars(r)["tag"]
+	request := &common.RolloutPercentageRequest{}
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		impl.WriteJsonResp(w, err, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	err = impl.releaseNoteService.SetRolloutPercentage(tag, request.Percentage)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseRollouts(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release rollouts")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	rollouts, err := impl.releaseNoteService.GetReleaseRollouts()
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, rollouts, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseSourceMeta(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release source meta")
+	impl.WriteJsonResp(w, nil, impl.releaseNoteService.GetReleaseSourceMeta(), http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetEffectiveConfig(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get effective config")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	effectiveConfig, err := impl.releaseNoteService.GetEffectiveConfig()
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, effectiveConfig, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetTokenScopes(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get github token scopes")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	scopes, err := impl.releaseNoteService.GetTokenScopes()
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, scopes, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) CheckIn(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("installation check-in")
+	checkIn := &common.InstallationCheckIn{}
+	err := json.NewDecoder(r.Body).Decode(checkIn)
+	if err != nil {
+		impl.WriteJsonResp(w, err, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	err = impl.telemetryService.CheckIn(checkIn)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetModuleAdoptionStats(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get module adoption stats")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	var window time.Duration
+	windowQueryParam := r.URL.Query().Get("window")
+	if len(windowQueryParam) > 0 {
+		var err error
+		window, err = time.ParseDuration(windowQueryParam)
+		if err != nil || window <= 0 {
+			impl.WriteJsonResp(w, fmt.Errorf("invalid window %s", windowQueryParam), "invalid window, expected a duration like 168h", http.StatusBadRequest)
+			return
+		}
+	}
+	stats, err := impl.telemetryService.GetModuleAdoptionStats(window)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, stats, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) BlockRelease(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("block release as upgrade target")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	tag := mux.Vars(r)["tag"]
+	request := &common.BlockReleaseRequest{}
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		impl.WriteJsonResp(w, err, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	err = impl.releaseNoteService.BlockRelease(tag, request.Reason)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) UnblockRelease(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("unblock release as upgrade target")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	tag := mux.Vars(r)["tag"]
+	err := impl.releaseNoteService.UnblockRelease(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, true, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetRecentReleases(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get recent releases")
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil {
+		impl.WriteJsonResp(w, err, "invalid days", http.StatusBadRequest)
+		return
+	}
+	releases, err := impl.releaseNoteService.GetRecentReleasesByDays(days)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, releases, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleasesByTags(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get releases by tags")
+	lookup, err := impl.releaseNoteService.GetReleasesByTags(strings.Split(r.URL.Query().Get("tags"), ","), r.URL.Query().Get("installationId"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, lookup, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseComponents(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release components")
+	tag := mux.Vars(r)["tag"]
+	components, err := impl.releaseNoteService.GetReleaseComponents(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, components, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseImages(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release images")
+	tag := mux.Vars(r)["tag"]
+	images, err := impl.releaseNoteService.GetReleaseImages(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, images, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release feature flags")
+	tag := mux.Vars(r)["tag"]
+	flags, err := impl.releaseNoteService.GetReleaseFeatureFlags(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, flags, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get feature flags introduced since version")
+	flags, err := impl.releaseNoteService.GetFeatureFlagsSince(r.URL.Query().Get("since"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, flags, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseAttestations(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release attestations")
+	tag := mux.Vars(r)["tag"]
+	attestations, err := impl.releaseNoteService.GetReleaseAttestations(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, attestations, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) DownloadReleaseAsset(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("download release asset")
+	vars := mux.Vars(r)
+	download, err := impl.releaseAssetService.DownloadReleaseAsset(r.Context(), vars["tag"], vars["assetName"], r.Header.Get("Range"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": download.Asset.Name}))
+	if download.File != nil {
+		defer download.File.Close()
+		if len(download.Asset.ContentType) > 0 {
+			w.Header().Set("Content-Type", download.Asset.ContentType)
+		}
+		modTime := time.Time{}
+		if info, err := download.File.Stat(); err == nil {
+			modTime = info.ModTime()
+		}
+		// serves range requests against the cached copy
+		http.ServeContent(w, r, download.Asset.Name, modTime, download.File)
+		return
+	}
+	defer download.Body.Close()
+	for name, values := range download.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(download.StatusCode)
+	// the asset is streamed, an error once it has started can only be logged
+	_, err = io.Copy(w, download.Body)
+	if err != nil {
+		impl.logger.Errorw("error in streaming release asset", "tag", vars["tag"], "assetName", vars["assetName"], "err", err)
+	}
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseKnownIssues(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release known issues")
+	tag := mux.Vars(r)["tag"]
+	knownIssues, err := impl.releaseNoteService.GetReleaseKnownIssues(tag)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, knownIssues, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseByTag(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release by tag")
+	release, err := impl.releaseNoteService.GetReleaseByTag(mux.Vars(r)["tag"])
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, release, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetComponentVersions(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get component versions")
+	component := r.URL.Query().Get("component")
+	componentVersions, err := impl.releaseNoteService.GetComponentVersions(component)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, componentVersions, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseLintReport(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release lint report")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	lintReport, err := impl.releaseNoteService.GetReleaseLintReport()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, lintReport, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetModuleLintReport(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get module lint report")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	lintReport, err := impl.releaseNoteService.GetModuleLintReport()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, lintReport, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetVersionGaps(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release version gaps")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	gaps, err := impl.releaseNoteService.DetectVersionGaps()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, gaps, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetMergedChangelog(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get changelog merged across repos")
+	releases, err := impl.releaseNoteService.GetMergedChangelog()
+	if err != nil {
+		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	impl.WriteJsonResp(w, nil, releases, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) QueryReleases(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("query releases")
+	query := r.URL.Query()
+	q := common.ReleaseQuery{
+		PublishedFrom: query.Get("publishedFrom"),
+		PublishedTo:   query.Get("publishedTo"),
+		Search:        query.Get("search"),
+		Series:        query.Get("series"),
+		Channel:       strings.ToLower(query.Get("channel")),
+		Sort:          query.Get("sort"),
+		Cursor:        query.Get("cursor"),
+	}
+	var fieldErrors []*common.FieldError
+	q.Prerelease, fieldErrors = parseOptionalBool(query, "prerelease", fieldErrors)
+	q.Draft, fieldErrors = parseOptionalBool(query, "draft", fieldErrors)
+	if limit := query.Get("limit"); len(limit) > 0 {
+		var err error
+		q.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			fieldErrors = append(fieldErrors, &common.FieldError{Field: "limit", Message: "should be a number"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("invalid release query"), fieldErrors, http.StatusBadRequest)
+		return
+	}
+	page, err := impl.releaseNoteService.QueryReleases(q)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, page, http.StatusOK)
+	return
+}
+
+// parseOptionalBool parses the query param when present, a malformed value is added to the field errors
+func parseOptionalBool(query url.Values, field string, fieldErrors []*common.FieldError) (*bool, []*common.FieldError) {
+	value := query.Get(field)
+	if len(value) == 0 {
+		return nil, fieldErrors
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, append(fieldErrors, &common.FieldError{Field: field, Message: "should be true or false"})
+	}
+	return &parsed, fieldErrors
+}
+
+// parseStrictBool accepts only true, false, 1 and 0, any other value is added to the field errors
+func parseStrictBool(query url.Values, field string, fieldErrors []*common.FieldError) (*bool, []*common.FieldError) {
+	var parsed bool
+	switch query.Get(field) {
+	case "":
+		return nil, fieldErrors
+	case "true", "1":
+		parsed = true
+	case "false", "0":
+		parsed = false
+	default:
+		return nil, append(fieldErrors, &common.FieldError{Field: field, Message: "should be true, false, 1 or 0"})
+	}
+	return &parsed, fieldErrors
+}
+
+// parseNonNegativeInt reads an optional integer query param, defaultValue when it is missing
+func parseNonNegativeInt(query url.Values, field string, defaultValue int, fieldErrors []*common.FieldError) (int, []*common.FieldError) {
+	value := query.Get(field)
+	if len(value) == 0 {
+		return defaultValue, fieldErrors
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return defaultValue, append(fieldErrors, &common.FieldError{Field: field, Message: "should be a non-negative integer"})
+	}
+	return parsed, fieldErrors
+}
+
+func (impl *RestHandlerImpl) GetChangelogDocument(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get changelog document")
+	query := r.URL.Query()
+	opts := &common.ChangelogOptions{
+		FromVersion: query.Get("from"),
+		ToVersion:   query.Get("to"),
+		Format:      query.Get("format"),
+	}
+	if len(opts.Format) == 0 {
+		opts.Format = pkg.ChangelogFormatMarkdown
+	}
+	releases, err := impl.releaseNoteService.GetChangelogReleases(opts)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	// the document is streamed, an error while rendering can only be logged once it has started
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	err = pkg.RenderChangelog(w, releases, opts.Format)
+	if err != nil {
+		impl.logger.Errorw("error in rendering changelog document", "format", opts.Format, "err", err)
+	}
+	return
+}
+
+func (impl *RestHandlerImpl) GetRepositoryReadme(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get repository readme")
+	readme, err := impl.releaseNoteService.GetRepositoryReadme()
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, readme, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleasesDelta(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get releases delta")
+	knownTag := r.URL.Query().Get("knownTag")
+	if len(knownTag) == 0 {
+		impl.WriteJsonResp(w, fmt.Errorf("knownTag is required"), "knownTag is required", http.StatusBadRequest)
+		return
+	}
+	delta, err := impl.releaseNoteService.GetReleasesDelta(knownTag, r.URL.Query().Get("knownEtag"))
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, delta, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetReleaseFrequency(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get release frequency")
+	frequency, err := impl.releaseNoteService.GetReleaseFrequency()
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, frequency, http.StatusOK)
+	return
+}
+
 func (impl *RestHandlerImpl) ReleaseWebhookHandler(w http.ResponseWriter, r *http.Request) {
 	impl.logger.Debug("release webhook handler received event")
 	// get git host Id and secret from request
@@ -152,13 +1028,25 @@ func (impl *RestHandlerImpl) ReleaseWebhookHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	isValidSig := impl.webhookSecretValidator.ValidateSecret(r, requestBodyBytes)
-	impl.logger.Debugw("Secret validation result ", "isValidSig", isValidSig)
-	if !isValidSig {
-		impl.logger.Error("Signature mismatch")
-		impl.WriteJsonResp(w, err, nil, http.StatusUnauthorized)
+	err = impl.webhookSecretValidator.ValidateRequest(r, requestBodyBytes)
+	impl.logger.Debugw("Secret validation result ", "err", err)
+	if err != nil {
+		impl.logger.Errorw("webhook signature validation failed", "err", err)
+		impl.WriteJsonResp(w, err, "invalid webhook signature", http.StatusUnauthorized)
 		return
 	}
+	// a hook delivering form encoded payloads sends the json in the payload field, the signature is over the form
+	formEncoded := false
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == WebhookFormContentType {
+		form, err := url.ParseQuery(string(requestBodyBytes))
+		if err != nil || len(form.Get("payload")) == 0 {
+			impl.logger.Errorw("form encoded webhook event without payload", "err", err)
+			impl.WriteJsonResp(w, fmt.Errorf("form encoded webhook event without payload"), "invalid webhook payload", http.StatusBadRequest)
+			return
+		}
+		requestBodyBytes = []byte(form.Get("payload"))
+		formEncoded = true
+	}
 	// validate event type
 	eventType := r.Header.Get(impl.client.GitHubConfig.GitHubEventTypeHeader)
 	impl.logger.Debugw("webhook event type header", "eventType : ", eventType)
@@ -168,12 +1056,56 @@ func (impl *RestHandlerImpl) ReleaseWebhookHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	flag, err := impl.releaseNoteService.UpdateReleases(requestBodyBytes)
+	deliveryId := r.Header.Get(GitHubDeliveryHeader)
+	if eventType == pkg.EventTypePing {
+		ping, err := impl.releaseNoteService.HandlePing(requestBodyBytes, formEncoded)
+		if err != nil {
+			impl.logger.Errorw("error in handling webhook ping", "deliveryId", deliveryId, "err", err)
+			impl.writeServiceErrorResp(w, err)
+			return
+		}
+		impl.WriteJsonResp(w, nil, ping, http.StatusOK)
+		return
+	}
+	ack, err := impl.releaseNoteService.UpdateReleasesWithAck(requestBodyBytes)
 	if err != nil {
+		impl.logger.Errorw("error in handling release webhook event", "deliveryId", deliveryId, "action", ack.Action, "tagName", ack.TagName, "err", err)
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.logger.Infow("release webhook event handled", "deliveryId", deliveryId, "action", ack.Action, "tagName", ack.TagName, "processed", ack.Processed)
+	impl.WriteJsonResp(w, err, ack, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetWebhookStatus(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("get webhook status")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	impl.WriteJsonResp(w, nil, impl.releaseNoteService.GetWebhookStatus(), http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) DryRunWebhook(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	impl.logger.Debug("dry run release webhook payload")
+	if !impl.isAdminAuthorized(w, r) {
+		return
+	}
+	requestBodyBytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		impl.logger.Errorw("Cannot read the request body:", "err", err)
 		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
-	impl.WriteJsonResp(w, err, flag, http.StatusOK)
+	result, err := impl.releaseNoteService.DryRunWebhook(requestBodyBytes)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, result, http.StatusOK)
 	return
 }
 
@@ -187,10 +1119,70 @@ func (impl *RestHandlerImpl) GetModuleByName(w http.ResponseWriter, r *http.Requ
 		impl.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	includeRecentChangesQueryParam := r.URL.Query().Get("includeRecentChanges")
+	if len(includeRecentChangesQueryParam) > 0 {
+		limit, err := strconv.Atoi(includeRecentChangesQueryParam)
+		if err != nil || limit < 0 {
+			impl.WriteJsonResp(w, fmt.Errorf("invalid includeRecentChanges %s", includeRecentChangesQueryParam), "invalid includeRecentChanges", http.StatusBadRequest)
+			return
+		}
+		if limit > MaxModuleRecentChanges {
+			limit = MaxModuleRecentChanges
+		}
+		recentChanges, err := impl.releaseNoteService.GetModuleRecentChanges(name, limit)
+		if err != nil {
+			impl.writeServiceErrorResp(w, err)
+			return
+		}
+		impl.WriteJsonResp(w, nil, &common.ModuleWithRecentChanges{Module: module, RecentChanges: recentChanges}, http.StatusOK)
+		return
+	}
 	impl.WriteJsonResp(w, nil, module, http.StatusOK)
 	return
 }
 
+func (impl *RestHandlerImpl) GetModuleUninstallInfo(w http.ResponseWriter, r *http.Request) {
+	impl.logger.Debug("get module uninstall info")
+	setupResponse(&w, r)
+	vars := mux.Vars(r)
+	name := vars["name"]
+	uninstallInfo, err := impl.releaseNoteService.GetModuleUninstallInfo(name)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, uninstallInfo, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetNewModulesBetween(w http.ResponseWriter, r *http.Request) {
+	impl.logger.Debug("get modules available between versions")
+	setupResponse(&w, r)
+	fromVersion := r.URL.Query().Get("fromVersion")
+	toVersion := r.URL.Query().Get("toVersion")
+	modules, err := impl.releaseNoteService.GetNewModulesBetween(fromVersion, toVersion)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, modules, http.StatusOK)
+	return
+}
+
+func (impl *RestHandlerImpl) GetModuleDisableImpact(w http.ResponseWriter, r *http.Request) {
+	impl.logger.Debug("get modules impacted on disabling module")
+	setupResponse(&w, r)
+	vars := mux.Vars(r)
+	name := vars["name"]
+	modules, err := impl.releaseNoteService.DisableImpact(name)
+	if err != nil {
+		impl.writeServiceErrorResp(w, err)
+		return
+	}
+	impl.WriteJsonResp(w, nil, modules, http.StatusOK)
+	return
+}
+
 func (impl *RestHandlerImpl) GetDockerfileTemplateMetadata(w http.ResponseWriter, r *http.Request) {
 	impl.logger.Debug("get all dockerfile template metadata")
 	setupResponse(&w, r)
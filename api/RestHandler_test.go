@@ -0,0 +1,276 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeReleaseNoteService answers the release list with the releases and keeps the filter and webhook body it was
+// called with. The methods not overridden panic on the nil embedded service.
+type fakeReleaseNoteService struct {
+	pkg.ReleaseNoteService
+	releases     []*common.Release
+	filter       *common.ReleaseListFilter
+	webhookBody  []byte
+	formEncoded  bool
+	lastModified time.Time
+	err          error
+}
+
+func (impl *fakeReleaseNoteService) GetReleaseList(filter *common.ReleaseListFilter) ([]*common.Release, error) {
+	impl.filter = filter
+	return impl.releases, nil
+}
+
+func (impl *fakeReleaseNoteService) UpdateReleasesWithAck(requestBodyBytes []byte) (*common.ReleaseWebhookAck, error) {
+	impl.webhookBody = requestBodyBytes
+	return &common.ReleaseWebhookAck{Action: "published", TagName: "v0.7.1", Processed: true}, nil
+}
+
+func (impl *fakeReleaseNoteService) HandlePing(body []byte, formEncoded bool) (*common.WebhookPing, error) {
+	impl.webhookBody = body
+	impl.formEncoded = formEncoded
+	return &common.WebhookPing{}, nil
+}
+
+func (impl *fakeReleaseNoteService) DisableImpact(name string) ([]*common.Module, error) {
+	return nil, impl.err
+}
+
+func (impl *fakeReleaseNoteService) GetEffectiveConfig() (*common.EffectiveConfig, error) {
+	return &common.EffectiveConfig{GitHubToken: pkg.RedactedValue, GitHubWebhookSecret: pkg.RedactedValue}, nil
+}
+
+func (impl *fakeReleaseNoteService) GetLastModified() time.Time {
+	return impl.lastModified
+}
+
+func newTestRestHandler(releaseNoteService pkg.ReleaseNoteService) *RestHandlerImpl {
+	return NewRestHandlerImpl(zap.NewNop().Sugar(), releaseNoteService, nil, nil, nil, nil, nil, nil)
+}
+
+func decodeTestResponse(t *testing.T, recorder *httptest.ResponseRecorder, result interface{}) {
+	t.Helper()
+	response := &common.Response{Result: result}
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatalf("unexpected response %s, %v", recorder.Body.String(), err)
+	}
+}
+
+func TestGetReleasesParsesFilters(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name         string
+		query        string
+		status       int
+		prerequisite *bool
+		breaking     *bool
+		after        string
+		before       string
+	}{
+		{name: "no filter", query: "", status: http.StatusOK},
+		{name: "prerequisite", query: "prerequisite=true", status: http.StatusOK, prerequisite: &yes},
+		{name: "breaking as number", query: "breaking=1", status: http.StatusOK, breaking: &yes},
+		{name: "both off", query: "prerequisite=false&breaking=0", status: http.StatusOK, prerequisite: &no, breaking: &no},
+		{name: "with version bounds", query: "prerequisite=1&breaking=true&after=0.6.0&before=0.8.0", status: http.StatusOK, prerequisite: &yes, breaking: &yes, after: "0.6.0", before: "0.8.0"},
+		{name: "invalid prerequisite", query: "prerequisite=yes", status: http.StatusBadRequest},
+		{name: "invalid breaking", query: "prerequisite=true&breaking=TRUE", status: http.StatusBadRequest},
+		{name: "negative offset", query: "offset=-1&size=2", status: http.StatusBadRequest},
+		{name: "negative size", query: "size=-2", status: http.StatusBadRequest},
+		{name: "invalid size", query: "size=ten", status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releaseNoteService := &fakeReleaseNoteService{}
+			recorder := httptest.NewRecorder()
+			newTestRestHandler(releaseNoteService).GetReleases(recorder, httptest.NewRequest(http.MethodGet, "/release/notes?"+test.query, nil))
+			if recorder.Code != test.status {
+				t.Fatalf("expected status %d, got %d", test.status, recorder.Code)
+			}
+			if test.status != http.StatusOK {
+				if releaseNoteService.filter != nil {
+					t.Errorf("expected the releases not to be listed with an invalid filter")
+				}
+				return
+			}
+			filter := releaseNoteService.filter
+			if !isSameBool(filter.Prerequisite, test.prerequisite) || !isSameBool(filter.Breaking, test.breaking) {
+				t.Errorf("expected prerequisite %v and breaking %v, got %v and %v", test.prerequisite, test.breaking, filter.Prerequisite, filter.Breaking)
+			}
+			if filter.After != test.after || filter.Before != test.before {
+				t.Errorf("expected bounds %s and %s, got %s and %s", test.after, test.before, filter.After, filter.Before)
+			}
+		})
+	}
+}
+
+func isSameBool(a *bool, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestGetReleasesPaginatesFilteredReleases(t *testing.T) {
+	releaseNoteService := &fakeReleaseNoteService{releases: []*common.Release{{TagName: "v0.7.1"}, {TagName: "v0.7.0"}, {TagName: "v0.6.0"}}}
+	tests := []struct {
+		query string
+		tags  []string
+	}{
+		{query: "breaking=true&size=2", tags: []string{"v0.7.1", "v0.7.0"}},
+		{query: "breaking=true&offset=2&size=2", tags: []string{"v0.6.0"}},
+		{query: "breaking=true&offset=5&size=2", tags: []string{}},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		newTestRestHandler(releaseNoteService).GetReleases(recorder, httptest.NewRequest(http.MethodGet, "/release/notes?"+test.query, nil))
+		var releases []*common.Release
+		decodeTestResponse(t, recorder, &releases)
+		if len(releases) != len(test.tags) {
+			t.Errorf("expected %v for %s, got %d releases", test.tags, test.query, len(releases))
+			continue
+		}
+		for i, release := range releases {
+			if release.TagName != test.tags[i] {
+				t.Errorf("expected %v for %s, got %s at %d", test.tags, test.query, release.TagName, i)
+			}
+		}
+	}
+}
+
+func TestReleaseWebhookHandlerUnwrapsFormDelivery(t *testing.T) {
+	const secret = "It's a Secret to Everybody"
+	const payload = `{"action": "published", "release": {"tag_name": "v0.7.1"}}`
+	tests := []struct {
+		name        string
+		eventType   string
+		contentType string
+		body        string
+		status      int
+		formEncoded bool
+	}{
+		{name: "json release event", eventType: pkg.EventTypeRelease, contentType: "application/json", body: payload, status: http.StatusOK},
+		{name: "form release event", eventType: pkg.EventTypeRelease, contentType: WebhookFormContentType, body: "payload=" + url.QueryEscape(payload), status: http.StatusOK, formEncoded: true},
+		{name: "form ping", eventType: pkg.EventTypePing, contentType: WebhookFormContentType + "; charset=utf-8", body: "payload=" + url.QueryEscape(payload), status: http.StatusOK, formEncoded: true},
+		{name: "form without payload", eventType: pkg.EventTypeRelease, contentType: WebhookFormContentType, body: "release=v0.7.1", status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitHubClient := &util.GitHubClient{GitHubConfig: &util.GitHubConfig{
+				GitHubSecretValidator:    pkg.SECRET_VALIDATOR_SHA256,
+				GitHubWebhookSecret:      secret,
+				GitHubSignature256Header: "X-Hub-Signature-256",
+				GitHubEventTypeHeader:    "X-GitHub-Event",
+			}}
+			releaseNoteService := &fakeReleaseNoteService{}
+			handler := NewRestHandlerImpl(zap.NewNop().Sugar(), releaseNoteService, pkg.NewWebhookSecretValidatorImpl(zap.NewNop().Sugar(), gitHubClient), gitHubClient, nil, nil, nil, nil)
+			// the signature is computed over the body as delivered, form included
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(test.body))
+			req := httptest.NewRequest(http.MethodPost, "/release/webhook", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			req.Header.Set("X-GitHub-Event", test.eventType)
+			req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+			recorder := httptest.NewRecorder()
+
+			handler.ReleaseWebhookHandler(recorder, req)
+			if recorder.Code != test.status {
+				t.Fatalf("expected status %d, got %d", test.status, recorder.Code)
+			}
+			if test.status != http.StatusOK {
+				return
+			}
+			if string(releaseNoteService.webhookBody) != payload {
+				t.Errorf("expected the service to get the json payload, got %s", releaseNoteService.webhookBody)
+			}
+			if test.eventType == pkg.EventTypePing && releaseNoteService.formEncoded != test.formEncoded {
+				t.Errorf("expected the ping to be told the delivery was form encoded")
+			}
+		})
+	}
+}
+
+func TestGetModuleDisableImpactReportsServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "impact found", status: http.StatusOK},
+		{name: "unknown module", err: &util2.ApiError{HttpStatusCode: http.StatusNotFound, UserMessage: "module not found"}, status: http.StatusNotFound},
+		{name: "catalog unavailable", err: errors.New("github unavailable"), status: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/module/disable-impact?name=cicd", nil), map[string]string{"name": "cicd"})
+			recorder := httptest.NewRecorder()
+			newTestRestHandler(&fakeReleaseNoteService{err: test.err}).GetModuleDisableImpact(recorder, req)
+			if recorder.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetEffectiveConfigDoesNotEchoAdminToken(t *testing.T) {
+	const adminToken = "effective-config-admin-token"
+	handler := NewRestHandlerImpl(zap.NewNop().Sugar(), &fakeReleaseNoteService{}, nil, nil, nil, &util.AdminConfig{AdminApiToken: adminToken}, nil, nil)
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+		req.Header.Set("Authorization", authorization)
+		recorder := httptest.NewRecorder()
+		handler.GetEffectiveConfig(recorder, req)
+		return recorder
+	}
+
+	if recorder := serve("Bearer wrong-token"); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected an invalid admin token to be refused, got %d", recorder.Code)
+	}
+	recorder := serve("Bearer " + adminToken)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected the effective config, got %d", recorder.Code)
+	}
+	if strings.Contains(recorder.Body.String(), adminToken) {
+		t.Errorf("expected the admin token not to be part of the response, got %s", recorder.Body.String())
+	}
+	effectiveConfig := &common.EffectiveConfig{}
+	decodeTestResponse(t, recorder, effectiveConfig)
+	if effectiveConfig.GitHubToken != pkg.RedactedValue || effectiveConfig.GitHubWebhookSecret != pkg.RedactedValue {
+		t.Errorf("expected the secrets to be shown as redacted, got %q and %q", effectiveConfig.GitHubToken, effectiveConfig.GitHubWebhookSecret)
+	}
+}
+
+func TestGetReleasesReportsInvalidPagination(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestRestHandler(&fakeReleaseNoteService{}).GetReleases(recorder, httptest.NewRequest(http.MethodGet, "/release/notes?offset=-1&size=-2", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+	response := struct {
+		Errors []struct {
+			UserMessage []*common.FieldError `json:"userMessage"`
+		} `json:"errors"`
+	}{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || len(response.Errors) != 1 {
+		t.Fatalf("unexpected response %s, %v", recorder.Body.String(), err)
+	}
+	fieldErrors := response.Errors[0].UserMessage
+	if len(fieldErrors) != 2 || fieldErrors[0].Field != "offset" || fieldErrors[1].Field != "size" {
+		t.Errorf("expected offset and size to be reported, got %s", recorder.Body.String())
+	}
+}
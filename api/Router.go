@@ -2,25 +2,55 @@ package api
 
 import (
 	"encoding/json"
+	util "github.com/devtron-labs/central-api/client"
 	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"net/http"
 )
 
 type MuxRouter struct {
-	logger      *zap.SugaredLogger
-	Router      *mux.Router
-	restHandler RestHandler
+	logger              *zap.SugaredLogger
+	Router              *mux.Router
+	restHandler         RestHandler
+	compressor          *responseCompressor
+	clientVersionConfig *util.ClientVersionConfig
+	signer              *responseSigner
+	// requestMetrics is nil when the request metrics are disabled
+	requestMetrics *requestMetrics
 }
 
-func NewMuxRouter(logger *zap.SugaredLogger, restHandler RestHandler) *MuxRouter {
-	return &MuxRouter{logger: logger, Router: mux.NewRouter(), restHandler: restHandler}
+func NewMuxRouter(logger *zap.SugaredLogger, restHandler RestHandler, responseConfig *util.ResponseConfig, clientVersionConfig *util.ClientVersionConfig,
+	responseSigningConfig *util.ResponseSigningConfig, metricsConfig *util.MetricsConfig) *MuxRouter {
+	router := &MuxRouter{logger: logger, Router: mux.NewRouter(), restHandler: restHandler, compressor: newResponseCompressor(responseConfig),
+		clientVersionConfig: clientVersionConfig, signer: newResponseSigner(responseSigningConfig)}
+	if metricsConfig.RequestMetricsEnabled {
+		router.requestMetrics = newRequestMetrics(metricsConfig)
+	}
+	return router
+}
+
+// ReloadConfig reads the reloadable config of the router again, like the client version policy
+func (r MuxRouter) ReloadConfig() error {
+	return r.clientVersionConfig.Reload()
 }
 
 func (r MuxRouter) Init() {
 	r.Router.StrictSlash(true)
-	//r.Router.Handle("/metrics", promhttp.Handler())
+	r.Router.Use(r.countRequests)
+	r.Router.Use(r.instrumentRequests)
+	r.Router.Use(r.enforceClientVersion)
+	if r.requestMetrics != nil {
+		for _, collector := range []prometheus.Collector{r.requestMetrics.duration, r.requestMetrics.responseSize} {
+			err := util2.DefaultMetricsRegistry.Register(collector)
+			if err != nil {
+				r.logger.Errorw("error in registering request metrics", "err", err)
+			}
+		}
+	}
+	r.Router.Path("/metrics").Handler(util2.MetricsHandler()).Methods("GET")
 	r.Router.Path("/health").HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(200)
@@ -35,13 +65,63 @@ func (r MuxRouter) Init() {
 		_, _ = writer.Write(b)
 	})
 
-	r.Router.Path("/release/notes").HandlerFunc(r.restHandler.GetReleases).Methods("GET")
+	r.Router.Path("/status").HandlerFunc(r.restHandler.GetStatus).Methods("GET")
+	r.Router.Path("/signing-keys").HandlerFunc(r.getSigningKeys).Methods("GET")
+	r.Router.Path("/release/notes").HandlerFunc(r.cacheable(r.restHandler.GetReleases)).Methods("GET", "HEAD")
+	r.Router.Path("/release/notes/latest").HandlerFunc(r.cacheable(r.signed(r.restHandler.GetLatestRelease))).Methods("GET", "HEAD")
+	r.Router.Path("/release/notes/meta").HandlerFunc(r.restHandler.GetReleaseSourceMeta).Methods("GET")
+	r.Router.Path("/release/notes/latest-patch").HandlerFunc(r.restHandler.GetLatestPatch).Methods("GET")
+	r.Router.Path("/release/notes/prerequisites").HandlerFunc(r.restHandler.GetPrerequisiteSummary).Methods("GET")
+	r.Router.Path("/release/notes/partitioned").HandlerFunc(r.restHandler.GetReleasesPartitioned).Methods("GET")
+	r.Router.Path("/release/notes/unacknowledged").HandlerFunc(r.restHandler.GetUnacknowledgedReleases).Methods("GET")
+	r.Router.Path("/release/notes/unacknowledged/count").HandlerFunc(r.restHandler.GetUnacknowledgedCount).Methods("GET")
+	r.Router.Path("/release/notes/recent").HandlerFunc(r.restHandler.GetRecentReleases).Methods("GET")
+	r.Router.Path("/repository/readme").HandlerFunc(r.restHandler.GetRepositoryReadme).Methods("GET")
+	r.Router.Path("/release/notes/delta").HandlerFunc(r.restHandler.GetReleasesDelta).Methods("GET")
+	r.Router.Path("/release/notes/frequency").HandlerFunc(r.restHandler.GetReleaseFrequency).Methods("GET")
+	r.Router.Path("/release/notes/query").HandlerFunc(r.cacheable(r.restHandler.QueryReleases)).Methods("GET", "HEAD")
+	r.Router.Path("/release-notes/changelog.md").HandlerFunc(r.restHandler.GetChangelogDocument).Methods("GET")
+	r.Router.Path("/release/notes/merged").HandlerFunc(r.restHandler.GetMergedChangelog).Methods("GET")
+	r.Router.Path("/release/notes/by-minor").HandlerFunc(r.restHandler.GetReleasesGroupedByMinor).Methods("GET")
+	r.Router.Path("/release-notes/tags").HandlerFunc(r.cacheable(r.restHandler.GetReleaseTags)).Methods("GET", "HEAD")
+	r.Router.Path("/release-notes/by-tags").HandlerFunc(r.restHandler.GetReleasesByTags).Methods("GET")
+	r.Router.Path("/release-note/{tag}/components").HandlerFunc(r.cacheable(r.restHandler.GetReleaseComponents)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}/images").HandlerFunc(r.cacheable(r.restHandler.GetReleaseImages)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}/feature-flags").HandlerFunc(r.cacheable(r.restHandler.GetReleaseFeatureFlags)).Methods("GET", "HEAD")
+	r.Router.Path("/feature-flags").HandlerFunc(r.cacheable(r.restHandler.GetFeatureFlags)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}").HandlerFunc(r.cacheable(r.restHandler.GetReleaseByTag)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}/known-issues").HandlerFunc(r.cacheable(r.restHandler.GetReleaseKnownIssues)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}/attestations").HandlerFunc(r.cacheable(r.restHandler.GetReleaseAttestations)).Methods("GET", "HEAD")
+	r.Router.Path("/release-note/{tag}/assets/{assetName}/download").HandlerFunc(r.restHandler.DownloadReleaseAsset).Methods("GET", "HEAD")
+	r.Router.Path("/component-versions").HandlerFunc(r.restHandler.GetComponentVersions).Methods("GET")
+	r.Router.Path("/admin/release-lint").HandlerFunc(r.restHandler.GetReleaseLintReport).Methods("GET")
+	r.Router.Path("/admin/module-lint").HandlerFunc(r.restHandler.GetModuleLintReport).Methods("GET")
+	r.Router.Path("/admin/config").HandlerFunc(r.restHandler.GetEffectiveConfig).Methods("GET")
+	r.Router.Path("/admin/github/token-scopes").HandlerFunc(r.restHandler.GetTokenScopes).Methods("GET")
+	r.Router.Path("/admin/webhook-status").HandlerFunc(r.restHandler.GetWebhookStatus).Methods("GET")
+	r.Router.Path("/admin/webhook-dry-run").HandlerFunc(r.restHandler.DryRunWebhook).Methods("POST")
+	r.Router.Path("/admin/version-gaps").HandlerFunc(r.restHandler.GetVersionGaps).Methods("GET")
+	r.Router.Path("/support-policy").HandlerFunc(r.restHandler.GetSupportPolicy).Methods("GET")
+	r.Router.Path("/admin/support-policy/lines/{line}").HandlerFunc(r.restHandler.SetLineEolDate).Methods("PUT")
+	r.Router.Path("/admin/support-policy/lines/{line}").HandlerFunc(r.restHandler.RemoveLineEolDate).Methods("DELETE")
+	r.Router.Path("/admin/releases/rollouts").HandlerFunc(r.restHandler.GetReleaseRollouts).Methods("GET")
+	r.Router.Path("/admin/releases/{tag}/rollout").HandlerFunc(r.restHandler.SetRolloutPercentage).Methods("PUT")
+	r.Router.Path("/admin/releases/{tag}/block").HandlerFunc(r.restHandler.BlockRelease).Methods("PUT")
+	r.Router.Path("/admin/releases/{tag}/block").HandlerFunc(r.restHandler.UnblockRelease).Methods("DELETE")
+	r.Router.Path("/installations/check-in").HandlerFunc(r.restHandler.CheckIn).Methods("POST")
+	r.Router.Path("/admin/adoption-stats/modules").HandlerFunc(r.restHandler.GetModuleAdoptionStats).Methods("GET")
+	r.Router.Path("/release/check").HandlerFunc(r.signed(r.restHandler.CheckRelease)).Methods("GET")
 	r.Router.Path("/release/webhook").HandlerFunc(r.restHandler.ReleaseWebhookHandler).Methods("POST")
-	r.Router.Path("/modules").HandlerFunc(r.restHandler.GetModules).Methods("GET")
+	r.Router.Path("/modules").HandlerFunc(r.cacheable(r.restHandler.GetModules)).Methods("GET", "HEAD")
 	r.Router.Path("/dockerfileTemplate").HandlerFunc(r.restHandler.GetDockerfileTemplateMetadata).Methods("GET")
 	r.Router.Path("/buildpackMetadata").HandlerFunc(r.restHandler.GetBuildpackMetadata).Methods("GET")
-	r.Router.Path("/v2/modules").HandlerFunc(r.restHandler.GetModulesV2).Methods("GET")
+	r.Router.Path("/v2/modules").HandlerFunc(r.cacheable(r.restHandler.GetModulesV2)).Methods("GET", "HEAD")
 	r.Router.Path("/module").
 		Queries("name", "{name}").
-		HandlerFunc(r.restHandler.GetModuleByName).Methods("GET")
+		HandlerFunc(r.cacheable(r.restHandler.GetModuleByName)).Methods("GET", "HEAD")
+	r.Router.Path("/modules/new").HandlerFunc(r.restHandler.GetNewModulesBetween).Methods("GET")
+	r.Router.Path("/module/{name}/uninstall-info").HandlerFunc(r.restHandler.GetModuleUninstallInfo).Methods("GET")
+	r.Router.Path("/module/disable-impact").
+		Queries("name", "{name}").
+		HandlerFunc(r.restHandler.GetModuleDisableImpact).Methods("GET")
 }
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// processStartedAt and requestsServed back the status document
+var processStartedAt = time.Now().UTC()
+var requestsServed uint64
+
+func (r MuxRouter) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddUint64(&requestsServed, 1)
+		next.ServeHTTP(w, req)
+	})
+}
+
+// getBuildInfo reads the module version and vcs stamp embedded by the go toolchain
+func getBuildInfo() *common.BuildInfo {
+	buildInfo := &common.BuildInfo{GoVersion: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return buildInfo
+	}
+	buildInfo.Module = info.Main.Path
+	buildInfo.Version = info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			buildInfo.Revision = setting.Value
+		case "vcs.time":
+			buildInfo.BuiltAt = setting.Value
+		case "vcs.modified":
+			buildInfo.Modified = setting.Value == "true"
+		}
+	}
+	return buildInfo
+}
+
+var buildInfo = getBuildInfo()
+
+func (impl *RestHandlerImpl) GetStatus(w http.ResponseWriter, r *http.Request) {
+	setupResponse(&w, r)
+	status := &common.Status{
+		StartedAt:        processStartedAt,
+		UptimeSeconds:    int64(time.Since(processStartedAt).Seconds()),
+		RequestsServed:   atomic.LoadUint64(&requestsServed),
+		OperationalStats: impl.releaseNoteService.GetOperationalStats(),
+		Build:            buildInfo,
+	}
+	impl.WriteJsonResp(w, nil, status, http.StatusOK)
+	return
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+type AdminConfig struct {
+	// AdminApiToken is required by the admin apis, which are disabled when it is empty
+	AdminApiToken string `env:"ADMIN_API_TOKEN" envDefault:""`
+	// AdminStateFilePath keeps the admin state in a local file instead of the blob storage or the database
+	AdminStateFilePath string `env:"ADMIN_STATE_FILE_PATH" envDefault:""`
+}
+
+func NewAdminConfig(logger *zap.SugaredLogger) (*AdminConfig, error) {
+	cfg := &AdminConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing admin config", "err", err)
+		return &AdminConfig{}, err
+	}
+	return cfg, nil
+}
@@ -33,3 +33,37 @@ func NewBlobConfig(logger *zap.SugaredLogger) (*BlobConfigVariables, error) {
 	}
 	return cfg, nil
 }
+
+// NewBlobStorageRequest builds the request copying the source key to the destination key on the configured storage
+func (cfg *BlobConfigVariables) NewBlobStorageRequest(sourceKey string, destinationKey string) *blob_storage.BlobStorageRequest {
+	request := &blob_storage.BlobStorageRequest{
+		StorageType:    cfg.BlobStorageType,
+		SourceKey:      sourceKey,
+		DestinationKey: destinationKey,
+	}
+	switch cfg.BlobStorageType {
+	case blob_storage.BLOB_STORAGE_S3:
+		request.AwsS3BaseConfig = &blob_storage.AwsS3BaseConfig{
+			AccessKey:         cfg.S3AccessKey,
+			Passkey:           cfg.S3Passkey,
+			EndpointUrl:       cfg.S3EndpointUrl,
+			IsInSecure:        cfg.S3IsInSecure,
+			BucketName:        cfg.S3BucketName,
+			Region:            cfg.S3Region,
+			VersioningEnabled: cfg.S3VersioningEnabled,
+		}
+	case blob_storage.BLOB_STORAGE_AZURE:
+		request.AzureBlobBaseConfig = &blob_storage.AzureBlobBaseConfig{
+			AccountKey:        cfg.AzureAccountKey,
+			AccountName:       cfg.AzureAccountName,
+			Enabled:           cfg.AzureEnabled,
+			BlobContainerName: cfg.AzureBlobContainerName,
+		}
+	case blob_storage.BLOB_STORAGE_GCP:
+		request.GcpBlobBaseConfig = &blob_storage.GcpBlobBaseConfig{
+			CredentialFileJsonData: cfg.GcpCredentialFileJsonData,
+			BucketName:             cfg.GcpBucketName,
+		}
+	}
+	return request
+}
@@ -0,0 +1,107 @@
+package util
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+)
+
+type ClientVersionConfigVariables struct {
+	// MinClientVersions per endpoint group, like "release=0.6.0,module=0.6.0"
+	MinClientVersions []string `env:"CLIENT_MIN_VERSIONS" envDefault:"" envSeparator:","`
+	// DeprecatedClientVersions per endpoint group below which clients get a deprecation warning
+	DeprecatedClientVersions []string `env:"CLIENT_DEPRECATED_VERSIONS" envDefault:"" envSeparator:","`
+	ClientVersionRequired    bool     `env:"CLIENT_VERSION_REQUIRED" envDefault:"false"`
+	ClientUpgradeDocsLink    string   `env:"CLIENT_UPGRADE_DOCS_LINK" envDefault:"https://docs.devtron.ai/install/upgrade"`
+}
+
+// ClientVersionPolicy is the parsed client version config, a policy is never changed once read
+type ClientVersionPolicy struct {
+	ClientVersionConfig      *ClientVersionConfigVariables
+	MinClientVersions        map[string]*util2.SemanticVersion
+	DeprecatedClientVersions map[string]*util2.SemanticVersion
+}
+
+// ClientVersionConfig holds the client version policy, Reload reads it again from the environment
+type ClientVersionConfig struct {
+	logger *zap.SugaredLogger
+	mutex  sync.RWMutex
+	policy *ClientVersionPolicy
+}
+
+func NewClientVersionConfig(logger *zap.SugaredLogger) (*ClientVersionConfig, error) {
+	policy, err := readClientVersionPolicy(logger)
+	if err != nil {
+		return &ClientVersionConfig{}, err
+	}
+	return &ClientVersionConfig{logger: logger, policy: policy}, nil
+}
+
+// GetPolicy returns the current policy, it is read on every request
+func (cfg *ClientVersionConfig) GetPolicy() *ClientVersionPolicy {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	if cfg.policy == nil {
+		return &ClientVersionPolicy{ClientVersionConfig: &ClientVersionConfigVariables{}}
+	}
+	return cfg.policy
+}
+
+// Reload keeps the current policy when the one read is invalid
+func (cfg *ClientVersionConfig) Reload() error {
+	policy, err := readClientVersionPolicy(cfg.logger)
+	if err != nil {
+		return err
+	}
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.policy = policy
+	return nil
+}
+
+func readClientVersionPolicy(logger *zap.SugaredLogger) (*ClientVersionPolicy, error) {
+	cfg := &ClientVersionConfigVariables{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing client version config", "err", err)
+		return nil, err
+	}
+	minVersions, err := parseGroupVersions(cfg.MinClientVersions)
+	if err != nil {
+		logger.Errorw("error on parsing min client versions", "minClientVersions", cfg.MinClientVersions, "err", err)
+		return nil, err
+	}
+	deprecatedVersions, err := parseGroupVersions(cfg.DeprecatedClientVersions)
+	if err != nil {
+		logger.Errorw("error on parsing deprecated client versions", "deprecatedClientVersions", cfg.DeprecatedClientVersions, "err", err)
+		return nil, err
+	}
+	return &ClientVersionPolicy{
+		ClientVersionConfig:      cfg,
+		MinClientVersions:        minVersions,
+		DeprecatedClientVersions: deprecatedVersions,
+	}, nil
+}
+
+func parseGroupVersions(values []string) (map[string]*util2.SemanticVersion, error) {
+	versions := make(map[string]*util2.SemanticVersion)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid client version %q, expected <group>=<version>", value)
+		}
+		version, err := util2.ParseSemanticVersion(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client version %q, %v", value, err)
+		}
+		versions[strings.TrimSpace(parts[0])] = version
+	}
+	return versions, nil
+}
@@ -20,12 +20,15 @@ package util
 import (
 	"context"
 	"github.com/caarlos0/env"
+	logger2 "github.com/devtron-labs/central-api/internal/logger"
 	"github.com/google/go-github/github"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 	http2 "net/http"
 	"net/url"
 	"path"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -43,6 +46,11 @@ const (
 	GITHUB_HOST           = "github.com"
 )
 
+const (
+	ReleaseDisplayNameFromName = "name"
+	ReleaseDisplayNameFromTag  = "tag"
+)
+
 type GitConfig struct {
 	GitlabGroupId        string //local
 	GitlabGroupPath      string //local
@@ -67,25 +75,84 @@ type GitHubConfig struct {
 	GitHubWebhookSecret   string `env:"GITHUB_WEBHOOK_SECRET" envDefault:""`
 	GitHubEventTypeHeader string `env:"GITHUB_EVENT_TYPE_HEADER" envDefault:"X-GitHub-Event"`
 	GitHubSecretHeader    string `env:"GITHUB_SECRET_HEADER" envDefault:"X-Hub-Signature"`
-	GitHubSecretValidator string `env:"GITHUB_SECRET_VALIDATOR" envDefault:"SHA-1"`
+	GitHubSecretValidator string `env:"GITHUB_SECRET_VALIDATOR" envDefault:"SHA-256"`
+	GitHubYankedFilePath  string `env:"GITHUB_YANKED_FILE_PATH" envDefault:"yanked.json"`
+	// GitHubSignature256Header carries the hmac sha256 of the webhook payload checked by the SHA-256 validator
+	GitHubSignature256Header string `env:"GITHUB_SIGNATURE_256_HEADER" envDefault:"X-Hub-Signature-256"`
+	// GitHubResolveCommitSha resolves the commit sha of every release tag on sync, it costs extra calls per release
+	GitHubResolveCommitSha bool `env:"GITHUB_RESOLVE_COMMIT_SHA" envDefault:"false"`
+	// GitHubWebhookRateLimit is per second, zero disables it
+	GitHubWebhookRateLimit float64 `env:"GITHUB_WEBHOOK_RATE_LIMIT" envDefault:"0"`
+	GitHubWebhookRateBurst int     `env:"GITHUB_WEBHOOK_RATE_BURST" envDefault:"5"`
+	// ReleaseDisplayName is "name", falling back to the tag when the name is empty, or "tag"
+	ReleaseDisplayName string `env:"RELEASE_DISPLAY_NAME" envDefault:"name"`
+	// LatestTagAlias is the keyword accepted in place of a tag by tag lookups, it resolves to the latest release
+	LatestTagAlias string `env:"LATEST_TAG_ALIAS" envDefault:"latest"`
+	// GitHubChangelogRepos are in priority order, defaults to GitHubRepo alone
+	GitHubChangelogRepos       []string `env:"GITHUB_CHANGELOG_REPOS" envDefault:"" envSeparator:","`
+	GitHubChangelogParallelism int      `env:"GITHUB_CHANGELOG_PARALLELISM" envDefault:"4"`
+	// GitHubReadmeEnabled serves the rendered README of GitHubRepo, cached for GitHubReadmeTTL
+	GitHubReadmeEnabled bool          `env:"GITHUB_README_ENABLED" envDefault:"false"`
+	GitHubReadmeTTL     time.Duration `env:"GITHUB_README_TTL" envDefault:"1h"`
+	// GitHubReleasesPerPage is the page size the releases are listed with, github allows at most 100
+	GitHubReleasesPerPage int `env:"GITHUB_RELEASES_PER_PAGE" envDefault:"100"`
+	// GitHubTagLookupTimeout bounds the github lookup of a tag missing from the cache
+	GitHubTagLookupTimeout     time.Duration `env:"GITHUB_TAG_LOOKUP_TIMEOUT" envDefault:"5s"`
+	GitHubTagLookupNotFoundTTL time.Duration `env:"GITHUB_TAG_LOOKUP_NOT_FOUND_TTL" envDefault:"10m"`
 }
 
 type GitHubClient struct {
 	GitHubClient *github.Client
 	GitHubConfig *GitHubConfig
+	// HttpClient is the authenticated client under GitHubClient, for the calls needing the raw response
+	HttpClient *http2.Client
+	// requestObserver holds the GitHubRequestObserver told about every request made to github
+	requestObserver atomic.Value
+	// logger is named after the component so that the github calls can be filtered apart from the service ones
+	logger *zap.SugaredLogger
+}
+
+// GitHubRequestObserver gets no response when err is a transport error
+type GitHubRequestObserver func(duration time.Duration, resp *http2.Response, err error)
+
+// SetRequestObserver sets the observer of the requests made to github, replacing the previous one
+func (impl *GitHubClient) SetRequestObserver(observer GitHubRequestObserver) {
+	impl.requestObserver.Store(observer)
+}
+
+// observedTransport times the requests going through it for the observer of the client
+type observedTransport struct {
+	next   http2.RoundTripper
+	client *GitHubClient
+}
+
+func (transport *observedTransport) RoundTrip(req *http2.Request) (*http2.Response, error) {
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil {
+		transport.client.logger.Warnw("github request failed", "method", req.Method, "path", req.URL.Path, "err", err)
+	} else {
+		transport.client.logger.Debugw("github request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "duration", time.Since(start))
+	}
+	if observer, ok := transport.client.requestObserver.Load().(GitHubRequestObserver); ok && observer != nil {
+		observer(time.Since(start), resp, err)
+	}
+	return resp, err
 }
 
 /* #nosec */
 func NewGitHubClient(logger *zap.SugaredLogger) (*GitHubClient, error) {
+	logger = logger.Named(logger2.GithubLoggerName)
 	cfg := &GitHubConfig{}
 	err := env.Parse(cfg)
 	if err != nil {
 		logger.Error("err", err)
-		return &GitHubClient{}, err
+		return &GitHubClient{logger: logger}, err
 	}
 	ctx := context.Background()
+	gitHubClient := &GitHubClient{GitHubConfig: cfg, logger: logger}
 	httpTransport := &http2.Transport{}
-	httpClient := &http2.Client{Transport: httpTransport}
+	httpClient := &http2.Client{Transport: &observedTransport{next: httpTransport, client: gitHubClient}}
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: cfg.GitHubToken},
 	)
@@ -104,9 +171,7 @@ func NewGitHubClient(logger *zap.SugaredLogger) (*GitHubClient, error) {
 		hostUrl.Path = path.Join(hostUrl.Path, GITHUB_API_V3)
 		client, err = github.NewEnterpriseClient(hostUrl.String(), hostUrl.String(), tc)
 	}
-	gitHubClient := &GitHubClient{
-		GitHubClient: client,
-		GitHubConfig: cfg,
-	}
+	gitHubClient.GitHubClient = client
+	gitHubClient.HttpClient = tc
 	return gitHubClient, err
 }
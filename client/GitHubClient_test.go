@@ -0,0 +1,51 @@
+package util
+
+import (
+	"bytes"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitHubClientLogsWithNamedLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Setenv("GITHUB_HOST", server.URL)
+	var logs bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&logs), zapcore.DebugLevel)
+	client, err := NewGitHubClient(zap.New(core).Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.HttpClient.Get(server.URL + "/api/v3/repos/devtron-labs/devtron/releases")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	resp.Body.Close()
+	server.Close()
+	if _, err = client.HttpClient.Get(server.URL + "/api/v3/repos/devtron-labs/devtron/releases"); err == nil {
+		t.Fatalf("expected the request to the closed server to fail")
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	var requests, failures int
+	for _, line := range lines {
+		if !strings.Contains(line, `"logger":"github"`) {
+			t.Errorf("expected every log of the client to be named github, got %s", line)
+		}
+		if strings.Contains(line, `"msg":"github request"`) && strings.Contains(line, `"status":404`) {
+			requests++
+		}
+		if strings.Contains(line, `"msg":"github request failed"`) {
+			failures++
+		}
+	}
+	if requests != 1 || failures != 1 {
+		t.Errorf("expected the request and the failed one to be logged, got %s", logs.String())
+	}
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+type MetricsConfig struct {
+	// RequestMetricsEnabled records the latency and response size of every request per route
+	RequestMetricsEnabled bool `env:"REQUEST_METRICS_ENABLED" envDefault:"true"`
+	// LatencyBuckets are in seconds
+	LatencyBuckets []float64 `env:"REQUEST_METRICS_LATENCY_BUCKETS" envDefault:"0.001,0.005,0.01,0.025,0.05,0.1,0.25,0.5,1,2.5,5,10,30" envSeparator:","`
+	// SizeBuckets are in bytes
+	SizeBuckets []float64 `env:"REQUEST_METRICS_SIZE_BUCKETS" envDefault:"256,1024,4096,16384,65536,262144,1048576,4194304" envSeparator:","`
+	// ExcludedRoutes are route patterns left out of the request metrics, like the probes
+	ExcludedRoutes []string `env:"REQUEST_METRICS_EXCLUDED_ROUTES" envDefault:"/metrics,/health" envSeparator:","`
+}
+
+func NewMetricsConfig(logger *zap.SugaredLogger) (*MetricsConfig, error) {
+	cfg := &MetricsConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing metrics config", "err", err)
+		return &MetricsConfig{}, err
+	}
+	for name, buckets := range map[string][]float64{"latency": cfg.LatencyBuckets, "size": cfg.SizeBuckets} {
+		for i := 1; i < len(buckets); i++ {
+			if buckets[i] <= buckets[i-1] {
+				err = fmt.Errorf("%s buckets should be increasing, got %v", name, buckets)
+				logger.Errorw("error on parsing metrics config", "err", err)
+				return &MetricsConfig{}, err
+			}
+		}
+	}
+	return cfg, nil
+}
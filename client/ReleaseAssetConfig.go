@@ -0,0 +1,25 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+type ReleaseAssetConfig struct {
+	// ProxyEnabled serves release assets for installations which can't reach the github asset cdn
+	ProxyEnabled bool `env:"RELEASE_ASSET_PROXY_ENABLED" envDefault:"true"`
+	// MaxSize of a proxied asset in bytes, zero removes the bound
+	MaxSize int64 `env:"RELEASE_ASSET_MAX_SIZE" envDefault:"104857600"`
+	// CacheDir keeps downloaded assets on disk, empty streams every download from github
+	CacheDir string `env:"RELEASE_ASSET_CACHE_DIR" envDefault:""`
+}
+
+func NewReleaseAssetConfig(logger *zap.SugaredLogger) (*ReleaseAssetConfig, error) {
+	cfg := &ReleaseAssetConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release asset config", "err", err)
+		return &ReleaseAssetConfig{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ReleaseBodyConfig struct {
+	// FallbackBody replaces an empty release body, empty keeps the body empty
+	FallbackBody string `env:"RELEASE_FALLBACK_BODY" envDefault:"See the release page for details."`
+	// MaxBodyBytes zero keeps bodies as they are
+	MaxBodyBytes int `env:"RELEASE_MAX_BODY_BYTES" envDefault:"262144"`
+	// ImageDimensionsEnabled annotates the images of release bodies with their width and height
+	ImageDimensionsEnabled bool          `env:"RELEASE_IMAGE_DIMENSIONS_ENABLED" envDefault:"false"`
+	ImageFetchTimeout      time.Duration `env:"RELEASE_IMAGE_FETCH_TIMEOUT" envDefault:"5s"`
+	// ImageFailureTTL is how long an image which couldn't be read is left before it is fetched again
+	ImageFailureTTL time.Duration `env:"RELEASE_IMAGE_FAILURE_TTL" envDefault:"1h"`
+	// DefaultUpgradeDuration is counted for releases without upgrade-duration marker
+	DefaultUpgradeDuration time.Duration `env:"RELEASE_DEFAULT_UPGRADE_DURATION" envDefault:"10m"`
+}
+
+func NewReleaseBodyConfig(logger *zap.SugaredLogger) (*ReleaseBodyConfig, error) {
+	cfg := &ReleaseBodyConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release body config", "err", err)
+		return &ReleaseBodyConfig{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ReleaseCacheConfig struct {
+	// RefreshInterval zero disables the background refresh
+	RefreshInterval time.Duration `env:"RELEASE_REFRESH_INTERVAL" envDefault:"0s"`
+	// TTL zero keeps the refresh interval, otherwise releases are refreshed RefreshLeadTime before they expire
+	TTL             time.Duration `env:"RELEASE_CACHE_TTL" envDefault:"0s"`
+	RefreshLeadTime time.Duration `env:"RELEASE_CACHE_REFRESH_LEAD_TIME" envDefault:"30s"`
+	// RefreshTimeout bounds a single background refresh attempt
+	RefreshTimeout     time.Duration `env:"RELEASE_REFRESH_TIMEOUT" envDefault:"60s"`
+	MergedChangelogTTL time.Duration `env:"MERGED_CHANGELOG_TTL" envDefault:"10m"`
+	// DerivedViewCacheEnabled keeps views computed from the releases until the releases change
+	DerivedViewCacheEnabled bool          `env:"DERIVED_VIEW_CACHE_ENABLED" envDefault:"true"`
+	PersistRetries          int           `env:"RELEASE_PERSIST_RETRIES" envDefault:"3"`
+	PersistBackoff          time.Duration `env:"RELEASE_PERSIST_BACKOFF" envDefault:"500ms"`
+	// PersistRevertOnFailure restores the cached release of a webhook update which could not be persisted
+	PersistRevertOnFailure bool `env:"RELEASE_PERSIST_REVERT_ON_FAILURE" envDefault:"false"`
+}
+
+func NewReleaseCacheConfig(logger *zap.SugaredLogger) (*ReleaseCacheConfig, error) {
+	cfg := &ReleaseCacheConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release cache config", "err", err)
+		return &ReleaseCacheConfig{}, err
+	}
+	if cfg.TTL > 0 && (cfg.RefreshLeadTime < 0 || cfg.RefreshLeadTime >= cfg.TTL) {
+		err = fmt.Errorf("release cache refresh lead time %s should be less than the ttl %s", cfg.RefreshLeadTime, cfg.TTL)
+		logger.Errorw("error on parsing release cache config", "err", err)
+		return &ReleaseCacheConfig{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"regexp"
+	"strings"
+)
+
+type ReleaseChannelConfigVariables struct {
+	// ChannelPatterns maps tags to channels, like "edge=^nightly-;rc=-rc\.[0-9]+$", separated by semicolons
+	ChannelPatterns []string `env:"RELEASE_CHANNEL_PATTERNS" envDefault:"" envSeparator:";"`
+	// EdgeRetention zero keeps all edge releases
+	EdgeRetention int `env:"RELEASE_EDGE_RETENTION" envDefault:"10"`
+}
+
+// ReleaseChannelPattern assigns the channel to the releases whose tag matches the pattern
+type ReleaseChannelPattern struct {
+	Channel string
+	Pattern *regexp.Regexp
+}
+
+type ReleaseChannelConfig struct {
+	ReleaseChannelConfig *ReleaseChannelConfigVariables
+	// ChannelPatterns are in configured order, the first match wins
+	ChannelPatterns []*ReleaseChannelPattern
+}
+
+func NewReleaseChannelConfig(logger *zap.SugaredLogger) (*ReleaseChannelConfig, error) {
+	cfg := &ReleaseChannelConfigVariables{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release channel config", "err", err)
+		return &ReleaseChannelConfig{}, err
+	}
+	channelPatterns, err := parseChannelPatterns(cfg.ChannelPatterns)
+	if err != nil {
+		logger.Errorw("error on parsing release channel patterns", "channelPatterns", cfg.ChannelPatterns, "err", err)
+		return &ReleaseChannelConfig{}, err
+	}
+	return &ReleaseChannelConfig{
+		ReleaseChannelConfig: cfg,
+		ChannelPatterns:      channelPatterns,
+	}, nil
+}
+
+func parseChannelPatterns(values []string) ([]*ReleaseChannelPattern, error) {
+	var channelPatterns []*ReleaseChannelPattern
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 || len(strings.TrimSpace(parts[1])) == 0 {
+			return nil, fmt.Errorf("invalid channel pattern %q, expected <channel>=<regex>", value)
+		}
+		pattern, err := regexp.Compile(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel pattern %q, %v", value, err)
+		}
+		channelPatterns = append(channelPatterns, &ReleaseChannelPattern{Channel: strings.ToLower(strings.TrimSpace(parts[0])), Pattern: pattern})
+	}
+	return channelPatterns, nil
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+type ReleaseLintConfig struct {
+	// BreakingChangeHeuristics suggest a breaking change in a body without the breaking changes marker
+	BreakingChangeHeuristics []string `env:"RELEASE_LINT_BREAKING_CHANGE_HEURISTICS" envDefault:"breaking change,breaking:,action required,must run migration" envSeparator:","`
+	// StrictWebhook rejects release bodies with malformed prerequisite markers instead of logging them
+	StrictWebhook bool `env:"RELEASE_LINT_STRICT_WEBHOOK" envDefault:"false"`
+}
+
+func NewReleaseLintConfig(logger *zap.SugaredLogger) (*ReleaseLintConfig, error) {
+	cfg := &ReleaseLintConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release lint config", "err", err)
+		return &ReleaseLintConfig{}, err
+	}
+	return cfg, nil
+}
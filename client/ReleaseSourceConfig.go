@@ -0,0 +1,46 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	ReleaseSourceGithub = "github"
+	ReleaseSourceStatic = "static"
+	ReleaseSourceGitlab = "gitlab"
+)
+
+type ReleaseSourceConfig struct {
+	// ReleaseSource is where releases are fetched from, "github", "static" or "gitlab"
+	ReleaseSource string `env:"RELEASE_SOURCE" envDefault:"github"`
+	// StaticReleasesLocation is the url or file path of the releases json
+	StaticReleasesLocation string `env:"RELEASE_SOURCE_STATIC_LOCATION" envDefault:""`
+	GitlabURL              string `env:"RELEASE_SOURCE_GITLAB_URL" envDefault:"https://gitlab.com"`
+	GitlabProject          string `env:"RELEASE_SOURCE_GITLAB_PROJECT" envDefault:""`
+	GitlabToken            string `env:"RELEASE_SOURCE_GITLAB_TOKEN" envDefault:""`
+	// BundledPrimingEnabled fills an empty cache with the bundled releases when the fetch on startup fails
+	BundledPrimingEnabled bool   `env:"RELEASE_BUNDLED_PRIMING_ENABLED" envDefault:"true"`
+	BundledReleasesPath   string `env:"RELEASE_BUNDLED_PATH" envDefault:""`
+	// BootstrapSnapshotURL is a releases json with a .sha256 companion, tried before the bundled releases
+	BootstrapSnapshotURL string `env:"BOOTSTRAP_SNAPSHOT_URL" envDefault:""`
+	// FallbackGitHubOrg and FallbackGitHubRepo name a mirror repo, exclusive with FallbackSnapshotURL
+	FallbackGitHubOrg   string `env:"RELEASE_FALLBACK_GITHUB_ORG" envDefault:""`
+	FallbackGitHubRepo  string `env:"RELEASE_FALLBACK_GITHUB_REPO" envDefault:""`
+	FallbackSnapshotURL string `env:"RELEASE_FALLBACK_SNAPSHOT_URL" envDefault:""`
+	FallbackOnNotFound  bool   `env:"RELEASE_FALLBACK_ON_NOT_FOUND" envDefault:"true"`
+	// FallbackOnRetriesExhausted reads the fallback once every retry on the primary source failed
+	FallbackOnRetriesExhausted bool          `env:"RELEASE_FALLBACK_ON_RETRIES_EXHAUSTED" envDefault:"true"`
+	FallbackRecoveryInterval   time.Duration `env:"RELEASE_FALLBACK_RECOVERY_INTERVAL" envDefault:"5m"`
+}
+
+func NewReleaseSourceConfig(logger *zap.SugaredLogger) (*ReleaseSourceConfig, error) {
+	cfg := &ReleaseSourceConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing release source config", "err", err)
+		return &ReleaseSourceConfig{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+type ResponseConfig struct {
+	// GzipEnabled compresses cacheable responses for clients accepting gzip
+	GzipEnabled bool `env:"RESPONSE_GZIP_ENABLED" envDefault:"true"`
+	// GzipMinBytes is the smallest response body compressed, smaller ones are not worth it
+	GzipMinBytes  int `env:"RESPONSE_GZIP_MIN_BYTES" envDefault:"1024"`
+	GzipCacheSize int `env:"RESPONSE_GZIP_CACHE_SIZE" envDefault:"32"`
+}
+
+func NewResponseConfig(logger *zap.SugaredLogger) (*ResponseConfig, error) {
+	cfg := &ResponseConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing response config", "err", err)
+		return &ResponseConfig{}, err
+	}
+	return cfg, nil
+}
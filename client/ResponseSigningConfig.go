@@ -0,0 +1,86 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type ResponseSigningConfigVariables struct {
+	// SigningEnabled signs the responses installers rely on with a detached JWS
+	SigningEnabled bool   `env:"RESPONSE_SIGNING_ENABLED" envDefault:"false"`
+	SigningKeyId   string `env:"RESPONSE_SIGNING_KEY_ID" envDefault:""`
+	// SigningPrivateKey is the base64 encoded ed25519 seed or private key
+	SigningPrivateKey string `env:"RESPONSE_SIGNING_PRIVATE_KEY" envDefault:""`
+	// RetiredPublicKeys are still published after a rotation, like "kid-1=<base64 public key>"
+	RetiredPublicKeys  []string `env:"RESPONSE_SIGNING_RETIRED_PUBLIC_KEYS" envDefault:"" envSeparator:","`
+	SignatureCacheSize int      `env:"RESPONSE_SIGNATURE_CACHE_SIZE" envDefault:"32"`
+}
+
+type ResponseSigningConfig struct {
+	ResponseSigningConfig *ResponseSigningConfigVariables
+	PrivateKey            ed25519.PrivateKey
+	// PublicKeys holds the public key of every published key id, the current one included
+	PublicKeys map[string]ed25519.PublicKey
+}
+
+func NewResponseSigningConfig(logger *zap.SugaredLogger) (*ResponseSigningConfig, error) {
+	cfg := &ResponseSigningConfigVariables{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing response signing config", "err", err)
+		return &ResponseSigningConfig{}, err
+	}
+	signingConfig := &ResponseSigningConfig{ResponseSigningConfig: cfg, PublicKeys: make(map[string]ed25519.PublicKey)}
+	if !cfg.SigningEnabled {
+		return signingConfig, nil
+	}
+	if len(cfg.SigningKeyId) == 0 {
+		err = fmt.Errorf("signing key id is required when response signing is enabled")
+		logger.Errorw("error on parsing response signing config", "err", err)
+		return &ResponseSigningConfig{}, err
+	}
+	signingConfig.PrivateKey, err = parseSigningPrivateKey(cfg.SigningPrivateKey)
+	if err != nil {
+		logger.Errorw("error on parsing response signing private key", "err", err)
+		return &ResponseSigningConfig{}, err
+	}
+	signingConfig.PublicKeys[cfg.SigningKeyId] = signingConfig.PrivateKey.Public().(ed25519.PublicKey)
+	for _, value := range cfg.RetiredPublicKeys {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			err = fmt.Errorf("invalid retired public key %q, expected <kid>=<base64 public key>", value)
+			logger.Errorw("error on parsing response signing config", "err", err)
+			return &ResponseSigningConfig{}, err
+		}
+		publicKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			err = fmt.Errorf("invalid retired public key of kid %s", parts[0])
+			logger.Errorw("error on parsing response signing config", "err", err)
+			return &ResponseSigningConfig{}, err
+		}
+		signingConfig.PublicKeys[strings.TrimSpace(parts[0])] = publicKey
+	}
+	return signingConfig, nil
+}
+
+func parseSigningPrivateKey(value string) (ed25519.PrivateKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing private key, %v", err)
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	}
+	return nil, fmt.Errorf("invalid signing private key, expected %d or %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize)
+}
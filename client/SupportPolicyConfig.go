@@ -0,0 +1,61 @@
+package util
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const EolDateLayout = "2006-01-02"
+
+type SupportPolicyConfigVariables struct {
+	SupportedMinorLines int `env:"SUPPORTED_MINOR_LINES" envDefault:"3"`
+	// EolDates per minor line, like "0.5=2025-06-30,0.4=2024-12-31"
+	EolDates          []string      `env:"SUPPORT_POLICY_EOL_DATES" envDefault:"" envSeparator:","`
+	EolWarningHorizon time.Duration `env:"SUPPORT_POLICY_EOL_WARNING_HORIZON" envDefault:"720h"`
+}
+
+type SupportPolicyConfig struct {
+	SupportPolicyConfig *SupportPolicyConfigVariables
+	EolDates            map[string]time.Time
+}
+
+func NewSupportPolicyConfig(logger *zap.SugaredLogger) (*SupportPolicyConfig, error) {
+	cfg := &SupportPolicyConfigVariables{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing support policy config", "err", err)
+		return &SupportPolicyConfig{}, err
+	}
+	eolDates, err := parseEolDates(cfg.EolDates)
+	if err != nil {
+		logger.Errorw("error on parsing support policy eol dates", "eolDates", cfg.EolDates, "err", err)
+		return &SupportPolicyConfig{}, err
+	}
+	return &SupportPolicyConfig{
+		SupportPolicyConfig: cfg,
+		EolDates:            eolDates,
+	}, nil
+}
+
+func parseEolDates(values []string) (map[string]time.Time, error) {
+	eolDates := make(map[string]time.Time)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid eol date %q, expected <line>=<yyyy-mm-dd>", value)
+		}
+		eolDate, err := time.Parse(EolDateLayout, strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid eol date %q, %v", value, err)
+		}
+		eolDates[strings.TrimPrefix(strings.TrimSpace(parts[0]), "v")] = eolDate
+	}
+	return eolDates, nil
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+	"time"
+)
+
+type TelemetryConfig struct {
+	// MaxInstallations evicts the least recently seen installation first
+	MaxInstallations int           `env:"TELEMETRY_MAX_INSTALLATIONS" envDefault:"10000"`
+	AdoptionWindow   time.Duration `env:"TELEMETRY_ADOPTION_WINDOW" envDefault:"168h"`
+}
+
+func NewTelemetryConfig(logger *zap.SugaredLogger) (*TelemetryConfig, error) {
+	cfg := &TelemetryConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		logger.Errorw("error on parsing telemetry config", "err", err)
+		return &TelemetryConfig{}, err
+	}
+	return cfg, nil
+}
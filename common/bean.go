@@ -1,6 +1,9 @@
 package common
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Response struct {
 	Code   int         `json:"code,omitempty"`
@@ -20,15 +23,323 @@ type ReleaseList struct {
 	Releases []*Release `json:"releases"`
 }
 
+// Release times are always UTC and serialized as RFC3339, like "2024-03-18T06:37:10Z"
 type Release struct {
-	TagName             string    `json:"tagName"`
-	ReleaseName         string    `json:"releaseName"`
-	CreatedAt           time.Time `json:"createdAt"`
-	PublishedAt         time.Time `json:"publishedAt"`
-	Body                string    `json:"body"`
-	Prerequisite        bool      `json:"prerequisite"`
-	PrerequisiteMessage string    `json:"prerequisiteMessage"`
-	TagLink             string    `json:"tagLink"`
+	// GithubReleaseID is the id github keeps for the release across edits of its tag and name
+	GithubReleaseID     int64                 `json:"githubReleaseId,omitempty"`
+	TagName             string                `json:"tagName"`
+	ReleaseName         string                `json:"releaseName"`
+	CreatedAt           time.Time             `json:"createdAt"`
+	PublishedAt         time.Time             `json:"publishedAt"`
+	PublishedAtUnix     int64                 `json:"publishedAtUnix,omitempty"`
+	Body                string                `json:"body"`
+	Placeholder         bool                  `json:"placeholder,omitempty"`
+	BodyTruncated       bool                  `json:"bodyTruncated,omitempty"`
+	Prerequisite        bool                  `json:"prerequisite"`
+	PrerequisiteMessage string                `json:"prerequisiteMessage"`
+	PrerequisiteId      string                `json:"prerequisiteId,omitempty"`
+	PrerequisiteBlocks  []*PrerequisiteBlock  `json:"prerequisiteBlocks,omitempty"`
+	TagLink             string                `json:"tagLink"`
+	CompareURL          string                `json:"compareUrl,omitempty"`
+	TargetCommitish     string                `json:"targetCommitish,omitempty"`
+	CommitSha           string                `json:"commitSha,omitempty"`
+	Prerelease          bool                  `json:"prerelease"`
+	Channel             string                `json:"channel,omitempty"`
+	Yanked              bool                  `json:"yanked"`
+	YankedReason        string                `json:"yankedReason,omitempty"`
+	Blocked             bool                  `json:"blocked"`
+	BlockedReason       string                `json:"blockedReason,omitempty"`
+	Supported           bool                  `json:"supported"`
+	Components          map[string]string     `json:"components,omitempty"`
+	Images              []*ReleaseImage       `json:"images,omitempty"`
+	FeatureFlags        []*FeatureFlag        `json:"featureFlags,omitempty"`
+	Attestations        []*ReleaseAttestation `json:"attestations,omitempty"`
+	Assets              []*ReleaseAsset       `json:"assets,omitempty"`
+	Repository          string                `json:"repository,omitempty"`
+	DiscussionURL       *string               `json:"discussionUrl"`
+	AgeDays             *int                  `json:"ageDays,omitempty"`
+	BreakingChange      bool                  `json:"breakingChange"`
+	// EstimatedUpgradeDuration is how long upgrading to the release takes per its upgrade-duration marker, like "15m0s"
+	EstimatedUpgradeDuration string        `json:"estimatedUpgradeDuration,omitempty"`
+	KnownIssues              []*KnownIssue `json:"knownIssues,omitempty"`
+	// RollbackSafe tells whether rolling back from the release to the previous version is safe, RollbackNotes why not
+	RollbackSafe  bool   `json:"rollbackSafe"`
+	RollbackNotes string `json:"rollbackNotes,omitempty"`
+	// PrerequisiteMessages holds the message of every marker delimited prerequisite block, PrerequisiteMessage joins them
+	PrerequisiteMessages []string `json:"prerequisiteMessages,omitempty"`
+}
+
+// UnmarshalJSON defaults RollbackSafe to true for releases stored before the rollback marker was parsed
+func (release *Release) UnmarshalJSON(data []byte) error {
+	// releaseFields has the fields of Release without its methods, so that unmarshalling it doesn't recurse
+	type releaseFields Release
+	fields := releaseFields{RollbackSafe: true}
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	*release = Release(fields)
+	return nil
+}
+
+// KnownIssue Id is the issue referenced like "#123", FixedIn the later release fixing it
+type KnownIssue struct {
+	Id          string `json:"id,omitempty"`
+	Description string `json:"description"`
+	FixedIn     string `json:"fixedIn,omitempty"`
+}
+
+// PrerequisiteBlock without modules applies to every installation
+type PrerequisiteBlock struct {
+	Message          string   `json:"message"`
+	AppliesToModules []string `json:"appliesToModules,omitempty"`
+}
+
+type PrerequisiteInfo struct {
+	TagName             string `json:"tagName"`
+	PrerequisiteId      string `json:"prerequisiteId,omitempty"`
+	PrerequisiteMessage string `json:"prerequisiteMessage"`
+	Superseded          bool   `json:"superseded"`
+	SupersededBy        string `json:"supersededBy,omitempty"`
+}
+
+type PrerequisiteSummary struct {
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	// Prerequisites lists every prerequisite on the upgrade path oldest first, Effective leaves out the superseded ones
+	Prerequisites []*PrerequisiteInfo `json:"prerequisites"`
+	Effective     []*PrerequisiteInfo `json:"effective"`
+}
+
+// ReleaseWebhookAck acknowledges a release webhook delivery with what was parsed from it
+type ReleaseWebhookAck struct {
+	Processed bool   `json:"processed"`
+	Action    string `json:"action,omitempty"`
+	TagName   string `json:"tagName,omitempty"`
+}
+
+type ReleaseBatchLookup struct {
+	Releases []*Release `json:"releases"`
+	NotFound []string   `json:"notFound"`
+}
+
+// PartitionedReleases splits the releases of an upgrade path for upgrade wizards
+type PartitionedReleases struct {
+	ActionRequired  []*Release       `json:"actionRequired"`
+	Informational   []*Release       `json:"informational"`
+	UpgradeEstimate *UpgradeEstimate `json:"upgradeEstimate"`
+	RollbackSafe    bool             `json:"rollbackSafe"`
+	// FirstUnsafeHop is the oldest release of the path which can't be rolled back, the point of no return
+	FirstUnsafeHop *RollbackHop `json:"firstUnsafeHop,omitempty"`
+}
+
+type RollbackHop struct {
+	TagName string `json:"tagName"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// UpgradeEstimate is Estimated when a release without upgrade duration counted for the default
+type UpgradeEstimate struct {
+	TotalDuration string                `json:"totalDuration"`
+	TotalSeconds  int64                 `json:"totalSeconds"`
+	Estimated     bool                  `json:"estimated"`
+	Hops          []*UpgradeHopEstimate `json:"hops"`
+}
+
+type UpgradeHopEstimate struct {
+	TagName   string `json:"tagName"`
+	Duration  string `json:"duration"`
+	Estimated bool   `json:"estimated"`
+}
+
+type ReleaseFrequency struct {
+	Month string `json:"month"`
+	Count int    `json:"count"`
+}
+
+type ReleaseDelta struct {
+	Releases         []*Release        `json:"releases"`
+	Etags            map[string]string `json:"etags"`
+	KnownTagModified bool              `json:"knownTagModified"`
+	Resync           bool              `json:"resync"`
+}
+
+type ModuleLintResult struct {
+	Name     string   `json:"name"`
+	Findings []string `json:"findings"`
+}
+
+// ReleaseAsset is a file attached to a release, Id is the github asset id it is downloaded by
+type ReleaseAsset struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+// ReleaseAttestation is an sbom or provenance attestation attached to a release as an asset
+type ReleaseAttestation struct {
+	// Kind is sbom or provenance
+	Kind string `json:"kind"`
+	// Format is spdx or cyclonedx for an sbom and slsa for provenance
+	Format string `json:"format"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Size   int    `json:"size"`
+}
+
+// ReleaseImage is a container image shipped by a release, Signed is false when the release has no signature for it
+type ReleaseImage struct {
+	Name      string          `json:"name"`
+	Image     string          `json:"image"`
+	Digest    string          `json:"digest,omitempty"`
+	Signed    bool            `json:"signed"`
+	Signature *ImageSignature `json:"signature,omitempty"`
+}
+
+// ImageSignature is what cosign verification of an image is expected to find
+type ImageSignature struct {
+	Repository          string `json:"repository"`
+	CertificateIdentity string `json:"certificateIdentity"`
+	RekorLogIndex       int64  `json:"rekorLogIndex"`
+}
+
+type ComponentVersion struct {
+	Version  string   `json:"version"`
+	Releases []string `json:"releases"`
+}
+
+type ReleaseLintResult struct {
+	TagName  string   `json:"tagName"`
+	Findings []string `json:"findings"`
+}
+
+type SupportPolicy struct {
+	SupportedMinorLines int                  `json:"supportedMinorLines"`
+	Lines               []*SupportedLineInfo `json:"lines"`
+}
+
+type ReleaseRollout struct {
+	TagName    string `json:"tagName"`
+	Percentage int    `json:"percentage"`
+}
+
+// InstallationCheckIn is reported periodically by an installation with the status of its modules
+type InstallationCheckIn struct {
+	InstallationId string          `json:"installationId"`
+	Version        string          `json:"version"`
+	Modules        []*ModuleStatus `json:"modules"`
+}
+
+type ModuleStatus struct {
+	Name string `json:"name"`
+	// Status is either installed or enabled
+	Status  string `json:"status"`
+	Version string `json:"version,omitempty"`
+}
+
+type ModuleAdoption struct {
+	Name      string         `json:"name"`
+	Installed int            `json:"installed"`
+	Enabled   int            `json:"enabled"`
+	Versions  map[string]int `json:"versions"`
+	// UnknownNames lists the reported module names missing from the catalog, only set on the unknown bucket
+	UnknownNames []string `json:"unknownNames,omitempty"`
+}
+
+type ModuleAdoptionStats struct {
+	Window              string            `json:"window"`
+	ActiveInstallations int               `json:"activeInstallations"`
+	Modules             []*ModuleAdoption `json:"modules"`
+}
+
+// ReleaseSourceMeta tells where the served releases come from, Provenance is primary, fallback or bootstrap
+type ReleaseSourceMeta struct {
+	Source            string     `json:"source"`
+	Provenance        string     `json:"provenance"`
+	FallbackLocation  string     `json:"fallbackLocation,omitempty"`
+	BootstrapLocation string     `json:"bootstrapLocation,omitempty"`
+	FetchedAt         *time.Time `json:"fetchedAt,omitempty"`
+}
+
+// EffectiveConfig is the configuration the service runs with, secrets are redacted
+type EffectiveConfig struct {
+	GitHubHost             string   `json:"githubHost"`
+	GitHubOrg              string   `json:"githubOrg"`
+	GitHubRepo             string   `json:"githubRepo"`
+	GitHubToken            string   `json:"githubToken"`
+	GitHubWebhookSecret    string   `json:"githubWebhookSecret"`
+	GitHubChangelogRepos   []string `json:"githubChangelogRepos"`
+	ReleaseSource          string   `json:"releaseSource"`
+	StaticReleasesLocation string   `json:"staticReleasesLocation,omitempty"`
+	CloudConfigured        bool     `json:"cloudConfigured"`
+	BlobStorageType        string   `json:"blobStorageType,omitempty"`
+	// Durations holds the ttls, intervals and timeouts formatted like 1h0m0s
+	Durations    map[string]string  `json:"durations"`
+	FeatureFlags map[string]bool    `json:"featureFlags"`
+	Limits       map[string]float64 `json:"limits"`
+}
+
+// ClientUpgradeRequired tells a client rejected for its version which version it has to upgrade to
+type ClientUpgradeRequired struct {
+	ClientVersion  string `json:"clientVersion,omitempty"`
+	MinimumVersion string `json:"minimumVersion,omitempty"`
+	DocsLink       string `json:"docsLink,omitempty"`
+}
+
+type BlockReleaseRequest struct {
+	Reason string `json:"reason"`
+}
+
+type RolloutPercentageRequest struct {
+	Percentage int `json:"percentage"`
+}
+
+type LineEolDateRequest struct {
+	EolDate string `json:"eolDate"`
+}
+
+type SupportedLineInfo struct {
+	Line      string     `json:"line"`
+	Supported bool       `json:"supported"`
+	EolDate   *time.Time `json:"eolDate,omitempty"`
+}
+
+type MinorReleaseLine struct {
+	Line             string     `json:"line"`
+	LatestPatch      string     `json:"latestPatch"`
+	Count            int        `json:"count"`
+	PrerequisiteTags []string   `json:"prerequisiteTags"`
+	Releases         []*Release `json:"releases"`
+}
+
+type ChangelogOptions struct {
+	OldestFirst        bool
+	IncludePrereleases bool
+	// FromVersion and ToVersion optionally bound the included releases, both inclusive
+	FromVersion string
+	ToVersion   string
+	// Format is markdown or keepachangelog, markdown when empty
+	Format string
+}
+
+type YankedRelease struct {
+	TagName string `json:"tagName"`
+	Reason  string `json:"reason"`
+}
+
+type ReleaseCheck struct {
+	Version          string     `json:"version"`
+	Yanked           bool       `json:"yanked"`
+	YankedReason     string     `json:"yankedReason,omitempty"`
+	UpgradeAvailable bool       `json:"upgradeAvailable"`
+	LatestRelease    *Release   `json:"latestRelease,omitempty"`
+	Supported        bool       `json:"supported"`
+	EolDate          *time.Time `json:"eolDate,omitempty"`
+	EolWarning       string     `json:"eolWarning,omitempty"`
+	// KnownIssues lists the known issues of the version, with the release fixing them when there is one
+	KnownIssues []*KnownIssue `json:"knownIssues,omitempty"`
 }
 
 const MODULE_CICD = "cicd"
@@ -37,6 +348,7 @@ const MODULE_Security = "security"
 type Module struct {
 	Id                            int             `json:"id"`
 	Name                          string          `json:"name"`
+	Aliases                       []string        `json:"aliases,omitempty"`
 	BaseMinVersionSupported       string          `json:"baseMinVersionSupported"`
 	IsIncludedInLegacyFullPackage bool            `json:"isIncludedInLegacyFullPackage"`
 	Assets                        []string        `json:"assets"`
@@ -47,6 +359,48 @@ type Module struct {
 	DependentModules              []int           `json:"dependentModules"`
 	ResourceFilter                *ResourceFilter `json:"resourceFilter,omitempty"`
 	ModuleType                    string          `json:"moduleType"`
+	UninstallWarnings             []string        `json:"uninstallWarnings,omitempty"`
+	UninstallBlockedBy            []string        `json:"uninstallBlockedBy,omitempty"`
+	// KubernetesConstraint is the semver constraint on the cluster kubernetes version, like ">=1.24, <1.30"
+	KubernetesConstraint string `json:"kubernetesConstraint,omitempty"`
+	// FeatureFlag gates the module, it is only listed to requests passing the flag
+	FeatureFlag string `json:"featureFlag,omitempty"`
+}
+
+// ModuleCatalogFile is the format of the module catalog file
+type ModuleCatalogFile struct {
+	// Modules replace the built in base catalog when set, an empty section is refused
+	Modules  []*Module                  `json:"modules,omitempty"`
+	Profiles map[string]*CatalogProfile `json:"profiles"`
+}
+
+// CatalogProfile rules apply in the order include, exclude, overrides and then its own modules
+type CatalogProfile struct {
+	// Include keeps only the listed base modules when set
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// Overrides replace the fields they set of a base module, keyed by module name
+	Overrides map[string]json.RawMessage `json:"overrides,omitempty"`
+	// Modules are served by this profile only, like the integrations of an enterprise catalog
+	Modules []*Module `json:"modules,omitempty"`
+}
+
+type ModuleChange struct {
+	TagName     string    `json:"tagName"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Version     string    `json:"version"`
+	TagLink     string    `json:"tagLink"`
+}
+
+type ModuleWithRecentChanges struct {
+	*Module
+	RecentChanges []*ModuleChange `json:"recentChanges"`
+}
+
+type ModuleUninstallInfo struct {
+	Name               string   `json:"name"`
+	UninstallWarnings  []string `json:"uninstallWarnings"`
+	UninstallBlockedBy []string `json:"uninstallBlockedBy"`
 }
 
 type ResourceFilter struct {
@@ -68,3 +422,134 @@ type GroupVersionKind struct {
 type ResourceIdentifier struct {
 	Labels map[string]string `json:"labels"`
 }
+
+type ConfigCheck struct {
+	Source  string `json:"source"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type ConfigValidationReport struct {
+	Valid  bool           `json:"valid"`
+	Checks []*ConfigCheck `json:"checks"`
+}
+
+// WebhookPing is what github sent with the last ping of a hook, Warnings lists the problems of the hook configuration
+type WebhookPing struct {
+	Zen        string    `json:"zen"`
+	HookId     int64     `json:"hookId"`
+	Events     []string  `json:"events,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	Warnings   []string  `json:"warnings"`
+}
+
+// WebhookStatus tells whether the hook is set up right, LastPing is empty until github pings the service
+type WebhookStatus struct {
+	LastPing        *WebhookPing `json:"lastPing,omitempty"`
+	EventsProcessed uint64       `json:"eventsProcessed"`
+}
+
+type WebhookDryRunResult struct {
+	Action  string   `json:"action,omitempty"`
+	TagName string   `json:"tagName,omitempty"`
+	Outcome string   `json:"outcome"`
+	Message string   `json:"message,omitempty"`
+	Release *Release `json:"release,omitempty"`
+	// LintFindings are the lint rule findings on the release, Warnings the problems accepted outside of strict mode
+	LintFindings []string `json:"lintFindings"`
+	Warnings     []string `json:"warnings"`
+}
+
+// ReleaseQuery combines filters, a sort order and pagination over the cached releases, empty fields don't filter
+type ReleaseQuery struct {
+	Prerelease *bool
+	Draft      *bool
+	// PublishedFrom and PublishedTo bound the publish date, both inclusive, as yyyy-mm-dd
+	PublishedFrom string
+	PublishedTo   string
+	// Search matches the tag, name or body case-insensitively
+	Search string
+	// Series is a minor line like "0.6"
+	Series string
+	// Channel is a release channel, empty leaves out the edge channel and all includes it
+	Channel string
+	Sort    string
+	Limit   int
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+}
+
+// ReleaseTag is an entry of the tag list installers populate their version picker from
+type ReleaseTag struct {
+	TagName     string    `json:"tagName"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Channel     string    `json:"channel"`
+	Yanked      bool      `json:"yanked"`
+	Supported   bool      `json:"supported"`
+}
+
+type ReleaseTagList struct {
+	Count int           `json:"count"`
+	Tags  []*ReleaseTag `json:"tags"`
+}
+
+// ReleaseListFilter narrows the release list, unset fields don't filter
+type ReleaseListFilter struct {
+	// Channel is a release channel, empty leaves out the edge channel and all includes it
+	Channel      string
+	Prerequisite *bool
+	Breaking     *bool
+	// After and Before are exclusive version bounds
+	After  string
+	Before string
+}
+
+type ReleaseListPage struct {
+	Releases   []*Release `json:"releases"`
+	Total      int        `json:"total"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FeatureFlag is a row of the feature flags table of a release body, IntroducedIn is only set when listing flags across releases
+type FeatureFlag struct {
+	Name         string `json:"name"`
+	Default      string `json:"default,omitempty"`
+	Description  string `json:"description,omitempty"`
+	IntroducedIn string `json:"introducedIn,omitempty"`
+}
+
+type SyncResult struct {
+	At           time.Time `json:"at"`
+	Success      bool      `json:"success"`
+	Error        string    `json:"error,omitempty"`
+	ReleaseCount int       `json:"releaseCount"`
+}
+
+type OperationalStats struct {
+	WebhookEventsProcessed uint64         `json:"webhookEventsProcessed"`
+	LastSync               *SyncResult    `json:"lastSync,omitempty"`
+	CacheSizes             map[string]int `json:"cacheSizes"`
+}
+
+type BuildInfo struct {
+	GoVersion string `json:"goVersion"`
+	Module    string `json:"module,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	BuiltAt   string `json:"builtAt,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+type Status struct {
+	StartedAt      time.Time `json:"startedAt"`
+	UptimeSeconds  int64     `json:"uptimeSeconds"`
+	RequestsServed uint64    `json:"requestsServed"`
+	*OperationalStats
+	Build *BuildInfo `json:"build"`
+}
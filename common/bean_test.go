@@ -0,0 +1,44 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReleaseUnmarshalDefaultsRollbackSafe(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected bool
+	}{
+		{name: "missing field", data: `{"tagName":"v0.6.20"}`, expected: true},
+		{name: "null field", data: `{"tagName":"v0.6.20","rollbackSafe":null}`, expected: true},
+		{name: "safe", data: `{"tagName":"v0.6.20","rollbackSafe":true}`, expected: true},
+		{name: "unsafe", data: `{"tagName":"v0.6.20","rollbackSafe":false}`, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := &Release{}
+			if err := json.Unmarshal([]byte(tt.data), release); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if release.TagName != "v0.6.20" {
+				t.Errorf("expected tag v0.6.20, got %q", release.TagName)
+			}
+			if release.RollbackSafe != tt.expected {
+				t.Errorf("expected rollbackSafe %v, got %v", tt.expected, release.RollbackSafe)
+			}
+		})
+	}
+}
+
+func TestReleaseListUnmarshalDefaultsRollbackSafe(t *testing.T) {
+	var releases []*Release
+	err := json.Unmarshal([]byte(`[{"tagName":"v0.6.20"},{"tagName":"v0.6.19","rollbackSafe":false}]`), &releases)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !releases[0].RollbackSafe || releases[1].RollbackSafe {
+		t.Errorf("expected stored releases to keep their rollbackSafe and default to safe, got %v and %v", releases[0].RollbackSafe, releases[1].RollbackSafe)
+	}
+}
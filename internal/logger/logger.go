@@ -6,10 +6,26 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"net/http"
+	"strconv"
 )
 
 type LogConfig struct {
-	Level int `env:"LOG_LEVEL" envDefault:"0"` // default info
+	// Level is the zap level number (-1 debug, 0 info, 1 warn, 2 error) or its name
+	Level string `env:"LOG_LEVEL" envDefault:"0"` // default info
+}
+
+const (
+	ReleaseLoggerName = "release"
+	GithubLoggerName  = "github"
+)
+
+func parseLogLevel(level string) (zapcore.Level, error) {
+	if number, err := strconv.Atoi(level); err == nil {
+		return zapcore.Level(number), nil
+	}
+	var zapLevel zapcore.Level
+	err := zapLevel.UnmarshalText([]byte(level))
+	return zapLevel, err
 }
 
 func NewSugardLogger() (*zap.SugaredLogger, error) {
@@ -20,8 +36,13 @@ func NewSugardLogger() (*zap.SugaredLogger, error) {
 		return nil, err
 	}
 
+	level, err := parseLogLevel(cfg.Level)
+	if err != nil {
+		fmt.Println("invalid log level " + cfg.Level + ": " + err.Error())
+		return nil, err
+	}
 	config := zap.NewProductionConfig()
-	config.Level = zap.NewAtomicLevelAt(zapcore.Level(cfg.Level))
+	config.Level = zap.NewAtomicLevelAt(level)
 	l, err := config.Build()
 	if err != nil {
 		fmt.Println("failed to create the default logger: " + err.Error())
@@ -0,0 +1,13 @@
+package util
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+var DefaultMetricsRegistry = prometheus.NewRegistry()
+
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(DefaultMetricsRegistry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionConstraint is a semver constraint like ">=1.24, <1.29" or "~1.27 || ^2.0"
+type VersionConstraint struct {
+	raw          string
+	alternatives [][]*versionComparison
+}
+
+type versionComparison struct {
+	operator string
+	version  *SemanticVersion
+}
+
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+func ParseVersionConstraint(constraint string) (*VersionConstraint, error) {
+	versionConstraint := &VersionConstraint{raw: constraint}
+	for _, alternative := range strings.Split(constraint, "||") {
+		var comparisons []*versionComparison
+		for _, term := range strings.Fields(strings.ReplaceAll(alternative, ",", " ")) {
+			comparison, err := parseVersionComparison(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q, %v", constraint, err)
+			}
+			comparisons = append(comparisons, comparison)
+		}
+		if len(comparisons) == 0 {
+			return nil, fmt.Errorf("invalid version constraint %q, empty condition", constraint)
+		}
+		versionConstraint.alternatives = append(versionConstraint.alternatives, comparisons)
+	}
+	return versionConstraint, nil
+}
+
+func parseVersionComparison(term string) (*versionComparison, error) {
+	operator := "="
+	for _, candidate := range constraintOperators {
+		if strings.HasPrefix(term, candidate) {
+			operator = candidate
+			term = term[len(candidate):]
+			break
+		}
+	}
+	version, err := ParseSemanticVersion(term)
+	if err != nil {
+		return nil, err
+	}
+	return &versionComparison{operator: operator, version: version}, nil
+}
+
+func (c *VersionConstraint) Check(version *SemanticVersion) bool {
+	for _, comparisons := range c.alternatives {
+		satisfied := true
+		for _, comparison := range comparisons {
+			if !comparison.check(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *VersionConstraint) String() string {
+	return c.raw
+}
+
+func (c *versionComparison) check(version *SemanticVersion) bool {
+	result := version.Compare(c.version)
+	switch c.operator {
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	case "~":
+		// ~1.27 and ~1.27.3 allow patch updates within 1.27
+		return result >= 0 && version.Major() == c.version.Major() && version.Minor() == c.version.Minor()
+	case "^":
+		// ^1.2.3 allows updates within major 1, ^0.6.1 within 0.6 like in other semver tools
+		if c.version.Major() == 0 {
+			return result >= 0 && version.Major() == 0 && version.Minor() == c.version.Minor()
+		}
+		return result >= 0 && version.Major() == c.version.Major()
+	default:
+		return result == 0
+	}
+}
@@ -0,0 +1,152 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemanticVersion is a parsed release tag like v0.6.19, v0.6.19.1 or v0.7.0-rc.1
+type SemanticVersion struct {
+	// Segments holds major, minor, patch and any further numeric segment in order
+	Segments   []int
+	Prerelease string
+	Build      string
+}
+
+// ParseSemanticVersion parses a version, an optional leading "v" is ignored
+func ParseSemanticVersion(version string) (*SemanticVersion, error) {
+	trimmed := NormalizeVersionTag(version)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("invalid semantic version %q", version)
+	}
+	semanticVersion := &SemanticVersion{}
+	if index := strings.Index(trimmed, "+"); index >= 0 {
+		semanticVersion.Build = trimmed[index+1:]
+		trimmed = trimmed[:index]
+	}
+	if index := strings.Index(trimmed, "-"); index >= 0 {
+		semanticVersion.Prerelease = trimmed[index+1:]
+		trimmed = trimmed[:index]
+		if len(semanticVersion.Prerelease) == 0 {
+			return nil, fmt.Errorf("invalid semantic version %q, empty pre-release", version)
+		}
+	}
+	for _, segment := range strings.Split(trimmed, ".") {
+		number, err := strconv.Atoi(segment)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid semantic version %q", version)
+		}
+		semanticVersion.Segments = append(semanticVersion.Segments, number)
+	}
+	return semanticVersion, nil
+}
+
+// NormalizeVersionTag drops surrounding spaces and the leading "v"
+func NormalizeVersionTag(tag string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(tag), "v"), "V")
+}
+
+func IsSameVersionTag(a, b string) bool {
+	return NormalizeVersionTag(a) == NormalizeVersionTag(b)
+}
+
+func (v *SemanticVersion) segment(index int) int {
+	if index < len(v.Segments) {
+		return v.Segments[index]
+	}
+	return 0
+}
+
+func (v *SemanticVersion) Major() int {
+	return v.segment(0)
+}
+
+func (v *SemanticVersion) Minor() int {
+	return v.segment(1)
+}
+
+func (v *SemanticVersion) Patch() int {
+	return v.segment(2)
+}
+
+// MinorLine returns the "major.minor" line the version belongs to, v0.6.19.1 belongs to 0.6
+func (v *SemanticVersion) MinorLine() string {
+	return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+}
+
+// String formats the version without leading "v", like 0.7.0-rc.1
+func (v *SemanticVersion) String() string {
+	segments := make([]string, 0, len(v.Segments))
+	for _, segment := range v.Segments {
+		segments = append(segments, strconv.Itoa(segment))
+	}
+	version := strings.Join(segments, ".")
+	if v.IsPrerelease() {
+		version += "-" + v.Prerelease
+	}
+	if len(v.Build) > 0 {
+		version += "+" + v.Build
+	}
+	return version
+}
+
+func (v *SemanticVersion) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare ignores build metadata
+func (v *SemanticVersion) Compare(other *SemanticVersion) int {
+	segments := len(v.Segments)
+	if len(other.Segments) > segments {
+		segments = len(other.Segments)
+	}
+	for i := 0; i < segments; i++ {
+		if v.segment(i) != other.segment(i) {
+			return compareInt(v.segment(i), other.segment(i))
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if len(a) == 0 {
+		return 1
+	}
+	if len(b) == 0 {
+		return -1
+	}
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.Atoi(aIdentifiers[i])
+		bNumber, bErr := strconv.Atoi(bIdentifiers[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return compareInt(aNumber, bNumber)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if aIdentifiers[i] != bIdentifiers[i] {
+				return strings.Compare(aIdentifiers[i], bIdentifiers[i])
+			}
+		}
+	}
+	return compareInt(len(aIdentifiers), len(bIdentifiers))
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
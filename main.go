@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -9,6 +10,13 @@ import (
 )
 
 func main() {
+		validate := flag.Bool("validate-config", false, "validate the configuration and exit without starting the server")
+		output := flag.String("output", "text", "format of the validation report, text or json")
+		checkConnectivity := flag.Bool("check-connectivity", false, "also verify github reachability and token validity when validating")
+		flag.Parse()
+		if *validate {
+			os.Exit(validateConfig(*output, *checkConnectivity))
+		}
 		app, err := InitializeApp()
 		if err != nil {
 			log.Panic(err)
@@ -24,5 +32,12 @@ func main() {
 			os.Exit(0)
 		}()
 		//      gracefulStop end
+		var reload = make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				app.ReloadConfig()
+			}
+		}()
 		app.Start()
 }
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"reflect"
+)
+
+// loadAdminState is called on startup and on every refresh to pick up overrides of other instances
+func (impl *ReleaseNoteServiceImpl) loadAdminState() error {
+	state, err := impl.adminStateRepository.Get()
+	if err == adminState.ErrAdminStateUnavailable {
+		impl.adminStateMutex.Lock()
+		defer impl.adminStateMutex.Unlock()
+		if impl.adminState == nil {
+			impl.logger.Warnw("admin state unavailable, starting without admin overrides")
+			impl.adminState = &adminState.AdminState{}
+		}
+		return nil
+	} else if err != nil {
+		impl.logger.Errorw("error in loading admin state", "err", err)
+		return err
+	}
+	impl.adminStateMutex.Lock()
+	defer impl.adminStateMutex.Unlock()
+	if impl.adminState != nil && reflect.DeepEqual(impl.adminState, state) {
+		return nil
+	}
+	impl.adminState = state
+	impl.invalidateDerivedViews()
+	return nil
+}
+
+// updateAdminState applies the update on the stored state, the in memory state is replaced once saved
+func (impl *ReleaseNoteServiceImpl) updateAdminState(update func(state *adminState.AdminState)) error {
+	impl.adminStateMutex.Lock()
+	defer impl.adminStateMutex.Unlock()
+	currentState, err := impl.adminStateRepository.Get()
+	if err == adminState.ErrAdminStateUnavailable {
+		currentState = impl.adminState
+	} else if err != nil {
+		impl.logger.Errorw("error in loading admin state", "err", err)
+		return err
+	}
+	updatedState := copyAdminState(currentState)
+	update(updatedState)
+	err = impl.adminStateRepository.Save(updatedState)
+	if err != nil {
+		impl.logger.Errorw("error in saving admin state", "err", err)
+		return err
+	}
+	impl.adminState = updatedState
+	impl.invalidateDerivedViews()
+	return nil
+}
+
+// copyAdminState deep copies through json so that new fields never get shared by mistake
+func copyAdminState(state *adminState.AdminState) *adminState.AdminState {
+	stateCopy := &adminState.AdminState{}
+	if state == nil {
+		return stateCopy
+	}
+	content, err := json.Marshal(state)
+	if err != nil {
+		return stateCopy
+	}
+	_ = json.Unmarshal(content, stateCopy)
+	return stateCopy
+}
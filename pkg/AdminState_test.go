@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"errors"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"testing"
+)
+
+func TestUpdateAdminStateKeepsOverridesOfOtherInstances(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	repository := impl.adminStateRepository.(*fakeAdminStateRepository)
+	// another instance blocked a release after this one loaded the state
+	repository.state = &adminState.AdminState{BlockedReleases: map[string]string{"0.7.0": "data loss"}}
+
+	err := impl.SetRolloutPercentage("v0.7.1", 20)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if repository.state.BlockedReleases["0.7.0"] != "data loss" {
+		t.Errorf("expected the block made by the other instance to be kept, got %v", repository.state.BlockedReleases)
+	}
+	if repository.state.RolloutPercentages["0.7.1"] != 20 {
+		t.Errorf("expected the rollout to be saved, got %v", repository.state.RolloutPercentages)
+	}
+	if impl.getRolloutPercentage("v0.7.1") != 20 {
+		t.Errorf("expected the rollout to be applied in memory")
+	}
+}
+
+func TestLoadAdminStatePicksUpStoredOverrides(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	repository := impl.adminStateRepository.(*fakeAdminStateRepository)
+	repository.state = &adminState.AdminState{RolloutPercentages: map[string]int{"0.7.1": 10}}
+
+	err := impl.loadAdminState()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if impl.getRolloutPercentage("v0.7.1") != 10 {
+		t.Errorf("expected the stored rollout to be loaded")
+	}
+
+	// an unavailable state leaves the loaded one as it was
+	repository.err = adminState.ErrAdminStateUnavailable
+	err = impl.loadAdminState()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if impl.getRolloutPercentage("v0.7.1") != 10 {
+		t.Errorf("expected the loaded rollout to be kept while the state is unavailable")
+	}
+
+	repository.err = errors.New("connection refused")
+	if impl.loadAdminState() == nil {
+		t.Errorf("expected the error of the repository")
+	}
+}
+
+func TestLoadAdminStateStartsEmptyWhenUnavailable(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.adminState = nil
+	impl.adminStateRepository = &fakeAdminStateRepository{err: adminState.ErrAdminStateUnavailable}
+
+	err := impl.loadAdminState()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if impl.adminState == nil || impl.getRolloutPercentage("v0.7.1") != FullRolloutPercentage {
+		t.Errorf("expected to start without overrides")
+	}
+}
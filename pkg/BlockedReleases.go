@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"net/http"
+	"strings"
+)
+
+// applyBlockedReleases runs on every read so that blocks survive re-syncs
+func (impl *ReleaseNoteServiceImpl) applyBlockedReleases(releases []*common.Release) {
+	impl.adminStateMutex.RLock()
+	defer impl.adminStateMutex.RUnlock()
+	for _, release := range releases {
+		reason, blocked := impl.adminState.BlockedReleases[util.NormalizeVersionTag(release.TagName)]
+		release.Blocked = blocked
+		release.BlockedReason = reason
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) BlockRelease(tag string, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if len(reason) == 0 {
+		return &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "block reason is required", UserMessage: "reason is required"}
+	}
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return err
+	}
+	return impl.updateAdminState(func(state *adminState.AdminState) {
+		if state.BlockedReleases == nil {
+			state.BlockedReleases = make(map[string]string)
+		}
+		state.BlockedReleases[util.NormalizeVersionTag(release.TagName)] = reason
+	})
+}
+
+// UnblockRelease accepts unknown tags so that stale blocks can always be lifted
+func (impl *ReleaseNoteServiceImpl) UnblockRelease(tag string) error {
+	return impl.updateAdminState(func(state *adminState.AdminState) {
+		delete(state.BlockedReleases, util.NormalizeVersionTag(tag))
+	})
+}
@@ -0,0 +1,156 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"github.com/google/go-github/github"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const (
+	ConfigCheckOk      = "ok"
+	ConfigCheckWarning = "warning"
+	ConfigCheckError   = "error"
+	ConfigCheckSkipped = "skipped"
+)
+
+const ConnectivityCheckTimeout = 10 * time.Second
+
+type configValidation struct {
+	report *common.ConfigValidationReport
+}
+
+func (validation *configValidation) add(source string, status string, message string) {
+	validation.report.Checks = append(validation.report.Checks, &common.ConfigCheck{Source: source, Status: status, Message: message})
+	if status == ConfigCheckError {
+		validation.report.Valid = false
+	}
+}
+
+// check records the outcome of a validation step, a nil error passes it
+func (validation *configValidation) check(source string, err error) bool {
+	if err != nil {
+		validation.add(source, ConfigCheckError, err.Error())
+		return false
+	}
+	validation.add(source, ConfigCheckOk, "")
+	return true
+}
+
+// ValidateConfiguration validates the configuration read on startup without starting the server
+func ValidateConfiguration(logger *zap.SugaredLogger, checkConnectivity bool) *common.ConfigValidationReport {
+	validation := &configValidation{report: &common.ConfigValidationReport{Valid: true, Checks: []*common.ConfigCheck{}}}
+
+	client, err := util.NewGitHubClient(logger)
+	githubValid := validation.check("github", err)
+	moduleConfig, err := util.NewModuleConfig(logger)
+	moduleValid := validation.check("module", err)
+	blobConfig, err := util.NewBlobConfig(logger)
+	blobValid := validation.check("blob", err)
+	releaseCacheConfig, err := util.NewReleaseCacheConfig(logger)
+	validation.check("releaseCache", err)
+	supportPolicyConfig, err := util.NewSupportPolicyConfig(logger)
+	validation.check("supportPolicy", err)
+	adminConfig, err := util.NewAdminConfig(logger)
+	adminValid := validation.check("admin", err)
+	releaseLintConfig, err := util.NewReleaseLintConfig(logger)
+	validation.check("releaseLint", err)
+	releaseSourceConfig, err := util.NewReleaseSourceConfig(logger)
+	releaseSourceValid := validation.check("releaseSource", err)
+	releaseBodyConfig, err := util.NewReleaseBodyConfig(logger)
+	validation.check("releaseBody", err)
+	releaseChannelConfig, err := util.NewReleaseChannelConfig(logger)
+	validation.check("releaseChannel", err)
+	_, err = util.NewResponseConfig(logger)
+	validation.check("response", err)
+	_, err = util.NewClientVersionConfig(logger)
+	validation.check("clientVersion", err)
+	_, err = util.NewResponseSigningConfig(logger)
+	validation.check("responseSigning", err)
+	_, err = util.NewTelemetryConfig(logger)
+	validation.check("telemetry", err)
+	_, err = util.NewReleaseAssetConfig(logger)
+	validation.check("releaseAsset", err)
+	_, err = util.NewMetricsConfig(logger)
+	validation.check("metrics", err)
+
+	if !githubValid || !moduleValid || !blobValid || !adminValid || !releaseSourceValid {
+		validation.add("catalog", ConfigCheckSkipped, "depends on configuration that failed to load")
+		return validation.report
+	}
+	// only the validations of the service are run
+	service := &ReleaseNoteServiceImpl{
+		logger:               logger,
+		client:               client,
+		moduleConfig:         moduleConfig,
+		blobConfig:           blobConfig,
+		releaseCacheConfig:   releaseCacheConfig,
+		supportPolicyConfig:  supportPolicyConfig,
+		releaseLintConfig:    releaseLintConfig,
+		releaseSourceConfig:  releaseSourceConfig,
+		releaseBodyConfig:    releaseBodyConfig,
+		releaseChannelConfig: releaseChannelConfig,
+		adminStateRepository: adminState.NewAdminStateRepositoryImpl(logger, adminConfig),
+	}
+	_, err = newReleaseSource(logger, releaseSourceConfig, service)
+	validation.check("releaseSource.primary", err)
+	_, err = newFallbackReleaseSource(logger, releaseSourceConfig, service)
+	validation.check("releaseSource.fallback", err)
+	validation.check("adminState", service.loadAdminState())
+	if releaseSourceConfig.BundledPrimingEnabled {
+		_, err = service.readBundledReleases()
+		validation.check("bundledReleases", err)
+	}
+	validation.validateModuleCatalog(service)
+
+	if !checkConnectivity {
+		validation.add("connectivity", ConfigCheckSkipped, "pass --check-connectivity to verify github access")
+		return validation.report
+	}
+	validation.checkGithubConnectivity(client)
+	return validation.report
+}
+
+func (validation *configValidation) validateModuleCatalog(service *ReleaseNoteServiceImpl) {
+	if !validation.check("catalog.file", service.loadModuleCatalog()) || !validation.check("catalog.profiles", service.validateCatalogProfiles()) {
+		return
+	}
+	modules, err := service.GetModulesV2()
+	if !validation.check("catalog", err) {
+		return
+	}
+	validation.check("catalog.constraints", validateModuleCatalog(modules))
+	// min versions partly come from the environment, startup only warns about them
+	if err = ValidateModuleDependencyVersions(modules); err != nil {
+		validation.add("catalog.dependencies", ConfigCheckWarning, err.Error())
+	} else {
+		validation.add("catalog.dependencies", ConfigCheckOk, "")
+	}
+}
+
+func (validation *configValidation) checkGithubConnectivity(client *util.GitHubClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectivityCheckTimeout)
+	defer cancel()
+	_, _, err := client.GitHubClient.RateLimits(ctx)
+	if responseErr, ok := err.(*github.ErrorResponse); ok && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusUnauthorized {
+		validation.add("connectivity.token", ConfigCheckError, "github rejected the token")
+		return
+	} else if err != nil {
+		validation.add("connectivity.github", ConfigCheckError, fmt.Sprintf("github is not reachable, %v", err))
+		return
+	}
+	validation.add("connectivity.github", ConfigCheckOk, "")
+	validation.add("connectivity.token", ConfigCheckOk, "")
+	config := client.GitHubConfig
+	_, _, err = client.GitHubClient.Repositories.Get(ctx, config.GitHubOrg, config.GitHubRepo)
+	if err != nil {
+		validation.add("connectivity.repo", ConfigCheckError, fmt.Sprintf("repo %s/%s is not accessible, %v", config.GitHubOrg, config.GitHubRepo, err))
+		return
+	}
+	validation.add("connectivity.repo", ConfigCheckOk, "")
+}
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"sync"
+	"time"
+)
+
+const (
+	DerivedViewGroupedByMinor = "groupedByMinor"
+	DerivedViewFrequency      = "frequency"
+)
+
+// derivedViewCache keys every view by the content hash of the releases it was computed from
+type derivedViewCache struct {
+	mutex sync.Mutex
+	views map[string]*derivedView
+}
+
+type derivedView struct {
+	releasesHash string
+	view         interface{}
+}
+
+// setCachedReleases is the only writer of the release cache
+func (impl *ReleaseNoteServiceImpl) setCachedReleases(releases []*common.Release) {
+	releases = impl.trimEdgeReleases(releases)
+	releases = impl.reconcileKnownIssues(releases)
+	sortReleasesByVersion(releases)
+	releaseCache[CACHE_KEY] = releases
+	impl.invalidateDerivedViews()
+}
+
+func (impl *ReleaseNoteServiceImpl) invalidateDerivedViews() {
+	impl.markContentModified()
+	impl.derivedViews.mutex.Lock()
+	defer impl.derivedViews.mutex.Unlock()
+	impl.derivedViews.views = nil
+}
+
+type lastModifiedState struct {
+	mutex sync.Mutex
+	at    time.Time
+}
+
+func (impl *ReleaseNoteServiceImpl) markContentModified() {
+	impl.lastModified.mutex.Lock()
+	defer impl.lastModified.mutex.Unlock()
+	impl.lastModified.at = time.Now()
+}
+
+func (impl *ReleaseNoteServiceImpl) GetLastModified() time.Time {
+	impl.lastModified.mutex.Lock()
+	defer impl.lastModified.mutex.Unlock()
+	return impl.lastModified.at
+}
+
+// getDerivedView recomputes the view only when the content of the releases changed
+func (impl *ReleaseNoteServiceImpl) getDerivedView(name string, compute func(releases []*common.Release) (interface{}, error)) (interface{}, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	if !impl.releaseCacheConfig.DerivedViewCacheEnabled {
+		return compute(releases)
+	}
+	releasesHash, err := util.GetContentHash(releases)
+	if err != nil {
+		impl.logger.Errorw("error in computing releases hash, computing view uncached", "view", name, "err", err)
+		return compute(releases)
+	}
+	cache := &impl.derivedViews
+	cache.mutex.Lock()
+	cached, ok := cache.views[name]
+	cache.mutex.Unlock()
+	if ok && cached.releasesHash == releasesHash {
+		return cached.view, nil
+	}
+	view, err := compute(releases)
+	if err != nil {
+		return nil, err
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.views == nil {
+		cache.views = make(map[string]*derivedView)
+	}
+	cache.views[name] = &derivedView{releasesHash: releasesHash, view: view}
+	return view, nil
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+	"time"
+)
+
+func TestDerivedViewFollowsReleasesChangedByAnotherInstance(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.releaseCacheConfig.DerivedViewCacheEnabled = true
+
+	lines, err := impl.GetReleasesGroupedByMinor()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected the 0.8 and 0.7 lines, got %d lines", len(lines))
+	}
+	cachedLines, err := impl.GetReleasesGroupedByMinor()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if &cachedLines[0] != &lines[0] {
+		t.Errorf("expected the view to be kept while the releases are unchanged")
+	}
+
+	// another instance stores a release in the database, nothing is invalidated on this one
+	repository := impl.releaseNoteRepository.(*fakeReleaseNoteRepository)
+	repository.releaseNote.ReleaseNote = append([]*common.Release{{TagName: "v0.9.0", PublishedAt: newTestReleases()[0].PublishedAt}}, newTestReleases()...)
+	lines, err = impl.GetReleasesGroupedByMinor()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(lines) != 3 {
+		t.Errorf("expected the view to be computed again with the 0.9 line, got %d lines", len(lines))
+	}
+}
+
+func TestLastModifiedFollowsContentChanges(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	before := time.Now()
+	impl.setCachedReleases(newTestReleases())
+	cachedAt := impl.GetLastModified()
+	if cachedAt.Before(before) {
+		t.Errorf("expected the cache write to be the last modification, got %v", cachedAt)
+	}
+	impl.RebuildModuleIndex()
+	if impl.GetLastModified().Before(cachedAt) {
+		t.Errorf("expected the module config change to be the last modification")
+	}
+}
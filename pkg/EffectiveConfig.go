@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+)
+
+// RedactedValue replaces a configured secret, an unset secret stays empty
+const RedactedValue = "<redacted>"
+
+func redact(secret string) string {
+	if len(secret) == 0 {
+		return ""
+	}
+	return RedactedValue
+}
+
+func (impl *ReleaseNoteServiceImpl) GetEffectiveConfig() (*common.EffectiveConfig, error) {
+	gitHubConfig := impl.client.GitHubConfig
+	return &common.EffectiveConfig{
+		GitHubHost:             gitHubConfig.GitHubHost,
+		GitHubOrg:              gitHubConfig.GitHubOrg,
+		GitHubRepo:             gitHubConfig.GitHubRepo,
+		GitHubToken:            redact(gitHubConfig.GitHubToken),
+		GitHubWebhookSecret:    redact(gitHubConfig.GitHubWebhookSecret),
+		GitHubChangelogRepos:   impl.getChangelogRepos(),
+		ReleaseSource:          impl.releaseSourceConfig.ReleaseSource,
+		StaticReleasesLocation: impl.releaseSourceConfig.StaticReleasesLocation,
+		CloudConfigured:        impl.blobConfig.CloudConfigured,
+		BlobStorageType:        string(impl.blobConfig.BlobStorageType),
+		Durations: map[string]string{
+			"releaseRefreshInterval":   impl.releaseCacheConfig.RefreshInterval.String(),
+			"releaseRefreshTimeout":    impl.releaseCacheConfig.RefreshTimeout.String(),
+			"releaseCacheTtl":          impl.releaseCacheConfig.TTL.String(),
+			"releaseCacheRefreshLead":  impl.releaseCacheConfig.RefreshLeadTime.String(),
+			"mergedChangelogTtl":       impl.releaseCacheConfig.MergedChangelogTTL.String(),
+			"readmeTtl":                gitHubConfig.GitHubReadmeTTL.String(),
+			"eolWarningHorizon":        impl.supportPolicyConfig.SupportPolicyConfig.EolWarningHorizon.String(),
+			"releaseImageFetchTimeout": impl.releaseBodyConfig.ImageFetchTimeout.String(),
+			"releaseImageFailureTtl":   impl.releaseBodyConfig.ImageFailureTTL.String(),
+			"releasePersistBackoff":    impl.releaseCacheConfig.PersistBackoff.String(),
+			"defaultUpgradeDuration":   impl.releaseBodyConfig.DefaultUpgradeDuration.String(),
+			"tagLookupTimeout":         gitHubConfig.GitHubTagLookupTimeout.String(),
+			"tagLookupNotFoundTtl":     gitHubConfig.GitHubTagLookupNotFoundTTL.String(),
+		},
+		FeatureFlags: map[string]bool{
+			"resolveCommitSha":         gitHubConfig.GitHubResolveCommitSha,
+			"readmeEnabled":            gitHubConfig.GitHubReadmeEnabled,
+			"bundledPrimingEnabled":    impl.releaseSourceConfig.BundledPrimingEnabled,
+			"strictWebhookLint":        impl.releaseLintConfig.StrictWebhook,
+			"derivedViewCacheEnabled":  impl.releaseCacheConfig.DerivedViewCacheEnabled,
+			"moduleLookupCacheEnabled": impl.moduleConfig.ModuleConfig.ModuleLookupCacheEnabled,
+			"imageDimensionsEnabled":   impl.releaseBodyConfig.ImageDimensionsEnabled,
+			"persistRevertOnFailure":   impl.releaseCacheConfig.PersistRevertOnFailure,
+		},
+		Limits: map[string]float64{
+			"webhookRateLimit":      gitHubConfig.GitHubWebhookRateLimit,
+			"webhookRateBurst":      float64(gitHubConfig.GitHubWebhookRateBurst),
+			"changelogParallelism":  float64(gitHubConfig.GitHubChangelogParallelism),
+			"releasesPerPage":       float64(gitHubConfig.GitHubReleasesPerPage),
+			"supportedMinorLines":   float64(impl.supportPolicyConfig.SupportPolicyConfig.SupportedMinorLines),
+			"releaseMaxBodyBytes":   float64(impl.releaseBodyConfig.MaxBodyBytes),
+			"releasePersistRetries": float64(impl.releaseCacheConfig.PersistRetries),
+			"releaseEdgeRetention":  float64(impl.releaseChannelConfig.ReleaseChannelConfig.EdgeRetention),
+		},
+	}, nil
+}
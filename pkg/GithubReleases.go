@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/google/go-github/github"
+	"net/url"
+	"strings"
+)
+
+// MaxGithubPageSize is the largest page size github serves lists with
+const MaxGithubPageSize = 100
+
+// githubRelease holds the fields not mapped by the vendored go-github version
+type githubRelease struct {
+	github.RepositoryRelease
+	DiscussionURL *string `json:"discussion_url,omitempty"`
+}
+
+// listGithubReleases decodes the raw payload so that discussion_url is kept
+func (impl *ReleaseNoteServiceImpl) listGithubReleases(ctx context.Context, org string, repo string) ([]*githubRelease, error) {
+	perPage := impl.client.GitHubConfig.GitHubReleasesPerPage
+	if perPage <= 0 || perPage > MaxGithubPageSize {
+		perPage = MaxGithubPageSize
+	}
+	var releases []*githubRelease
+	for page := 1; page != 0; {
+		url := fmt.Sprintf("repos/%v/%v/releases?per_page=%d&page=%d", org, repo, perPage, page)
+		req, err := impl.client.GitHubClient.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageReleases []*githubRelease
+		resp, err := impl.client.GitHubClient.Do(ctx, req, &pageReleases)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, pageReleases...)
+		page = resp.NextPage
+	}
+	return releases, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) getGithubReleaseByTag(ctx context.Context, org string, repo string, tag string) (*githubRelease, error) {
+	// the tag comes from the request path, escaping keeps it from reaching other endpoints
+	escapedTag := url.PathEscape(tag)
+	url := fmt.Sprintf("repos/%v/%v/releases/tags/%v", org, repo, escapedTag)
+	req, err := impl.client.GitHubClient.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	release := &githubRelease{}
+	_, err = impl.client.GitHubClient.Do(ctx, req, release)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func getRawGithubRelease(item *githubRelease, tagLinkPrefix string) *RawRelease {
+	raw := &RawRelease{
+		GithubReleaseID: item.GetID(),
+		DiscussionURL:   item.DiscussionURL,
+		Assets:          getRawReleaseAssets(item.Assets),
+		TagLinkPrefix:   tagLinkPrefix,
+	}
+	if item.TagName != nil {
+		raw.TagName = *item.TagName
+	}
+	if item.Name != nil {
+		raw.ReleaseName = *item.Name
+	}
+	if item.Body != nil {
+		raw.Body = *item.Body
+	}
+	if item.CreatedAt != nil {
+		raw.CreatedAt = item.CreatedAt.Time
+	}
+	if item.PublishedAt != nil {
+		raw.PublishedAt = item.PublishedAt.Time
+	}
+	if item.Prerelease != nil {
+		raw.Prerelease = *item.Prerelease
+	}
+	if item.TargetCommitish != nil {
+		raw.TargetCommitish = *item.TargetCommitish
+	}
+	return raw
+}
+
+func getDiscussionURL(discussionURL *string) *string {
+	if discussionURL == nil || len(strings.TrimSpace(*discussionURL)) == 0 {
+		return nil
+	}
+	url := strings.TrimSpace(*discussionURL)
+	return &url
+}
+
+// GitObjectTypeTag is the type of an annotated tag ref, the commit is one level below it
+const GitObjectTypeTag = "tag"
+
+func (impl *ReleaseNoteServiceImpl) resolveCommitShas(ctx context.Context, repo string, releases []*common.Release) {
+	org := impl.client.GitHubConfig.GitHubOrg
+	for _, release := range releases {
+		if len(release.TagName) == 0 || ctx.Err() != nil {
+			continue
+		}
+		ref, _, err := impl.client.GitHubClient.Git.GetRef(ctx, org, repo, "tags/"+release.TagName)
+		if err != nil || ref.Object == nil {
+			impl.logger.Warnw("error in resolving tag ref, leaving release without commit sha", "tagName", release.TagName, "err", err)
+			continue
+		}
+		sha := ref.Object.GetSHA()
+		if ref.Object.GetType() == GitObjectTypeTag {
+			tag, _, err := impl.client.GitHubClient.Git.GetTag(ctx, org, repo, sha)
+			if err != nil || tag.Object == nil {
+				impl.logger.Warnw("error in resolving annotated tag, leaving release without commit sha", "tagName", release.TagName, "err", err)
+				continue
+			}
+			sha = tag.Object.GetSHA()
+		}
+		release.CommitSha = sha
+	}
+}
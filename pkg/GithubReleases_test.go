@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListGithubReleasesFollowsPages(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/devtron-labs/devtron/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		if perPage := r.URL.Query().Get("per_page"); perPage != "2" {
+			t.Errorf("expected pages of 2 releases, got %s", perPage)
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch page {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/repos/devtron-labs/devtron/releases?per_page=2&page=2>; rel="next", <http://%s/api/v3/repos/devtron-labs/devtron/releases?per_page=2&page=2>; rel="last"`, r.Host, r.Host))
+			fmt.Fprint(w, `[{"id": 3, "tag_name": "v0.7.1"}, {"id": 2, "tag_name": "v0.7.0"}]`)
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/repos/devtron-labs/devtron/releases?per_page=2&page=1>; rel="first"`, r.Host))
+			fmt.Fprint(w, `[{"id": 1, "tag_name": "v0.6.0", "discussion_url": "https://github.com/devtron-labs/devtron/discussions/1"}]`)
+		default:
+			t.Errorf("unexpected page %q", page)
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_HOST", server.URL)
+	t.Setenv("GITHUB_RELEASES_PER_PAGE", "2")
+	client, err := util.NewGitHubClient(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	impl := newTestReleaseNoteService(t)
+	impl.client = client
+
+	releases, err := impl.listGithubReleases(context.Background(), "devtron-labs", "devtron")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if fmt.Sprint(pages) != "[1 2]" {
+		t.Errorf("expected both pages to be requested once, got %v", pages)
+	}
+	if len(releases) != 3 || releases[2].GetTagName() != "v0.6.0" {
+		t.Fatalf("expected the releases of both pages, got %d releases", len(releases))
+	}
+	if releases[2].DiscussionURL == nil || *releases[2].DiscussionURL != "https://github.com/devtron-labs/devtron/discussions/1" {
+		t.Errorf("expected the discussion url to be decoded, got %v", releases[2].DiscussionURL)
+	}
+}
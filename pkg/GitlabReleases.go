@@ -0,0 +1,143 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GitlabReleasesPerPage is the largest page of releases the gitlab api serves
+const GitlabReleasesPerPage = 100
+
+// gitlabRelease holds the fields read from the gitlab releases api
+type gitlabRelease struct {
+	TagName         string     `json:"tag_name"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReleasedAt      *time.Time `json:"released_at"`
+	UpcomingRelease bool       `json:"upcoming_release"`
+	Commit          *struct {
+		Id string `json:"id"`
+	} `json:"commit"`
+	Assets struct {
+		Links []*gitlabReleaseLink `json:"links"`
+	} `json:"assets"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+type gitlabReleaseLink struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// gitlabReleaseSource leaves upcoming releases out until they are released
+type gitlabReleaseSource struct {
+	service    *ReleaseNoteServiceImpl
+	baseURL    string
+	project    string
+	token      string
+	httpClient *http.Client
+}
+
+func (source *gitlabReleaseSource) FetchReleases(ctx context.Context) ([]*common.Release, error) {
+	items, err := source.listReleases(ctx)
+	if err != nil {
+		source.service.logger.Errorw("error in fetching releases from gitlab", "project", source.project, "err", err)
+		return nil, fmt.Errorf("failed operation on fetching releases from gitlab, %w", err)
+	}
+	releases := make([]*common.Release, 0, len(items))
+	for _, item := range items {
+		if item == nil || item.UpcomingRelease || len(item.TagName) == 0 {
+			continue
+		}
+		release := source.service.NormalizeRelease(source.getRawRelease(item))
+		if len(item.Links.Self) > 0 {
+			release.TagLink = item.Links.Self
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+func (source *gitlabReleaseSource) getRawRelease(item *gitlabRelease) *RawRelease {
+	raw := &RawRelease{
+		TagName:     item.TagName,
+		ReleaseName: item.Name,
+		Body:        item.Description,
+		CreatedAt:   item.CreatedAt,
+		PublishedAt: item.CreatedAt,
+		// a numeric project id gives no releases page
+		TagLinkPrefix: fmt.Sprintf("%s/%s/-/releases", source.baseURL, source.project),
+	}
+	if item.ReleasedAt != nil {
+		raw.PublishedAt = *item.ReleasedAt
+	}
+	if item.Commit != nil {
+		raw.TargetCommitish = item.Commit.Id
+	}
+	for _, link := range item.Assets.Links {
+		if link == nil {
+			continue
+		}
+		raw.Assets = append(raw.Assets, &RawReleaseAsset{Id: link.Id, Name: link.Name, URL: link.URL})
+	}
+	return raw
+}
+
+func (source *gitlabReleaseSource) listReleases(ctx context.Context) ([]*gitlabRelease, error) {
+	var releases []*gitlabRelease
+	page := 1
+	for page > 0 {
+		items, nextPage, err := source.getReleasesPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, items...)
+		page = nextPage
+	}
+	return releases, nil
+}
+
+func (source *gitlabReleaseSource) getReleasesPage(ctx context.Context, page int) ([]*gitlabRelease, int, error) {
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/releases?per_page=%d&page=%d", source.baseURL, url.PathEscape(source.project), GitlabReleasesPerPage, page)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(source.token) > 0 {
+		req.Header.Set("PRIVATE-TOKEN", source.token)
+	}
+	resp, err := source.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status %d from gitlab releases api", resp.StatusCode)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	var items []*gitlabRelease
+	err = json.Unmarshal(content, &items)
+	if err != nil {
+		return nil, 0, fmt.Errorf("malformed gitlab releases, %v", err)
+	}
+	nextPage, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	if err != nil {
+		// the header is empty on the last page
+		nextPage = 0
+	}
+	return items, nextPage, nil
+}
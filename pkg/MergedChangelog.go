@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type mergedChangelogCache struct {
+	mutex     sync.Mutex
+	releases  []*common.Release
+	fetchedAt time.Time
+}
+
+// getChangelogRepos returns the repos merged into the combined changelog, in priority order
+func (impl *ReleaseNoteServiceImpl) getChangelogRepos() []string {
+	var repos []string
+	seen := make(map[string]bool)
+	for _, repo := range impl.client.GitHubConfig.GitHubChangelogRepos {
+		repo = strings.TrimSpace(repo)
+		if len(repo) == 0 || seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		repos = append(repos, repo)
+	}
+	if len(repos) == 0 {
+		repos = []string{impl.client.GitHubConfig.GitHubRepo}
+	}
+	return repos
+}
+
+// GetMergedChangelog orders releases published at the same time by repo priority and then by tag
+func (impl *ReleaseNoteServiceImpl) GetMergedChangelog() ([]*common.Release, error) {
+	impl.mergedChangelog.mutex.Lock()
+	defer impl.mergedChangelog.mutex.Unlock()
+	if impl.mergedChangelog.releases != nil && time.Since(impl.mergedChangelog.fetchedAt) < impl.releaseCacheConfig.MergedChangelogTTL {
+		return impl.mergedChangelog.releases, nil
+	}
+	repos := impl.getChangelogRepos()
+	releasesByRepo, err := impl.fetchChangelogRepos(context.Background(), repos)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeChangelogReleases(repos, releasesByRepo)
+	impl.mergedChangelog.releases = merged
+	impl.mergedChangelog.fetchedAt = time.Now()
+	return merged, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) fetchChangelogRepos(ctx context.Context, repos []string) ([][]*common.Release, error) {
+	parallelism := impl.client.GitHubConfig.GitHubChangelogParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	releasesByRepo := make([][]*common.Release, len(repos))
+	failedRepos := make([]string, len(repos))
+	semaphore := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	for index, repo := range repos {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, repo string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			tagLinkPrefix := fmt.Sprintf("%s/%s/%s/releases/tag", strings.TrimSuffix(impl.client.GitHubConfig.GitHubHost, "/"), impl.client.GitHubConfig.GitHubOrg, repo)
+			if repo == impl.client.GitHubConfig.GitHubRepo {
+				tagLinkPrefix = TagLink
+			}
+			releases, operationComplete := impl.getReleasesFromGithubRepo(ctx, repo, tagLinkPrefix)
+			if !operationComplete {
+				failedRepos[index] = repo
+				return
+			}
+			for _, release := range releases {
+				release.Repository = repo
+			}
+			releasesByRepo[index] = releases
+		}(index, repo)
+	}
+	wg.Wait()
+	var failed []string
+	for _, repo := range failedRepos {
+		if len(repo) > 0 {
+			failed = append(failed, repo)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed operation on fetching releases from github for repos %s", strings.Join(failed, ", "))
+	}
+	return releasesByRepo, nil
+}
+
+// mergeChangelogReleases takes releasesByRepo indexed like the repos priority list
+func mergeChangelogReleases(repos []string, releasesByRepo [][]*common.Release) []*common.Release {
+	priority := make(map[string]int, len(repos))
+	for index, repo := range repos {
+		priority[repo] = index
+	}
+	merged := make([]*common.Release, 0)
+	for _, releases := range releasesByRepo {
+		merged = append(merged, releases...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].PublishedAt.Equal(merged[j].PublishedAt) {
+			return merged[i].PublishedAt.After(merged[j].PublishedAt)
+		}
+		if priority[merged[i].Repository] != priority[merged[j].Repository] {
+			return priority[merged[i].Repository] < priority[merged[j].Repository]
+		}
+		return merged[i].TagName > merged[j].TagName
+	})
+	return merged
+}
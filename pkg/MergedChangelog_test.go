@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeChangelogReleasesBreaksTiesByRepoPriority(t *testing.T) {
+	publishedAt := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	newReleasesByRepo := func() map[string][]*common.Release {
+		return map[string][]*common.Release{
+			"devtron": {
+				{TagName: "v0.7.1", Repository: "devtron", PublishedAt: publishedAt},
+				{TagName: "v0.7.0", Repository: "devtron", PublishedAt: publishedAt.Add(-time.Hour)},
+			},
+			"dashboard": {
+				{TagName: "v0.7.2", Repository: "dashboard", PublishedAt: publishedAt.Add(time.Hour)},
+				{TagName: "v0.7.1", Repository: "dashboard", PublishedAt: publishedAt},
+				{TagName: "v0.7.0", Repository: "dashboard", PublishedAt: publishedAt},
+			},
+		}
+	}
+	tests := []struct {
+		repos  []string
+		merged string
+	}{
+		{repos: []string{"devtron", "dashboard"}, merged: "dashboard/v0.7.2,devtron/v0.7.1,dashboard/v0.7.1,dashboard/v0.7.0,devtron/v0.7.0"},
+		{repos: []string{"dashboard", "devtron"}, merged: "dashboard/v0.7.2,dashboard/v0.7.1,dashboard/v0.7.0,devtron/v0.7.1,devtron/v0.7.0"},
+	}
+	for _, test := range tests {
+		releasesByRepo := newReleasesByRepo()
+		var ordered [][]*common.Release
+		for _, repo := range test.repos {
+			ordered = append(ordered, releasesByRepo[repo])
+		}
+		// the same input merges alike on every call
+		for i := 0; i < 3; i++ {
+			var merged []string
+			for _, release := range mergeChangelogReleases(test.repos, ordered) {
+				merged = append(merged, fmt.Sprintf("%s/%s", release.Repository, release.TagName))
+			}
+			if strings.Join(merged, ",") != test.merged {
+				t.Errorf("expected %s with priority %v, got %v", test.merged, test.repos, merged)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+)
+
+func normalizeModuleName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// validateModuleAliases fails when a name or alias identifies more than one module
+func validateModuleAliases(modules []*common.Module) error {
+	owners := make(map[string]string)
+	for _, module := range modules {
+		for _, name := range append([]string{module.Name}, module.Aliases...) {
+			key := normalizeModuleName(name)
+			if owner, ok := owners[key]; ok && owner != module.Name {
+				return fmt.Errorf("module name or alias %q of module %s is already used by module %s", name, module.Name, owner)
+			}
+			owners[key] = module.Name
+		}
+	}
+	return nil
+}
+
+// ResolveModuleName resolves a module name or alias case-insensitively, false when it is not in the catalog
+func (impl *ReleaseNoteServiceImpl) ResolveModuleName(name string) (string, bool) {
+	index, err := impl.getModuleIndex()
+	if err != nil {
+		return name, false
+	}
+	return index.resolveName(name)
+}
+
+func (index *moduleIndex) resolveName(name string) (string, bool) {
+	if _, ok := index.byName[name]; ok {
+		return name, true
+	}
+	if module, ok := index.byAlias[normalizeModuleName(name)]; ok {
+		return module.Name, true
+	}
+	return name, false
+}
+
+// resolveModuleNames keeps nil, which means the modules are unknown
+func (impl *ReleaseNoteServiceImpl) resolveModuleNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		canonical, _ := impl.ResolveModuleName(name)
+		resolved = append(resolved, canonical)
+	}
+	return resolved
+}
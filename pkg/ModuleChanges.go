@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"sort"
+)
+
+// GetModuleRecentChanges reads the changes from the component versions of the releases, newest first
+func (impl *ReleaseNoteServiceImpl) GetModuleRecentChanges(name string, limit int) ([]*common.ModuleChange, error) {
+	changes := make([]*common.ModuleChange, 0)
+	if limit <= 0 {
+		return changes, nil
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	name, _ = impl.ResolveModuleName(name)
+	var pinned []*common.Release
+	versions := make(map[*common.Release]string)
+	for _, release := range releases {
+		if release.PublishedAt.IsZero() {
+			continue
+		}
+		// components may be pinned under any alias of the module
+		for component, version := range release.Components {
+			if canonical, _ := impl.ResolveModuleName(component); canonical == name {
+				pinned = append(pinned, release)
+				versions[release] = version
+				break
+			}
+		}
+	}
+	sort.SliceStable(pinned, func(i, j int) bool {
+		return pinned[i].PublishedAt.Before(pinned[j].PublishedAt)
+	})
+	var previousVersion string
+	for _, release := range pinned {
+		version := versions[release]
+		if version != previousVersion {
+			changes = append(changes, &common.ModuleChange{
+				TagName:     release.TagName,
+				PublishedAt: release.PublishedAt,
+				Version:     version,
+				TagLink:     release.TagLink,
+			})
+		}
+		previousVersion = version
+	}
+	// newest first
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
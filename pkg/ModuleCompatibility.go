@@ -0,0 +1,84 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+)
+
+// validateModuleCatalog makes sure every kubernetes constraint of the catalog parses
+func validateModuleCatalog(modules []*common.Module) error {
+	err := validateModuleAliases(modules)
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		if len(module.KubernetesConstraint) == 0 {
+			continue
+		}
+		_, err := util.ParseVersionConstraint(module.KubernetesConstraint)
+		if err != nil {
+			return fmt.Errorf("invalid kubernetes constraint of module %s, %v", module.Name, err)
+		}
+	}
+	return nil
+}
+
+// FilterCompatibleModules skips the check of an empty version
+func FilterCompatibleModules(modules []*common.Module, serverVersion string, k8sVersion string) ([]*common.Module, error) {
+	var server, cluster *util.SemanticVersion
+	var err error
+	if len(serverVersion) > 0 {
+		server, err = util.ParseSemanticVersion(serverVersion)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid serverVersion %s", serverVersion)}
+		}
+	}
+	if len(k8sVersion) > 0 {
+		cluster, err = util.ParseSemanticVersion(k8sVersion)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid k8sVersion %s", k8sVersion)}
+		}
+		// distributions suffix the version like 1.27.3-eks-2d98532, the suffix is not a pre-release
+		cluster.Prerelease = ""
+	}
+	compatible := make([]*common.Module, 0, len(modules))
+	for _, module := range modules {
+		if server != nil {
+			minVersion, err := util.ParseSemanticVersion(module.BaseMinVersionSupported)
+			if err == nil && minVersion.Compare(server) > 0 {
+				continue
+			}
+		}
+		if cluster != nil && len(module.KubernetesConstraint) > 0 {
+			constraint, err := util.ParseVersionConstraint(module.KubernetesConstraint)
+			if err != nil {
+				return nil, err
+			}
+			if !constraint.Check(cluster) {
+				continue
+			}
+		}
+		compatible = append(compatible, module)
+	}
+	return compatible, nil
+}
+
+// GetModulesByVersion keeps modules without a parsable min version
+func (impl *ReleaseNoteServiceImpl) GetModulesByVersion(devtronVersion string) ([]*common.Module, error) {
+	devtronVersion = strings.TrimSpace(devtronVersion)
+	if len(devtronVersion) == 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "empty devtron version", UserMessage: "devtron version is required"}
+	}
+	if _, err := util.ParseSemanticVersion(devtronVersion); err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid devtron version %s", devtronVersion)}
+	}
+	modules, err := impl.GetModulesV2()
+	if err != nil {
+		impl.logger.Errorw("error on fetching modules", "err", err)
+		return nil, err
+	}
+	return FilterCompatibleModules(modules, devtronVersion, "")
+}
@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetModulesByVersion(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.catalogModules = []*common.Module{
+		{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.6.0"},
+		{Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0"},
+		{Id: 3, Name: "security.clair", BaseMinVersionSupported: "v0.7.1"},
+		{Id: 4, Name: "notifier"},
+	}
+	tests := []struct {
+		name    string
+		version string
+		modules string
+		status  int
+	}{
+		{name: "before every module", version: "v0.5.0", modules: "notifier"},
+		{name: "equal to the min version", version: "v0.7.0", modules: "cicd,argo-cd,notifier"},
+		{name: "just below the min version", version: "v0.7.0-rc.1", modules: "cicd,notifier"},
+		{name: "without prefix", version: "0.7.1", modules: "cicd,argo-cd,security.clair,notifier"},
+		{name: "padded", version: " v0.7.1 ", modules: "cicd,argo-cd,security.clair,notifier"},
+		{name: "empty version", version: "", status: http.StatusBadRequest},
+		{name: "blank version", version: "  ", status: http.StatusBadRequest},
+		{name: "malformed version", version: "latest", status: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules, err := impl.GetModulesByVersion(test.version)
+			if test.status != 0 {
+				if apiErr, ok := err.(*util.ApiError); !ok || apiErr.HttpStatusCode != test.status {
+					t.Fatalf("expected status %d, got %v", test.status, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var names []string
+			for _, module := range modules {
+				names = append(names, module.Name)
+			}
+			if strings.Join(names, ",") != test.modules {
+				t.Errorf("expected modules %s, got %v", test.modules, names)
+			}
+		})
+	}
+}
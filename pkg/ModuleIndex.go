@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"sync"
+)
+
+// moduleIndex shares the modules, callers must not modify them
+type moduleIndex struct {
+	modules []*common.Module
+	byName  map[string]*common.Module
+	byId    map[int]*common.Module
+	// byAlias holds the modules by their lower cased name and aliases
+	byAlias map[string]*common.Module
+}
+
+type moduleIndexCache struct {
+	mutex sync.Mutex
+	index *moduleIndex
+}
+
+func newModuleIndex(modules []*common.Module) *moduleIndex {
+	index := &moduleIndex{
+		modules: modules,
+		byName:  make(map[string]*common.Module, len(modules)),
+		byId:    make(map[int]*common.Module, len(modules)),
+		byAlias: make(map[string]*common.Module, len(modules)),
+	}
+	for _, module := range modules {
+		index.byName[module.Name] = module
+		index.byId[module.Id] = module
+		index.byAlias[normalizeModuleName(module.Name)] = module
+		for _, alias := range module.Aliases {
+			index.byAlias[normalizeModuleName(alias)] = module
+		}
+	}
+	return index
+}
+
+// getModuleIndex builds the index on every call when the lookup cache is disabled
+func (impl *ReleaseNoteServiceImpl) getModuleIndex() (*moduleIndex, error) {
+	if !impl.moduleConfig.ModuleConfig.ModuleLookupCacheEnabled {
+		return impl.buildModuleIndex()
+	}
+	impl.moduleIndex.mutex.Lock()
+	defer impl.moduleIndex.mutex.Unlock()
+	if impl.moduleIndex.index == nil {
+		index, err := impl.buildModuleIndex()
+		if err != nil {
+			return nil, err
+		}
+		impl.moduleIndex.index = index
+	}
+	return impl.moduleIndex.index, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) buildModuleIndex() (*moduleIndex, error) {
+	modules, err := impl.GetModulesV2()
+	if err != nil {
+		impl.logger.Errorw("error on fetching modules", "err", err)
+		return nil, err
+	}
+	return newModuleIndex(modules), nil
+}
+
+func (impl *ReleaseNoteServiceImpl) RebuildModuleIndex() {
+	impl.markContentModified()
+	impl.moduleIndex.mutex.Lock()
+	defer impl.moduleIndex.mutex.Unlock()
+	impl.moduleIndex.index = nil
+}
+
+// GetModuleById returns a copy of the module with the id, an empty module when there is none
+func (impl *ReleaseNoteServiceImpl) GetModuleById(id int) (*common.Module, error) {
+	index, err := impl.getModuleIndex()
+	if err != nil {
+		return &common.Module{}, err
+	}
+	module, ok := index.byId[id]
+	if !ok {
+		return &common.Module{}, nil
+	}
+	moduleCopy := *module
+	return &moduleCopy, nil
+}
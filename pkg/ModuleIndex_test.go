@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+)
+
+func newTestCatalogModules(count int) []*common.Module {
+	modules := make([]*common.Module, 0, count)
+	for i := 1; i <= count; i++ {
+		modules = append(modules, &common.Module{Id: i, Name: fmt.Sprintf("module-%d", i), Aliases: []string{fmt.Sprintf("alias-%d", i)}})
+	}
+	return modules
+}
+
+func TestModuleLookupsUseCachedIndex(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.moduleConfig.ModuleConfig.ModuleLookupCacheEnabled = true
+	impl.catalogModules = newTestCatalogModules(1000)
+
+	module, err := impl.GetModuleById(1000)
+	if err != nil || module.Name != "module-1000" {
+		t.Fatalf("expected module-1000, got %v, %v", module, err)
+	}
+	index := impl.moduleIndex.index
+	// lookups after the first one must be served from the index and not from the catalog
+	impl.catalogModules = []*common.Module{}
+	if module, _ := impl.GetModuleById(500); module.Name != "module-500" {
+		t.Errorf("expected the id to be looked up in the index, got %v", module)
+	}
+	if name, ok := impl.ResolveModuleName("ALIAS-42"); !ok || name != "module-42" {
+		t.Errorf("expected the alias to be looked up in the index, got %s", name)
+	}
+	if impl.moduleIndex.index != index {
+		t.Errorf("expected the index to be built once")
+	}
+
+	impl.RebuildModuleIndex()
+	if module, _ := impl.GetModuleById(500); module.Name != "" {
+		t.Errorf("expected the rebuilt index to drop the removed module, got %v", module)
+	}
+}
+
+// BenchmarkGetModuleById looks up a module in a large catalog, with the cache the index is built once and the
+// lookup is a map read
+func BenchmarkGetModuleById(b *testing.B) {
+	for _, cached := range []bool{true, false} {
+		b.Run(fmt.Sprintf("cached=%t", cached), func(b *testing.B) {
+			impl := newTestReleaseNoteService(b)
+			impl.moduleConfig.ModuleConfig.ModuleLookupCacheEnabled = cached
+			impl.catalogModules = newTestCatalogModules(10000)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := impl.GetModuleById(i%10000 + 1)
+				if err != nil {
+					b.Fatalf("unexpected error %v", err)
+				}
+			}
+		})
+	}
+}
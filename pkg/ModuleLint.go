@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"strings"
+)
+
+// peekCachedReleases never fetches, nil when nothing is cached yet
+func (impl *ReleaseNoteServiceImpl) peekCachedReleases() []*common.Release {
+	if impl.blobConfig.CloudConfigured {
+		return releaseCache[CACHE_KEY]
+	}
+	releaseNoteObj, err := impl.releaseNoteRepository.FindActive()
+	if err != nil || releaseNoteObj == nil {
+		return nil
+	}
+	return releaseNoteObj.ReleaseNote
+}
+
+func lintModuleVersions(module *common.Module, releasedTags map[string]bool) []string {
+	var findings []string
+	if _, err := util.ParseSemanticVersion(module.BaseMinVersionSupported); err != nil {
+		findings = append(findings, fmt.Sprintf("baseMinVersionSupported %q is not a valid version", module.BaseMinVersionSupported))
+	} else if releasedTags != nil && !releasedTags[util.NormalizeVersionTag(module.BaseMinVersionSupported)] {
+		findings = append(findings, fmt.Sprintf("baseMinVersionSupported %s is not a released tag", module.BaseMinVersionSupported))
+	}
+	return findings
+}
+
+// ValidateModules reports every dangling dependency and dependency cycle
+func ValidateModules(modules []*common.Module) error {
+	names := make(map[int]string, len(modules))
+	for _, module := range modules {
+		names[module.Id] = module.Name
+	}
+	var problems []string
+	for _, module := range modules {
+		for _, dependencyId := range module.DependentModules {
+			if _, ok := names[dependencyId]; !ok {
+				problems = append(problems, fmt.Sprintf("module %s depends on unknown module id %d", module.Name, dependencyId))
+			} else if dependencyId == module.Id {
+				problems = append(problems, fmt.Sprintf("module %s depends on itself", module.Name))
+			}
+		}
+	}
+	// dangling ids are ignored by the sort, so a cycle is reported on top of them
+	if _, err := SortModulesByDependency(modules); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid module dependencies, %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ValidateModuleDependencyVersions reports modules depending on a module requiring a higher version
+func ValidateModuleDependencyVersions(modules []*common.Module) error {
+	var conflicts []string
+	for _, module := range modules {
+		conflicts = append(conflicts, getDependencyVersionConflicts(module, modules)...)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("inconsistent module min versions, %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+func getDependencyVersionConflicts(module *common.Module, modules []*common.Module) []string {
+	moduleVersion, err := util.ParseSemanticVersion(module.BaseMinVersionSupported)
+	if err != nil {
+		return nil
+	}
+	var conflicts []string
+	for _, dependencyId := range module.DependentModules {
+		for _, dependency := range modules {
+			if dependency.Id != dependencyId {
+				continue
+			}
+			dependencyVersion, err := util.ParseSemanticVersion(dependency.BaseMinVersionSupported)
+			if err == nil && dependencyVersion.Compare(moduleVersion) > 0 {
+				conflicts = append(conflicts, fmt.Sprintf("%s requires %s but depends on %s which requires %s", module.Name, module.BaseMinVersionSupported, dependency.Name, dependency.BaseMinVersionSupported))
+			}
+		}
+	}
+	return conflicts
+}
+
+// GetModuleLintReport checks only the version format before the releases are cached
+func (impl *ReleaseNoteServiceImpl) GetModuleLintReport() ([]*common.ModuleLintResult, error) {
+	modules, err := impl.GetModulesV2()
+	if err != nil {
+		impl.logger.Errorw("error on fetching modules", "err", err)
+		return nil, err
+	}
+	var releasedTags map[string]bool
+	if releases := impl.peekCachedReleases(); len(releases) > 0 {
+		releasedTags = make(map[string]bool, len(releases))
+		for _, release := range releases {
+			releasedTags[util.NormalizeVersionTag(release.TagName)] = true
+		}
+	}
+	lintResults := make([]*common.ModuleLintResult, 0)
+	for _, module := range modules {
+		findings := lintModuleVersions(module, releasedTags)
+		findings = append(findings, getDependencyVersionConflicts(module, modules)...)
+		if len(findings) == 0 {
+			continue
+		}
+		lintResults = append(lintResults, &common.ModuleLintResult{
+			Name:     module.Name,
+			Findings: findings,
+		})
+	}
+	return lintResults, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) logModuleLintReport() {
+	lintResults, err := impl.GetModuleLintReport()
+	if err != nil {
+		impl.logger.Errorw("error in validating module catalog with releases", "err", err)
+		return
+	}
+	impl.metrics.moduleLintFailures.Set(float64(len(lintResults)))
+	for _, lintResult := range lintResults {
+		impl.logger.Warnw("module catalog validation failed", "module", lintResult.Name, "findings", lintResult.Findings)
+	}
+}
@@ -0,0 +1,150 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/pkg/releaseNote"
+	dto "github.com/prometheus/client_model/go"
+	"strings"
+	"testing"
+)
+
+func TestValidateModules(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  []*common.Module
+		problems []string
+	}{
+		{
+			name:    "valid dependencies",
+			modules: []*common.Module{{Id: 1, Name: "cicd"}, {Id: 2, Name: "argo-cd", DependentModules: []int{1}}, {Id: 3, Name: "security.clair", DependentModules: []int{1, 2}}},
+		},
+		{
+			name:     "dangling reference",
+			modules:  []*common.Module{{Id: 1, Name: "cicd"}, {Id: 2, Name: "argo-cd", DependentModules: []int{1, 9}}},
+			problems: []string{"module argo-cd depends on unknown module id 9"},
+		},
+		{
+			name:     "self dependency",
+			modules:  []*common.Module{{Id: 1, Name: "cicd", DependentModules: []int{1}}},
+			problems: []string{"module cicd depends on itself"},
+		},
+		{
+			name:     "dependency cycle",
+			modules:  []*common.Module{{Id: 1, Name: "cicd", DependentModules: []int{3}}, {Id: 2, Name: "argo-cd", DependentModules: []int{1}}, {Id: 3, Name: "security.clair", DependentModules: []int{2}}},
+			problems: []string{"cycle"},
+		},
+		{
+			name:     "every problem listed",
+			modules:  []*common.Module{{Id: 1, Name: "cicd", DependentModules: []int{2, 7}}, {Id: 2, Name: "argo-cd", DependentModules: []int{1, 8}}},
+			problems: []string{"module cicd depends on unknown module id 7", "module argo-cd depends on unknown module id 8", "cycle"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateModules(test.modules)
+			if len(test.problems) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %v to be reported", test.problems)
+			}
+			for _, problem := range test.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Errorf("expected %q to be reported, got %v", problem, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLogModuleLintReportBeforeAndAfterReleasesCached(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.catalogModules = []*common.Module{
+		{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.6.0"},
+		{Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.9.0"},
+		{Id: 3, Name: "security.clair", BaseMinVersionSupported: "latest"},
+	}
+	lintFailures := func() float64 {
+		metric := &dto.Metric{}
+		if err := impl.metrics.moduleLintFailures.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+		return metric.GetGauge().GetValue()
+	}
+
+	// the catalog is loaded before the releases are cached, only the version format can be checked
+	impl.logModuleLintReport()
+	if failures := lintFailures(); failures != 1 {
+		t.Errorf("expected the invalid version only to be reported before the releases are cached, got %v", failures)
+	}
+
+	impl.releaseNoteRepository = &fakeReleaseNoteRepository{releaseNote: &releaseNote.ReleaseNote{ReleaseNote: []*common.Release{{TagName: "v0.6.0"}, {TagName: "v0.7.0"}}}}
+	impl.logModuleLintReport()
+	if failures := lintFailures(); failures != 2 {
+		t.Errorf("expected the unreleased tag to be reported once the releases are cached, got %v", failures)
+	}
+	lintResults, err := impl.GetModuleLintReport()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(lintResults) != 2 || lintResults[0].Name != "argo-cd" || !strings.Contains(lintResults[0].Findings[0], "v0.9.0 is not a released tag") {
+		t.Errorf("expected argo-cd to refer to an unreleased tag, got %v", lintResults)
+	}
+}
+
+func TestValidateModuleDependencyVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		modules   []*common.Module
+		conflicts []string
+	}{
+		{
+			name:    "dependency requiring a lower version",
+			modules: []*common.Module{{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.6.0"}, {Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0", DependentModules: []int{1}}},
+		},
+		{
+			name:    "dependency requiring the same version",
+			modules: []*common.Module{{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.7.0"}, {Id: 2, Name: "argo-cd", BaseMinVersionSupported: "0.7.0", DependentModules: []int{1}}},
+		},
+		{
+			name:      "dependency requiring a higher version",
+			modules:   []*common.Module{{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.7.1"}, {Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0", DependentModules: []int{1}}},
+			conflicts: []string{"argo-cd requires v0.7.0 but depends on cicd which requires v0.7.1"},
+		},
+		{
+			name: "every conflict listed",
+			modules: []*common.Module{
+				{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.8.0"},
+				{Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0", DependentModules: []int{1}},
+				{Id: 3, Name: "security.clair", BaseMinVersionSupported: "v0.6.0", DependentModules: []int{2}},
+			},
+			conflicts: []string{"argo-cd requires v0.7.0 but depends on cicd which requires v0.8.0", "security.clair requires v0.6.0 but depends on argo-cd which requires v0.7.0"},
+		},
+		{
+			name:    "unparsable version skipped",
+			modules: []*common.Module{{Id: 1, Name: "cicd", BaseMinVersionSupported: "latest"}, {Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0", DependentModules: []int{1}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateModuleDependencyVersions(test.modules)
+			if len(test.conflicts) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %v to be reported", test.conflicts)
+			}
+			for _, conflict := range test.conflicts {
+				if !strings.Contains(err.Error(), conflict) {
+					t.Errorf("expected %q to be reported, got %v", conflict, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"sort"
+	"strings"
+)
+
+const ModuleOrderDependency = "dependency"
+
+// SortModulesByDependency orders modules after their dependencies, ties broken by id and then name
+func SortModulesByDependency(modules []*common.Module) ([]*common.Module, error) {
+	present := make(map[int]bool, len(modules))
+	for _, module := range modules {
+		present[module.Id] = true
+	}
+	inDegree := make(map[*common.Module]int, len(modules))
+	dependentsById := make(map[int][]*common.Module)
+	for _, module := range modules {
+		inDegree[module] = 0
+		for _, dependencyId := range module.DependentModules {
+			if !present[dependencyId] {
+				continue
+			}
+			inDegree[module]++
+			dependentsById[dependencyId] = append(dependentsById[dependencyId], module)
+		}
+	}
+	var ready []*common.Module
+	for _, module := range modules {
+		if inDegree[module] == 0 {
+			ready = append(ready, module)
+		}
+	}
+	sorted := make([]*common.Module, 0, len(modules))
+	for len(ready) > 0 {
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].Id != ready[j].Id {
+				return ready[i].Id < ready[j].Id
+			}
+			return ready[i].Name < ready[j].Name
+		})
+		module := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, module)
+		for _, dependent := range dependentsById[module.Id] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(sorted) != len(modules) {
+		var cyclic []string
+		for _, module := range modules {
+			if inDegree[module] > 0 {
+				cyclic = append(cyclic, module.Name)
+			}
+		}
+		return nil, fmt.Errorf("cycle in module dependencies between modules %s", strings.Join(cyclic, ", "))
+	}
+	return sorted, nil
+}
@@ -0,0 +1,221 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+func loadModuleCatalogFile(path string) (*common.ModuleCatalogFile, error) {
+	catalogFile := &common.ModuleCatalogFile{}
+	if len(path) == 0 {
+		return catalogFile, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(content, catalogFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module catalog file %s, %v", path, err)
+	}
+	if catalogFile.Modules != nil && len(catalogFile.Modules) == 0 {
+		// an empty section would replace the built in modules with none
+		return nil, fmt.Errorf("invalid module catalog file %s, modules section is empty", path)
+	}
+	err = validateModuleDefinitions(catalogFile.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module catalog file %s, %v", path, err)
+	}
+	return catalogFile, nil
+}
+
+// loadModuleCatalog keeps the built in modules when the catalog file defines none
+func (impl *ReleaseNoteServiceImpl) loadModuleCatalog() error {
+	catalogFile, err := loadModuleCatalogFile(impl.moduleConfig.ModuleConfig.CatalogFile)
+	if err != nil {
+		return err
+	}
+	impl.catalogModules = catalogFile.Modules
+	impl.catalogProfiles = catalogFile.Profiles
+	return ValidateModules(impl.getBaseModules())
+}
+
+func validateModuleDefinitions(modules []*common.Module) error {
+	ids := make(map[int]string, len(modules))
+	names := make(map[string]bool, len(modules))
+	for index, module := range modules {
+		if module == nil {
+			return fmt.Errorf("module at index %d is null", index)
+		}
+		if len(strings.TrimSpace(module.Name)) == 0 {
+			return fmt.Errorf("module at index %d has no name", index)
+		}
+		if module.Id <= 0 {
+			return fmt.Errorf("module %s has no id, ids are positive", module.Name)
+		}
+		if owner, ok := ids[module.Id]; ok {
+			return fmt.Errorf("module id %d of module %s is already used by module %s", module.Id, module.Name, owner)
+		}
+		if names[module.Name] {
+			return fmt.Errorf("module %s is defined twice", module.Name)
+		}
+		if len(module.BaseMinVersionSupported) > 0 {
+			if _, err := util.ParseSemanticVersion(module.BaseMinVersionSupported); err != nil {
+				return fmt.Errorf("invalid base min version of module %s, %v", module.Name, err)
+			}
+		}
+		ids[module.Id] = module.Name
+		names[module.Name] = true
+	}
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) validateCatalogProfiles() error {
+	activeProfile := impl.moduleConfig.ModuleConfig.CatalogProfile
+	if _, ok := impl.catalogProfiles[activeProfile]; len(activeProfile) > 0 && !ok {
+		return fmt.Errorf("catalog profile %s not found in module catalog file %q", activeProfile, impl.moduleConfig.ModuleConfig.CatalogFile)
+	}
+	names := make([]string, 0, len(impl.catalogProfiles))
+	for name := range impl.catalogProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	base := impl.getBaseModules()
+	for _, name := range names {
+		modules, err := resolveCatalogProfile(base, impl.catalogProfiles[name])
+		if err == nil {
+			err = validateModuleCatalog(modules)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid catalog profile %s, %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetModulesForProfile(profile string) ([]*common.Module, error) {
+	var catalogProfile *common.CatalogProfile
+	if len(profile) > 0 {
+		var ok bool
+		catalogProfile, ok = impl.catalogProfiles[profile]
+		if !ok {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("catalog profile %s not found", profile), UserMessage: "catalog profile not found"}
+		}
+	}
+	modules, err := resolveCatalogProfile(impl.getBaseModules(), catalogProfile)
+	if err != nil {
+		impl.logger.Errorw("error in resolving catalog profile", "profile", profile, "err", err)
+		return nil, err
+	}
+	impl.applyModuleFeatureFlags(modules)
+	return modules, nil
+}
+
+// ResolveCatalogProfile checks the signature, the hex hmac sha256 of the profile name
+func (impl *ReleaseNoteServiceImpl) ResolveCatalogProfile(profile string, signature string) (string, error) {
+	profile = strings.TrimSpace(profile)
+	if len(profile) == 0 {
+		return impl.moduleConfig.ModuleConfig.CatalogProfile, nil
+	}
+	secret := impl.moduleConfig.ModuleConfig.CatalogProfileSecret
+	if len(secret) == 0 {
+		return "", &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "catalog profile header refused, no profile secret configured", UserMessage: "catalog profile selection is disabled"}
+	}
+	if !hmac.Equal([]byte(strings.ToLower(strings.TrimSpace(signature))), []byte(signCatalogProfile(secret, profile))) {
+		return "", &util.ApiError{HttpStatusCode: http.StatusUnauthorized, InternalMessage: fmt.Sprintf("invalid signature of catalog profile %s", profile), UserMessage: "invalid catalog profile signature"}
+	}
+	if _, ok := impl.catalogProfiles[profile]; !ok {
+		return "", &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("catalog profile %s not found", profile), UserMessage: "catalog profile not found"}
+	}
+	return profile, nil
+}
+
+func signCatalogProfile(secret string, profile string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(profile))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolveCatalogProfile leaves the base modules unmodified, an include list keeps only the modules it names
+func resolveCatalogProfile(base []*common.Module, profile *common.CatalogProfile) ([]*common.Module, error) {
+	if profile == nil {
+		return base, nil
+	}
+	index := newModuleIndex(base)
+	included, err := resolveProfileModuleNames(index, profile.Include)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := resolveProfileModuleNames(index, profile.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]json.RawMessage, len(profile.Overrides))
+	for name, override := range profile.Overrides {
+		canonical, ok := index.resolveName(name)
+		if !ok {
+			return nil, fmt.Errorf("override of unknown module %s", name)
+		}
+		overrides[canonical] = override
+	}
+	modules := make([]*common.Module, 0, len(base)+len(profile.Modules))
+	for _, module := range base {
+		if (profile.Include != nil && !included[module.Name]) || excluded[module.Name] {
+			continue
+		}
+		if override, ok := overrides[module.Name]; ok {
+			module, err = overrideModule(module, override)
+			if err != nil {
+				return nil, err
+			}
+		}
+		modules = append(modules, module)
+	}
+	for _, module := range profile.Modules {
+		moduleCopy := *module
+		modules = append(modules, &moduleCopy)
+	}
+	return modules, nil
+}
+
+func resolveProfileModuleNames(index *moduleIndex, names []string) (map[string]bool, error) {
+	resolved := make(map[string]bool, len(names))
+	for _, name := range names {
+		canonical, ok := index.resolveName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown module %s", name)
+		}
+		resolved[canonical] = true
+	}
+	return resolved, nil
+}
+
+// overrideModule copies through json so that the copy shares no slice or map with the module
+func overrideModule(module *common.Module, override json.RawMessage) (*common.Module, error) {
+	content, err := json.Marshal(module)
+	if err != nil {
+		return nil, err
+	}
+	overridden := &common.Module{}
+	err = json.Unmarshal(content, overridden)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(override, overridden)
+	if err != nil {
+		return nil, fmt.Errorf("invalid override of module %s, %v", module.Name, err)
+	}
+	if overridden.Name != module.Name {
+		return nil, fmt.Errorf("override of module %s can not rename it", module.Name)
+	}
+	return overridden, nil
+}
@@ -0,0 +1,187 @@
+package pkg
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestBaseModules() []*common.Module {
+	return []*common.Module{
+		{Id: 1, Name: "cicd", Title: "Build and Deploy"},
+		{Id: 2, Name: "argo-cd", Aliases: []string{"argocd"}, Title: "GitOps"},
+		{Id: 3, Name: "security.clair", Title: "Vulnerability scanning"},
+	}
+}
+
+func TestResolveCatalogProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *common.CatalogProfile
+		modules string
+		titles  map[string]string
+		err     string
+	}{
+		{name: "base catalog", modules: "cicd,argo-cd,security.clair"},
+		{name: "include", profile: &common.CatalogProfile{Include: []string{"cicd", "argocd"}}, modules: "cicd,argo-cd"},
+		{name: "empty include", profile: &common.CatalogProfile{Include: []string{}}, modules: ""},
+		{name: "exclude", profile: &common.CatalogProfile{Exclude: []string{"security.clair"}}, modules: "cicd,argo-cd"},
+		{name: "exclude wins over include", profile: &common.CatalogProfile{Include: []string{"cicd", "argo-cd"}, Exclude: []string{"argo-cd"}}, modules: "cicd"},
+		{name: "override", profile: &common.CatalogProfile{Overrides: map[string]json.RawMessage{"argocd": json.RawMessage(`{"title": "Enterprise GitOps"}`)}}, modules: "cicd,argo-cd,security.clair", titles: map[string]string{"argo-cd": "Enterprise GitOps", "cicd": "Build and Deploy"}},
+		{name: "profile modules", profile: &common.CatalogProfile{Modules: []*common.Module{{Id: 4, Name: "enterprise.sso"}}}, modules: "cicd,argo-cd,security.clair,enterprise.sso"},
+		{name: "unknown include", profile: &common.CatalogProfile{Include: []string{"fluxcd"}}, err: "unknown module fluxcd"},
+		{name: "unknown override", profile: &common.CatalogProfile{Overrides: map[string]json.RawMessage{"fluxcd": json.RawMessage(`{}`)}}, err: "override of unknown module fluxcd"},
+		{name: "renaming override", profile: &common.CatalogProfile{Overrides: map[string]json.RawMessage{"cicd": json.RawMessage(`{"name": "ci"}`)}}, err: "can not rename"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base := newTestBaseModules()
+			modules, err := resolveCatalogProfile(base, test.profile)
+			if len(test.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error with %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var names []string
+			for _, module := range modules {
+				names = append(names, module.Name)
+				if title, ok := test.titles[module.Name]; ok && module.Title != title {
+					t.Errorf("expected title %q of module %s, got %q", title, module.Name, module.Title)
+				}
+			}
+			if strings.Join(names, ",") != test.modules {
+				t.Errorf("expected modules %s, got %v", test.modules, names)
+			}
+			if base[1].Title != "GitOps" {
+				t.Errorf("expected the base modules to be left as they were")
+			}
+		})
+	}
+}
+
+func TestResolveCatalogProfileOfRequest(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.moduleConfig.ModuleConfig.CatalogProfile = "oss"
+	impl.moduleConfig.ModuleConfig.CatalogProfileSecret = "profile-secret"
+	impl.catalogProfiles = map[string]*common.CatalogProfile{"oss": {}, "enterprise": {}}
+	tests := []struct {
+		name      string
+		profile   string
+		signature string
+		resolved  string
+		status    int
+	}{
+		{name: "profile of the deployment", resolved: "oss"},
+		{name: "signed profile", profile: "enterprise", signature: signCatalogProfile("profile-secret", "enterprise"), resolved: "enterprise"},
+		{name: "upper case signature", profile: "enterprise", signature: strings.ToUpper(signCatalogProfile("profile-secret", "enterprise")), resolved: "enterprise"},
+		{name: "signature of another profile", profile: "enterprise", signature: signCatalogProfile("profile-secret", "oss"), status: http.StatusUnauthorized},
+		{name: "signature of another secret", profile: "enterprise", signature: signCatalogProfile("another-secret", "enterprise"), status: http.StatusUnauthorized},
+		{name: "unknown profile", profile: "staging", signature: signCatalogProfile("profile-secret", "staging"), status: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolved, err := impl.ResolveCatalogProfile(test.profile, test.signature)
+			if test.status != 0 {
+				if apiErr, ok := err.(*util.ApiError); !ok || apiErr.HttpStatusCode != test.status {
+					t.Fatalf("expected status %d, got %v", test.status, err)
+				}
+				return
+			}
+			if err != nil || resolved != test.resolved {
+				t.Errorf("expected profile %s, got %s, %v", test.resolved, resolved, err)
+			}
+		})
+	}
+
+	impl.moduleConfig.ModuleConfig.CatalogProfileSecret = ""
+	if _, err := impl.ResolveCatalogProfile("enterprise", signCatalogProfile("profile-secret", "enterprise")); err == nil {
+		t.Errorf("expected the profile header to be refused without a secret")
+	}
+}
+
+func writeTestCatalogFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "module_catalog.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadModuleCatalog(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.moduleConfig.ModuleConfig.CatalogFile = "testdata/module_catalog.json"
+	err := impl.loadModuleCatalog()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	modules := impl.getBaseModules()
+	if len(modules) != 3 || modules[1].Name != "argo-cd" || len(modules[1].DependentModules) != 1 || modules[1].DependentModules[0] != 1 {
+		t.Fatalf("expected the modules of the catalog file with their dependencies, got %d modules", len(modules))
+	}
+	err = impl.validateCatalogProfiles()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	modules, err = impl.GetModulesForProfile("enterprise")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var names []string
+	for _, module := range modules {
+		names = append(names, module.Name)
+	}
+	if strings.Join(names, ",") != "cicd,argo-cd,enterprise.sso" {
+		t.Errorf("expected the enterprise profile over the modules of the file, got %v", names)
+	}
+}
+
+func TestLoadModuleCatalogRefusesInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "malformed json", content: `{"modules": [{"id": 1, "name": "cicd"}`, err: "invalid module catalog file"},
+		{name: "empty modules", content: `{"modules": []}`, err: "modules section is empty"},
+		{name: "module without name", content: `{"modules": [{"id": 1}]}`, err: "module at index 0 has no name"},
+		{name: "module without id", content: `{"modules": [{"name": "cicd"}]}`, err: "module cicd has no id"},
+		{name: "duplicate id", content: `{"modules": [{"id": 1, "name": "cicd"}, {"id": 1, "name": "argo-cd"}]}`, err: "already used by module cicd"},
+		{name: "invalid version", content: `{"modules": [{"id": 1, "name": "cicd", "baseMinVersionSupported": "latest"}]}`, err: "invalid base min version of module cicd"},
+		{name: "dangling dependency", content: `{"modules": [{"id": 1, "name": "cicd", "dependentModules": [7]}]}`, err: "depends on unknown module id 7"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			impl.moduleConfig.ModuleConfig.CatalogFile = writeTestCatalogFile(t, test.content)
+			err := impl.loadModuleCatalog()
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error with %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadModuleCatalogKeepsBuiltInModules(t *testing.T) {
+	for _, content := range []string{`{"profiles": {"oss": {}}}`, `{"modules": null}`} {
+		impl := newTestReleaseNoteService(t)
+		builtIn := len(impl.getBaseModules())
+		impl.moduleConfig.ModuleConfig.CatalogFile = writeTestCatalogFile(t, content)
+		err := impl.loadModuleCatalog()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if modules := impl.getBaseModules(); len(modules) != builtIn || builtIn == 0 {
+			t.Errorf("expected the %d built in modules to be kept with %s, got %d", builtIn, content, len(modules))
+		}
+	}
+}
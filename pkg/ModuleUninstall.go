@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+)
+
+func addDependentUninstallWarnings(modules []*common.Module, module *common.Module) error {
+	dependents, err := getDisableImpact(modules, module.Name)
+	if err != nil {
+		return err
+	}
+	for _, dependent := range dependents {
+		module.UninstallWarnings = append(module.UninstallWarnings, fmt.Sprintf("%s (%s) depends on this module and will stop working.", dependent.Title, dependent.Name))
+	}
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetModuleUninstallInfo(name string) (*common.ModuleUninstallInfo, error) {
+	module, err := impl.GetModuleByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(module.Name) == 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("module not found, name: %s", name), UserMessage: "module not found"}
+	}
+	uninstallInfo := &common.ModuleUninstallInfo{
+		Name:               module.Name,
+		UninstallWarnings:  []string{},
+		UninstallBlockedBy: []string{},
+	}
+	uninstallInfo.UninstallWarnings = append(uninstallInfo.UninstallWarnings, module.UninstallWarnings...)
+	uninstallInfo.UninstallBlockedBy = append(uninstallInfo.UninstallBlockedBy, module.UninstallBlockedBy...)
+	return uninstallInfo, nil
+}
@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+)
+
+// GetNewModulesBetween returns the modules whose min supported version is in (fromVersion, toVersion]
+func (impl *ReleaseNoteServiceImpl) GetNewModulesBetween(fromVersion string, toVersion string) ([]*common.Module, error) {
+	from, err := util.ParseSemanticVersion(fromVersion)
+	if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid fromVersion %s", fromVersion)}
+	}
+	to, err := util.ParseSemanticVersion(toVersion)
+	if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid toVersion %s", toVersion)}
+	}
+	modules, err := impl.GetModulesV2()
+	if err != nil {
+		impl.logger.Errorw("error on fetching modules", "err", err)
+		return nil, err
+	}
+	newModules := make([]*common.Module, 0)
+	for _, module := range modules {
+		minVersion, err := util.ParseSemanticVersion(module.BaseMinVersionSupported)
+		if err != nil {
+			impl.logger.Warnw("ignoring module with invalid min supported version", "module", module.Name, "version", module.BaseMinVersionSupported)
+			continue
+		}
+		if minVersion.Compare(from) > 0 && minVersion.Compare(to) <= 0 {
+			newModules = append(newModules, module)
+		}
+	}
+	return newModules, nil
+}
+
+var ErrIntroducingReleaseNotFound = &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: "no cached release matches the module min supported version", UserMessage: "introducing release not found"}
+
+func (impl *ReleaseNoteServiceImpl) GetIntroducingRelease(moduleId int) (*common.Release, error) {
+	index, err := impl.getModuleIndex()
+	if err != nil {
+		return nil, err
+	}
+	module, ok := index.byId[moduleId]
+	if !ok {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("module not found, id: %d", moduleId), UserMessage: "module not found"}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if util.IsSameVersionTag(release.TagName, module.BaseMinVersionSupported) {
+			return release, nil
+		}
+	}
+	return nil, ErrIntroducingReleaseNotFound
+}
@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestVersionedModules() []*common.Module {
+	return []*common.Module{
+		{Id: 1, Name: "cicd", BaseMinVersionSupported: "v0.6.0"},
+		{Id: 2, Name: "argo-cd", BaseMinVersionSupported: "v0.7.0"},
+		{Id: 3, Name: "security.clair", BaseMinVersionSupported: "0.7.1"},
+		{Id: 4, Name: "notifier", BaseMinVersionSupported: "v0.8.0"},
+		{Id: 5, Name: "monitoring.grafana", BaseMinVersionSupported: "latest"},
+	}
+}
+
+func TestGetNewModulesBetween(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.catalogModules = newTestVersionedModules()
+	tests := []struct {
+		name        string
+		fromVersion string
+		toVersion   string
+		modules     string
+	}{
+		{name: "upgrade unlocks modules", fromVersion: "v0.6.0", toVersion: "v0.7.1", modules: "argo-cd,security.clair"},
+		{name: "from version excluded", fromVersion: "v0.7.0", toVersion: "v0.7.1", modules: "security.clair"},
+		{name: "patch upgrade unlocks nothing", fromVersion: "v0.7.1", toVersion: "v0.7.2"},
+		{name: "same version", fromVersion: "v0.8.0", toVersion: "0.8.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules, err := impl.GetNewModulesBetween(test.fromVersion, test.toVersion)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if modules == nil {
+				t.Fatalf("expected an empty list rather than nil")
+			}
+			var names []string
+			for _, module := range modules {
+				names = append(names, module.Name)
+			}
+			if strings.Join(names, ",") != test.modules {
+				t.Errorf("expected %q, got %v", test.modules, names)
+			}
+		})
+	}
+
+	for _, versions := range [][2]string{{"", "v0.7.0"}, {"v0.6.0", "latest"}} {
+		_, err := impl.GetNewModulesBetween(versions[0], versions[1])
+		if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+			t.Errorf("expected %q to %q to be refused, got %v", versions[0], versions[1], err)
+		}
+	}
+}
+
+func TestGetIntroducingRelease(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.catalogModules = newTestVersionedModules()
+	tests := []struct {
+		name     string
+		moduleId int
+		tag      string
+		status   int
+	}{
+		{name: "min version released", moduleId: 2, tag: "v0.7.0"},
+		{name: "min version without v prefix", moduleId: 3, tag: "v0.7.1"},
+		{name: "min version not released", moduleId: 4, status: http.StatusNotFound},
+		{name: "unknown module", moduleId: 9, status: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release, err := impl.GetIntroducingRelease(test.moduleId)
+			if test.status != 0 {
+				if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != test.status {
+					t.Errorf("expected status %d, got %v", test.status, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if release.TagName != test.tag {
+				t.Errorf("expected %s, got %s", test.tag, release.TagName)
+			}
+		})
+	}
+
+	if _, err := impl.GetIntroducingRelease(4); err != ErrIntroducingReleaseNotFound {
+		t.Errorf("expected the unreleased min version to be told from an unknown module, got %v", err)
+	}
+	if _, err := impl.GetIntroducingRelease(9); err == ErrIntroducingReleaseNotFound {
+		t.Errorf("expected an unknown module not to be reported as an unreleased min version")
+	}
+}
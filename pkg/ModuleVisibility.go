@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+)
+
+// applyModuleFeatureFlags sets the feature flag gating each module from the module config
+func (impl *ReleaseNoteServiceImpl) applyModuleFeatureFlags(modules []*common.Module) {
+	for _, module := range modules {
+		module.FeatureFlag = impl.moduleConfig.FeatureFlags[module.Name]
+	}
+}
+
+// ParseFeatureFlags splits the comma separated feature flags of a request
+func ParseFeatureFlags(featureFlags string) []string {
+	var flags []string
+	for _, flag := range strings.Split(featureFlags, ",") {
+		if flag = strings.TrimSpace(flag); len(flag) > 0 {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// FilterVisibleModules drops the modules gated by a feature flag not enabled
+func FilterVisibleModules(modules []*common.Module, featureFlags []string) []*common.Module {
+	enabled := make(map[string]bool, len(featureFlags))
+	for _, flag := range featureFlags {
+		enabled[flag] = true
+	}
+	visible := make([]*common.Module, 0, len(modules))
+	for _, module := range modules {
+		if len(module.FeatureFlag) > 0 && !enabled[module.FeatureFlag] {
+			continue
+		}
+		visible = append(visible, module)
+	}
+	return visible
+}
@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+func TestFeatureFlagHidesModule(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.catalogModules = []*common.Module{{Id: 1, Name: "cicd"}, {Id: 2, Name: "argo-cd"}, {Id: 3, Name: "security.trivy"}}
+	impl.moduleConfig.FeatureFlags = map[string]string{"security.trivy": "beta-trivy"}
+	modules, err := impl.GetModulesV2()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tests := []struct {
+		name         string
+		featureFlags string
+		modules      string
+	}{
+		{name: "no feature flag", modules: "cicd,argo-cd"},
+		{name: "other feature flag", featureFlags: "beta-argo", modules: "cicd,argo-cd"},
+		{name: "gating feature flag", featureFlags: "beta-argo, beta-trivy", modules: "cicd,argo-cd,security.trivy"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var names []string
+			for _, module := range FilterVisibleModules(modules, ParseFeatureFlags(test.featureFlags)) {
+				names = append(names, module.Name)
+			}
+			if strings.Join(names, ",") != test.modules {
+				t.Errorf("expected the visible modules %s, got %v", test.modules, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"sync"
+	"time"
+)
+
+type syncState struct {
+	mutex  sync.RWMutex
+	result *common.SyncResult
+}
+
+// recordSync keeps the outcome of the last fetch of the releases from the source
+func (impl *ReleaseNoteServiceImpl) recordSync(releaseCount int, err error) {
+	result := &common.SyncResult{At: time.Now().UTC(), Success: err == nil, ReleaseCount: releaseCount}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if err == nil {
+		impl.markReleasesRefreshed(result.At)
+	}
+	impl.lastSync.mutex.Lock()
+	defer impl.lastSync.mutex.Unlock()
+	impl.lastSync.result = result
+}
+
+func (impl *ReleaseNoteServiceImpl) GetOperationalStats() *common.OperationalStats {
+	stats := &common.OperationalStats{
+		WebhookEventsProcessed: impl.metrics.getWebhookEventsProcessed(),
+		CacheSizes: map[string]int{
+			"releases":        len(impl.peekCachedReleases()),
+			"derivedViews":    impl.derivedViews.size(),
+			"imageDimensions": impl.imageDimensions.size(),
+		},
+	}
+	impl.lastSync.mutex.RLock()
+	defer impl.lastSync.mutex.RUnlock()
+	if impl.lastSync.result != nil {
+		lastSync := *impl.lastSync.result
+		stats.LastSync = &lastSync
+	}
+	return stats
+}
+
+func (cache *derivedViewCache) size() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return len(cache.views)
+}
+
+func (cache *imageDimensionCache) size() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return len(cache.entries)
+}
@@ -0,0 +1,90 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"regexp"
+	"strings"
+)
+
+// prerequisiteMarkerRegex matches <!--upgrade-prerequisites-required module=security-clair-->
+var prerequisiteMarkerRegex = regexp.MustCompile(`<!--\s*upgrade-prerequisites-required(?:\s+module=([^>]*?))?\s*-->`)
+
+func (impl *ReleaseNoteServiceImpl) getPrerequisiteBlocks(releaseInfo *common.Release) {
+	releaseInfo.PrerequisiteBlocks = nil
+	markers := prerequisiteMarkerRegex.FindAllStringSubmatchIndex(releaseInfo.Body, -1)
+	for i := 0; i+1 < len(markers); i += 2 {
+		opening, closing := markers[i], markers[i+1]
+		block := &common.PrerequisiteBlock{
+			Message: strings.TrimSpace(releaseInfo.Body[opening[1]:closing[0]]),
+		}
+		if opening[2] >= 0 {
+			block.AppliesToModules = impl.resolveModuleNames(parseModuleQualifier(releaseInfo.Body[opening[2]:opening[3]]))
+		}
+		releaseInfo.PrerequisiteBlocks = append(releaseInfo.PrerequisiteBlocks, block)
+	}
+}
+
+func parseModuleQualifier(qualifier string) []string {
+	var modules []string
+	for _, module := range strings.Split(qualifier, ",") {
+		if module = strings.TrimSpace(module); len(module) > 0 {
+			modules = append(modules, module)
+		}
+	}
+	return modules
+}
+
+// newInstalledModuleSet returns nil for unknown modules, then every block applies
+func newInstalledModuleSet(installedModules []string) map[string]bool {
+	if installedModules == nil {
+		return nil
+	}
+	installed := make(map[string]bool, len(installedModules))
+	for _, module := range installedModules {
+		if module = strings.TrimSpace(module); len(module) > 0 {
+			installed[module] = true
+		}
+	}
+	return installed
+}
+
+func getApplicablePrerequisiteBlocks(release *common.Release, installed map[string]bool) []*common.PrerequisiteBlock {
+	if installed == nil {
+		return release.PrerequisiteBlocks
+	}
+	var applicable []*common.PrerequisiteBlock
+	for _, block := range release.PrerequisiteBlocks {
+		if len(block.AppliesToModules) == 0 {
+			applicable = append(applicable, block)
+			continue
+		}
+		for _, module := range block.AppliesToModules {
+			if installed[module] {
+				applicable = append(applicable, block)
+				break
+			}
+		}
+	}
+	return applicable
+}
+
+func isPrerequisiteApplicable(release *common.Release, installed map[string]bool) bool {
+	if !release.Prerequisite {
+		return false
+	}
+	if installed == nil || len(release.PrerequisiteBlocks) == 0 {
+		return true
+	}
+	return len(getApplicablePrerequisiteBlocks(release, installed)) > 0
+}
+
+func getApplicablePrerequisiteMessage(release *common.Release, installed map[string]bool) string {
+	if installed == nil || len(release.PrerequisiteBlocks) == 0 {
+		return strings.TrimSpace(release.PrerequisiteMessage)
+	}
+	var messages []string
+	for _, block := range getApplicablePrerequisiteBlocks(release, installed) {
+		messages = append(messages, block.Message)
+	}
+	return strings.Join(messages, PrerequisiteMessageSeparator)
+}
@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+const testPrerequisiteMarker = "<!--upgrade-prerequisites-required-->"
+
+// formatPrerequisiteBlocks lists the blocks like "message[module,module]" to compare them in one string
+func formatPrerequisiteBlocks(blocks []*common.PrerequisiteBlock) string {
+	formatted := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		formatted = append(formatted, fmt.Sprintf("%s[%s]", block.Message, strings.Join(block.AppliesToModules, ",")))
+	}
+	return strings.Join(formatted, ";")
+}
+
+func TestGetPrerequisiteBlocks(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.catalogModules = newTestBaseModules()
+	tests := []struct {
+		name   string
+		body   string
+		blocks string
+	}{
+		{name: "no marker", body: "* fixed the rollout", blocks: ""},
+		{name: "unqualified", body: testPrerequisiteMarker + "\nrun the migration\n" + testPrerequisiteMarker, blocks: "run the migration[]"},
+		{name: "qualified", body: "<!--upgrade-prerequisites-required module=security.clair-->\nre-register the clair scanner\n" + testPrerequisiteMarker, blocks: "re-register the clair scanner[security.clair]"},
+		{name: "spaces in the marker", body: "<!--  upgrade-prerequisites-required   module=security.clair  -->scan again<!-- upgrade-prerequisites-required -->", blocks: "scan again[security.clair]"},
+		{name: "spaces between modules", body: "<!--upgrade-prerequisites-required module=argocd, security.clair-->sync the apps" + testPrerequisiteMarker, blocks: "sync the apps[argo-cd,security.clair]"},
+		{name: "comma separated modules", body: "<!--upgrade-prerequisites-required module=argocd,security.clair,-->sync the apps" + testPrerequisiteMarker, blocks: "sync the apps[argo-cd,security.clair]"},
+		{name: "unknown module kept as written", body: "<!--upgrade-prerequisites-required module=security-clair-->scan again" + testPrerequisiteMarker, blocks: "scan again[security-clair]"},
+		{name: "empty qualifier", body: "<!--upgrade-prerequisites-required module=-->run the migration" + testPrerequisiteMarker, blocks: "run the migration[]"},
+		{
+			name: "qualifier of the opening marker only",
+			body: testPrerequisiteMarker + "run the migration<!--upgrade-prerequisites-required module=cicd-->\n" +
+				"<!--upgrade-prerequisites-required module=argocd-->sync the apps" + testPrerequisiteMarker,
+			blocks: "run the migration[];sync the apps[argo-cd]",
+		},
+		{name: "marker without closing", body: testPrerequisiteMarker + "run the migration" + testPrerequisiteMarker + "\n<!--upgrade-prerequisites-required module=cicd-->dangling", blocks: "run the migration[]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release := &common.Release{Body: test.body, PrerequisiteBlocks: []*common.PrerequisiteBlock{{Message: "of the previous body"}}}
+			impl.getPrerequisiteBlocks(release)
+			if blocks := formatPrerequisiteBlocks(release.PrerequisiteBlocks); blocks != test.blocks {
+				t.Errorf("expected blocks %q, got %q", test.blocks, blocks)
+			}
+		})
+	}
+}
+
+func TestGetApplicablePrerequisiteBlocks(t *testing.T) {
+	release := &common.Release{
+		Prerequisite:        true,
+		PrerequisiteMessage: "run the migration\n\nre-register the clair scanner\n\nsync the apps",
+		PrerequisiteBlocks: []*common.PrerequisiteBlock{
+			{Message: "run the migration"},
+			{Message: "re-register the clair scanner", AppliesToModules: []string{"security.clair"}},
+			{Message: "sync the apps", AppliesToModules: []string{"argo-cd", "security.clair"}},
+		},
+	}
+	tests := []struct {
+		name      string
+		installed []string
+		blocks    string
+		message   string
+	}{
+		{name: "modules unknown", installed: nil, blocks: "run the migration[];re-register the clair scanner[security.clair];sync the apps[argo-cd,security.clair]", message: release.PrerequisiteMessage},
+		{name: "no module installed", installed: []string{}, blocks: "run the migration[]", message: "run the migration"},
+		{name: "one of the modules", installed: []string{"cicd", "argo-cd"}, blocks: "run the migration[];sync the apps[argo-cd,security.clair]", message: "run the migration\n\nsync the apps"},
+		{name: "blank module names", installed: []string{" security.clair ", ""}, blocks: "run the migration[];re-register the clair scanner[security.clair];sync the apps[argo-cd,security.clair]", message: release.PrerequisiteMessage},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			installed := newInstalledModuleSet(test.installed)
+			if blocks := formatPrerequisiteBlocks(getApplicablePrerequisiteBlocks(release, installed)); blocks != test.blocks {
+				t.Errorf("expected blocks %q, got %q", test.blocks, blocks)
+			}
+			if message := getApplicablePrerequisiteMessage(release, installed); message != test.message {
+				t.Errorf("expected message %q, got %q", test.message, message)
+			}
+			if !isPrerequisiteApplicable(release, installed) {
+				t.Errorf("expected the unqualified block to always apply")
+			}
+		})
+	}
+}
+
+func TestIsPrerequisiteApplicable(t *testing.T) {
+	qualified := []*common.PrerequisiteBlock{{Message: "re-register the clair scanner", AppliesToModules: []string{"security.clair"}}}
+	tests := []struct {
+		name       string
+		release    *common.Release
+		installed  []string
+		applicable bool
+	}{
+		{name: "no prerequisite", release: &common.Release{PrerequisiteBlocks: qualified}, installed: []string{"security.clair"}},
+		{name: "prerequisite without blocks", release: &common.Release{Prerequisite: true}, installed: []string{}, applicable: true},
+		{name: "module installed", release: &common.Release{Prerequisite: true, PrerequisiteBlocks: qualified}, installed: []string{"security.clair"}, applicable: true},
+		{name: "module not installed", release: &common.Release{Prerequisite: true, PrerequisiteBlocks: qualified}, installed: []string{"cicd"}},
+		{name: "modules unknown", release: &common.Release{Prerequisite: true, PrerequisiteBlocks: qualified}, installed: nil, applicable: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if applicable := isPrerequisiteApplicable(test.release, newInstalledModuleSet(test.installed)); applicable != test.applicable {
+				t.Errorf("expected applicable %v, got %v", test.applicable, applicable)
+			}
+		})
+	}
+}
+
+func TestGetPrerequisiteSummaryFiltersInstalledModules(t *testing.T) {
+	releases := []*common.Release{
+		{TagName: "v0.7.0", Body: "<!--upgrade-prerequisites-required module=security.clair-->re-register the clair scanner" + testPrerequisiteMarker},
+		{TagName: "v0.6.2", Body: testPrerequisiteMarker + "run the migration" + testPrerequisiteMarker + "<!--upgrade-prerequisites-required module=argocd-->sync the apps" + testPrerequisiteMarker},
+		{TagName: "v0.6.1"},
+	}
+	impl := newTestReleaseNoteService(t, releases...)
+	impl.catalogModules = newTestBaseModules()
+	for _, release := range releases {
+		impl.normalizeRelease(release)
+	}
+	tests := []struct {
+		name          string
+		installed     []string
+		prerequisites string
+	}{
+		{name: "modules unknown", installed: nil, prerequisites: "v0.6.2:run the migration\n\nsync the apps|v0.7.0:re-register the clair scanner"},
+		{name: "no module installed", installed: []string{}, prerequisites: "v0.6.2:run the migration"},
+		{name: "alias of the module", installed: []string{"argocd"}, prerequisites: "v0.6.2:run the migration\n\nsync the apps"},
+		{name: "every module", installed: []string{"argo-cd", "security.clair"}, prerequisites: "v0.6.2:run the migration\n\nsync the apps|v0.7.0:re-register the clair scanner"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := impl.GetPrerequisiteSummary("v0.6.1", "", test.installed)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var prerequisites []string
+			for _, prerequisite := range summary.Effective {
+				prerequisites = append(prerequisites, prerequisite.TagName+":"+prerequisite.PrerequisiteMessage)
+			}
+			if strings.Join(prerequisites, "|") != test.prerequisites {
+				t.Errorf("expected %q, got %q", test.prerequisites, strings.Join(prerequisites, "|"))
+			}
+		})
+	}
+}
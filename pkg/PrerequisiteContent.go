@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+)
+
+const PrerequisiteMessageSeparator = "\n\n"
+
+type parsedPrerequisite struct {
+	prerequisite bool
+	messages     []string
+}
+
+// getPrerequisiteContent runs once on normalization so that reads never parse the bodies
+func (impl *ReleaseNoteServiceImpl) getPrerequisiteContent(releaseInfo *common.Release) {
+	parsed := parsePrerequisiteContent(releaseInfo.Body)
+	releaseInfo.Prerequisite = parsed.prerequisite
+	releaseInfo.PrerequisiteMessages = parsed.messages
+	releaseInfo.PrerequisiteMessage = strings.Join(parsed.messages, PrerequisiteMessageSeparator)
+}
+
+func parsePrerequisiteContent(body string) *parsedPrerequisite {
+	parsed := &parsedPrerequisite{}
+	// module qualified markers delimit the message like plain ones
+	parts := strings.Split(prerequisiteMarkerRegex.ReplaceAllString(body, PrerequisitesMatcher), PrerequisitesMatcher)
+	if len(parts) == 1 {
+		return parsed
+	}
+	parsed.prerequisite = true
+	// odd parts follow an opening marker
+	for i := 1; i < len(parts); i += 2 {
+		if message := strings.TrimSpace(parts[i]); len(message) > 0 {
+			parsed.messages = append(parsed.messages, message)
+		}
+	}
+	return parsed
+}
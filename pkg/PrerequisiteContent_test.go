@@ -0,0 +1,114 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+// newBenchmarkReleases returns releases with long bodies carrying a prerequisite block, like the notes of the
+// larger releases
+func newBenchmarkReleases(count int) []*common.Release {
+	body := strings.Repeat("* fixed an issue in the deployment of the helm apps\n", 200) +
+		"<!--upgrade-prerequisites-required-->\nrun the migration\n<!--upgrade-prerequisites-required-->"
+	releases := make([]*common.Release, 0, count)
+	for i := count; i > 0; i-- {
+		releases = append(releases, &common.Release{TagName: fmt.Sprintf("v0.%d.0", i), Body: body})
+	}
+	return releases
+}
+
+func TestNormalizeReleaseDerivesPrerequisiteFromBody(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	release := newBenchmarkReleases(1)[0]
+	impl.normalizeRelease(release)
+	if !release.Prerequisite || release.PrerequisiteMessage != "run the migration" {
+		t.Fatalf("expected the prerequisite to be derived, got %v %q", release.Prerequisite, release.PrerequisiteMessage)
+	}
+	// the same tag edited without its marker must not keep the prerequisite of the previous body
+	release.Body = "no prerequisite any more"
+	impl.normalizeRelease(release)
+	if release.Prerequisite || len(release.PrerequisiteMessage) != 0 {
+		t.Errorf("expected the prerequisite to be cleared, got %v %q", release.Prerequisite, release.PrerequisiteMessage)
+	}
+}
+
+func TestGetPrerequisiteContentBetweenMarkers(t *testing.T) {
+	const marker = PrerequisitesMatcher
+	tests := []struct {
+		name         string
+		body         string
+		prerequisite bool
+		message      string
+	}{
+		{name: "no marker", body: "fixes"},
+		{name: "one marker", body: "fixes\n" + marker + "\n  run the migration  \n", prerequisite: true, message: "run the migration"},
+		{name: "one marker closing the body", body: "fixes\n" + marker, prerequisite: true},
+		{name: "two markers", body: "fixes\n" + marker + "\nrun the migration\n" + marker + "\nmore fixes", prerequisite: true, message: "run the migration"},
+		{name: "three markers", body: marker + "run the migration" + marker + "\nmore fixes\n" + marker + " restart the pods", prerequisite: true, message: "run the migration" + PrerequisiteMessageSeparator + "restart the pods"},
+		{name: "empty block", body: "fixes " + marker + "  \n " + marker + " more fixes", prerequisite: true},
+		{name: "spaced marker", body: "<!-- upgrade-prerequisites-required -->run the migration<!-- upgrade-prerequisites-required -->", prerequisite: true, message: "run the migration"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			release := &common.Release{TagName: "v0.7.0", Body: test.body}
+			impl.getPrerequisiteContent(release)
+			if release.Prerequisite != test.prerequisite || release.PrerequisiteMessage != test.message {
+				t.Errorf("expected %v %q, got %v %q", test.prerequisite, test.message, release.Prerequisite, release.PrerequisiteMessage)
+			}
+			if strings.Contains(release.PrerequisiteMessage, "upgrade-prerequisites-required") {
+				t.Errorf("expected the markers to be left out of the message, got %q", release.PrerequisiteMessage)
+			}
+		})
+	}
+}
+
+// BenchmarkGetReleases reads the cached releases, the prerequisites are stored on them and never parsed on read
+func BenchmarkGetReleases(b *testing.B) {
+	releases := newBenchmarkReleases(100)
+	impl := newTestReleaseNoteService(b, releases...)
+	for _, release := range releases {
+		impl.normalizeRelease(release)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := impl.GetReleases()
+		if err != nil {
+			b.Fatalf("unexpected error %v", err)
+		}
+	}
+}
+
+// BenchmarkGetPrerequisiteContent is the parsing done once per release when it enters the cache
+func BenchmarkGetPrerequisiteContent(b *testing.B) {
+	impl := newTestReleaseNoteService(b)
+	release := newBenchmarkReleases(1)[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		impl.getPrerequisiteContent(release)
+	}
+}
+
+func TestGetPrerequisiteContentCollectsEveryBlock(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	release := &common.Release{TagName: "v0.7.0", Body: "## Prerequisites\n" +
+		"<!--upgrade-prerequisites-required-->\nrun the migration\n<!--upgrade-prerequisites-required-->\n" +
+		"## Bug fixes\n* fixed the helm app deployment\n" +
+		"<!--upgrade-prerequisites-required-->\nrestart the pods of the ci runner\n<!--upgrade-prerequisites-required-->\n"}
+	impl.getPrerequisiteContent(release)
+
+	if !release.Prerequisite || len(release.PrerequisiteMessages) != 2 {
+		t.Fatalf("expected 2 prerequisite blocks, got %q", release.PrerequisiteMessages)
+	}
+	if release.PrerequisiteMessages[0] != "run the migration" || release.PrerequisiteMessages[1] != "restart the pods of the ci runner" {
+		t.Errorf("expected the text of each block, got %q", release.PrerequisiteMessages)
+	}
+	if release.PrerequisiteMessage != "run the migration\n\nrestart the pods of the ci runner" {
+		t.Errorf("expected the blocks joined in the message, got %q", release.PrerequisiteMessage)
+	}
+	if strings.Contains(release.PrerequisiteMessage, "Bug fixes") {
+		t.Errorf("expected the text between the blocks to be left out, got %q", release.PrerequisiteMessage)
+	}
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+// prerequisiteIdRegex matches <!--prereq-id:db-v2-->, the newest release with an id supersedes the older ones
+var prerequisiteIdRegex = regexp.MustCompile(`<!--\s*prereq-id:\s*([^\s>]+?)\s*-->`)
+
+// getPrerequisiteId leaves a prerequisite without id distinct from all others
+func (impl *ReleaseNoteServiceImpl) getPrerequisiteId(releaseInfo *common.Release) {
+	releaseInfo.PrerequisiteId = ""
+	if !releaseInfo.Prerequisite {
+		return
+	}
+	if match := prerequisiteIdRegex.FindStringSubmatch(releaseInfo.Body); match != nil {
+		releaseInfo.PrerequisiteId = match[1]
+	}
+}
+
+// GetPrerequisiteSummary covers the path from fromVersion (exclusive) to toVersion (inclusive)
+func (impl *ReleaseNoteServiceImpl) GetPrerequisiteSummary(fromVersion string, toVersion string, installedModules []string) (*common.PrerequisiteSummary, error) {
+	from, to, err := parseUpgradeRange(fromVersion, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	installed := newInstalledModuleSet(impl.resolveModuleNames(installedModules))
+	var path []*versionedRelease
+	for _, item := range parseVersionedReleases(releases) {
+		if !isPrerequisiteApplicable(item.release, installed) || !isUpgradeTarget(item.release) {
+			continue
+		}
+		if !isInUpgradeRange(item.version, from, to) {
+			continue
+		}
+		path = append(path, item)
+	}
+	sort.SliceStable(path, func(i, j int) bool {
+		return path[i].version.Compare(path[j].version) < 0
+	})
+	return summarizePrerequisites(fromVersion, toVersion, path, installed), nil
+}
+
+// parseUpgradeRange gives a nil upper bound without toVersion
+func parseUpgradeRange(fromVersion string, toVersion string) (*util.SemanticVersion, *util.SemanticVersion, error) {
+	from, err := util.ParseSemanticVersion(fromVersion)
+	if err != nil {
+		return nil, nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid fromVersion"}
+	}
+	if len(toVersion) == 0 {
+		return from, nil, nil
+	}
+	to, err := util.ParseSemanticVersion(toVersion)
+	if err != nil {
+		return nil, nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid toVersion"}
+	}
+	if from.Compare(to) > 0 {
+		return nil, nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid version range, %s is higher than %s", fromVersion, toVersion), UserMessage: "fromVersion should not be higher than toVersion"}
+	}
+	return from, to, nil
+}
+
+func isInUpgradeRange(version *util.SemanticVersion, from *util.SemanticVersion, to *util.SemanticVersion) bool {
+	return version.Compare(from) > 0 && (to == nil || version.Compare(to) <= 0)
+}
+
+// summarizePrerequisites takes the path oldest first
+func summarizePrerequisites(fromVersion string, toVersion string, path []*versionedRelease, installed map[string]bool) *common.PrerequisiteSummary {
+	latestTagById := make(map[string]string)
+	for _, item := range path {
+		if len(item.release.PrerequisiteId) > 0 {
+			latestTagById[item.release.PrerequisiteId] = item.release.TagName
+		}
+	}
+	summary := &common.PrerequisiteSummary{
+		FromVersion:   fromVersion,
+		ToVersion:     toVersion,
+		Prerequisites: make([]*common.PrerequisiteInfo, 0, len(path)),
+		Effective:     make([]*common.PrerequisiteInfo, 0, len(path)),
+	}
+	for _, item := range path {
+		prerequisite := &common.PrerequisiteInfo{
+			TagName:             item.release.TagName,
+			PrerequisiteId:      item.release.PrerequisiteId,
+			PrerequisiteMessage: getApplicablePrerequisiteMessage(item.release, installed),
+		}
+		if latestTag, ok := latestTagById[item.release.PrerequisiteId]; ok && latestTag != item.release.TagName {
+			prerequisite.Superseded = true
+			prerequisite.SupersededBy = latestTag
+		} else {
+			summary.Effective = append(summary.Effective, prerequisite)
+		}
+		summary.Prerequisites = append(summary.Prerequisites, prerequisite)
+	}
+	return summary
+}
+
+// ErrPrerequisiteNotFound is returned when no cached release carries a prerequisite
+var ErrPrerequisiteNotFound = &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: "no cached release has a prerequisite", UserMessage: "prerequisite not found"}
+
+func (impl *ReleaseNoteServiceImpl) GetLatestPrerequisite() (*common.Release, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	// releases are ordered newest first
+	for _, release := range releases {
+		if release.Prerequisite {
+			return release, nil
+		}
+	}
+	return nil, ErrPrerequisiteNotFound
+}
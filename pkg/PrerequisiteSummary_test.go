@@ -0,0 +1,113 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+func TestGetPrerequisiteSummarySupersedesRepeatedIds(t *testing.T) {
+	releases := []*common.Release{
+		{TagName: "v0.8.0", Body: "<!--prereq-id:db-v2-->" + testPrerequisiteMarker + "migrate the database" + testPrerequisiteMarker},
+		{TagName: "v0.7.1", Body: "<!-- prereq-id: cache-v1 -->" + testPrerequisiteMarker + "flush the cache" + testPrerequisiteMarker},
+		{TagName: "v0.7.0", Body: "<!--prereq-id:db-v2-->" + testPrerequisiteMarker + "migrate the database" + testPrerequisiteMarker},
+		{TagName: "v0.6.2", Body: testPrerequisiteMarker + "run the hook" + testPrerequisiteMarker},
+		{TagName: "v0.6.1", Body: testPrerequisiteMarker + "run the hook" + testPrerequisiteMarker},
+	}
+	impl := newTestReleaseNoteService(t, releases...)
+	for _, release := range releases {
+		impl.normalizeRelease(release)
+	}
+	tests := []struct {
+		name          string
+		toVersion     string
+		prerequisites string
+		effective     string
+	}{
+		{
+			name:          "superseded by a later release",
+			prerequisites: "v0.6.1,v0.6.2,v0.7.0:db-v2>v0.8.0,v0.7.1:cache-v1,v0.8.0:db-v2",
+			effective:     "v0.6.1,v0.6.2,v0.7.1:cache-v1,v0.8.0:db-v2",
+		},
+		{
+			name:          "superseding release beyond the path",
+			toVersion:     "v0.7.1",
+			prerequisites: "v0.6.1,v0.6.2,v0.7.0:db-v2,v0.7.1:cache-v1",
+			effective:     "v0.6.1,v0.6.2,v0.7.0:db-v2,v0.7.1:cache-v1",
+		},
+	}
+	describe := func(prerequisites []*common.PrerequisiteInfo) string {
+		var described []string
+		for _, prerequisite := range prerequisites {
+			description := prerequisite.TagName
+			if len(prerequisite.PrerequisiteId) > 0 {
+				description += ":" + prerequisite.PrerequisiteId
+			}
+			if prerequisite.Superseded {
+				description += ">" + prerequisite.SupersededBy
+			}
+			described = append(described, description)
+		}
+		return strings.Join(described, ",")
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := impl.GetPrerequisiteSummary("v0.6.0", test.toVersion, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if prerequisites := describe(summary.Prerequisites); prerequisites != test.prerequisites {
+				t.Errorf("expected the prerequisites %q, got %q", test.prerequisites, prerequisites)
+			}
+			// prerequisites without id are distinct even with the same message
+			if effective := describe(summary.Effective); effective != test.effective {
+				t.Errorf("expected the effective prerequisites %q, got %q", test.effective, effective)
+			}
+		})
+	}
+}
+
+func TestGetLatestPrerequisite(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []*common.Release
+		tag      string
+		message  string
+	}{
+		{
+			name: "newest of several prerequisites",
+			releases: []*common.Release{
+				{TagName: "v0.8.0", Body: "## Bug fixes"},
+				{TagName: "v0.7.1", Body: testPrerequisiteMarker + "flush the cache" + testPrerequisiteMarker},
+				{TagName: "v0.7.0", Body: testPrerequisiteMarker + "migrate the database" + testPrerequisiteMarker},
+			},
+			tag:     "v0.7.1",
+			message: "flush the cache",
+		},
+		{
+			name:     "no prerequisite",
+			releases: []*common.Release{{TagName: "v0.7.1", Body: "## Bug fixes"}, {TagName: "v0.7.0"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t, test.releases...)
+			for _, release := range test.releases {
+				impl.normalizeRelease(release)
+			}
+			release, err := impl.GetLatestPrerequisite()
+			if len(test.tag) == 0 {
+				if err != ErrPrerequisiteNotFound {
+					t.Errorf("expected ErrPrerequisiteNotFound, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if release.TagName != test.tag || strings.TrimSpace(release.PrerequisiteMessage) != test.message {
+				t.Errorf("expected %s with %q, got %s with %q", test.tag, test.message, release.TagName, release.PrerequisiteMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+)
+
+func (impl *ReleaseNoteServiceImpl) GetUnacknowledgedReleases(acknowledgedTag string) ([]*common.Release, error) {
+	acknowledgedVersion, err := util.ParseSemanticVersion(acknowledgedTag)
+	if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid acknowledged tag"}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	unacknowledged := make([]*common.Release, 0)
+	for _, item := range parseVersionedReleases(releases) {
+		if item.version.Compare(acknowledgedVersion) > 0 {
+			unacknowledged = append(unacknowledged, item.release)
+		}
+	}
+	return unacknowledged, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetUnacknowledgedCount(acknowledgedTag string) (int, error) {
+	unacknowledged, err := impl.GetUnacknowledgedReleases(acknowledgedTag)
+	if err != nil {
+		return 0, err
+	}
+	return len(unacknowledged), nil
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"testing"
+)
+
+func TestGetUnacknowledgedReleases(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	tests := []struct {
+		name            string
+		acknowledgedTag string
+		tags            string
+	}{
+		{name: "nothing acknowledged yet", acknowledgedTag: "v0.0.0", tags: "v0.8.0-rc.1,v0.7.1,v0.7.0"},
+		{name: "older release acknowledged", acknowledgedTag: "v0.7.0", tags: "v0.8.0-rc.1,v0.7.1"},
+		{name: "tag without v prefix", acknowledgedTag: "0.7.1", tags: "v0.8.0-rc.1"},
+		{name: "latest acknowledged", acknowledgedTag: "v0.8.0-rc.1"},
+		{name: "acknowledged ahead of the cache", acknowledgedTag: "v0.9.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releases, err := impl.GetUnacknowledgedReleases(test.acknowledgedTag)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tags := getTestTags(releases); tags != test.tags {
+				t.Errorf("expected %q, got %q", test.tags, tags)
+			}
+			count, err := impl.GetUnacknowledgedCount(test.acknowledgedTag)
+			if err != nil || count != len(releases) {
+				t.Errorf("expected the count %d, got %d, %v", len(releases), count, err)
+			}
+		})
+	}
+}
+
+func TestGetUnacknowledgedReleasesRefusesInvalidTag(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	for _, tag := range []string{"", "latest"} {
+		_, err := impl.GetUnacknowledgedCount(tag)
+		if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+			t.Errorf("expected %q to be refused, got %v", tag, err)
+		}
+	}
+}
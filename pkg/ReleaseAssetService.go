@@ -0,0 +1,243 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"go.uber.org/zap"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// ReleaseAssetContentType makes github serve the content of an asset instead of its metadata
+const ReleaseAssetContentType = "application/octet-stream"
+
+// releaseAssetHeaders are the headers of the github response passed through to the client
+var releaseAssetHeaders = []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges", "ETag", "Last-Modified"}
+
+type ReleaseAssetService interface {
+	DownloadReleaseAsset(ctx context.Context, tag string, assetName string, rangeHeader string) (*ReleaseAssetDownload, error)
+}
+
+// ReleaseAssetDownload is a cached file or a github response whose body the caller must close
+type ReleaseAssetDownload struct {
+	Asset      *common.ReleaseAsset
+	File       *os.File
+	Body       io.ReadCloser
+	StatusCode int
+	Header     http.Header
+}
+
+type ReleaseAssetServiceImpl struct {
+	logger              *zap.SugaredLogger
+	client              *util.GitHubClient
+	releaseNoteService  ReleaseNoteService
+	releaseAssetConfig  *util.ReleaseAssetConfig
+	releaseSourceConfig *util.ReleaseSourceConfig
+	// cdnClient carries no github credentials, which the cdn refuses
+	cdnClient *http.Client
+}
+
+func NewReleaseAssetServiceImpl(logger *zap.SugaredLogger, client *util.GitHubClient, releaseNoteService ReleaseNoteService, releaseAssetConfig *util.ReleaseAssetConfig, releaseSourceConfig *util.ReleaseSourceConfig) *ReleaseAssetServiceImpl {
+	return &ReleaseAssetServiceImpl{
+		logger:              logger,
+		client:              client,
+		releaseNoteService:  releaseNoteService,
+		releaseAssetConfig:  releaseAssetConfig,
+		releaseSourceConfig: releaseSourceConfig,
+		cdnClient:           &http.Client{},
+	}
+}
+
+func (impl *ReleaseAssetServiceImpl) DownloadReleaseAsset(ctx context.Context, tag string, assetName string, rangeHeader string) (*ReleaseAssetDownload, error) {
+	if !impl.releaseAssetConfig.ProxyEnabled {
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: "release asset proxy disabled", UserMessage: "release asset download is disabled"}
+	}
+	if source := impl.releaseNoteService.GetReleaseSourceMeta().Source; !isAssetProxySupported(source) {
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("release asset proxy not supported for release source %s", source), UserMessage: "release asset download is not available for this release source"}
+	}
+	asset, err := impl.findReleaseAsset(tag, assetName)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize := impl.releaseAssetConfig.MaxSize; maxSize > 0 && int64(asset.Size) > maxSize {
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusRequestEntityTooLarge, InternalMessage: fmt.Sprintf("release asset %s of %d bytes exceeds the limit of %d", asset.Name, asset.Size, maxSize), UserMessage: "release asset is too large to download through central-api"}
+	}
+	if len(impl.releaseAssetConfig.CacheDir) == 0 {
+		resp, err := impl.fetchReleaseAsset(ctx, asset, rangeHeader)
+		if err != nil {
+			return nil, err
+		}
+		header := http.Header{}
+		for _, name := range releaseAssetHeaders {
+			if value := resp.Header.Get(name); len(value) > 0 {
+				header.Set(name, value)
+			}
+		}
+		if impl.releaseAssetConfig.MaxSize > 0 {
+			// the body may be cut short at the limit, the length announced by github would then be wrong
+			header.Del("Content-Length")
+		}
+		return &ReleaseAssetDownload{Asset: asset, Body: impl.limitBody(resp.Body), StatusCode: resp.StatusCode, Header: header}, nil
+	}
+	file, err := impl.getCachedReleaseAsset(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	return &ReleaseAssetDownload{Asset: asset, File: file}, nil
+}
+
+func isAssetProxySupported(source string) bool {
+	return source == util.ReleaseSourceGithub || len(source) == 0
+}
+
+func (impl *ReleaseAssetServiceImpl) findReleaseAsset(tag string, assetName string) (*common.ReleaseAsset, error) {
+	assets, err := impl.releaseNoteService.GetReleaseAssets(tag)
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		if asset.Name == assetName {
+			return asset, nil
+		}
+	}
+	return nil, &util2.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("asset %s not found in release %s", assetName, tag), UserMessage: "release asset not found"}
+}
+
+// fetchReleaseAsset follows the cdn redirect with the cdn client, which refuses the github credentials
+func (impl *ReleaseAssetServiceImpl) fetchReleaseAsset(ctx context.Context, asset *common.ReleaseAsset, rangeHeader string) (*http.Response, error) {
+	org, repo := impl.getReleaseAssetRepo()
+	url := fmt.Sprintf("repos/%v/%v/releases/assets/%d", org, repo, asset.Id)
+	req, err := impl.client.GitHubClient.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ReleaseAssetContentType)
+	if len(rangeHeader) > 0 {
+		req.Header.Set("Range", rangeHeader)
+	}
+	gitHubClient := &http.Client{
+		Transport: impl.client.HttpClient.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := gitHubClient.Do(req.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in requesting release asset", "assetId", asset.Id, "err", err)
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusBadGateway, InternalMessage: err.Error(), UserMessage: "release asset could not be fetched"}
+	}
+	if resp.StatusCode == http.StatusFound || resp.StatusCode == http.StatusMovedPermanently || resp.StatusCode == http.StatusTemporaryRedirect {
+		location := resp.Header.Get("Location")
+		resp.Body.Close()
+		cdnReq, err := http.NewRequestWithContext(ctx, "GET", location, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(rangeHeader) > 0 {
+			cdnReq.Header.Set("Range", rangeHeader)
+		}
+		resp, err = impl.cdnClient.Do(cdnReq)
+		if err != nil {
+			impl.logger.Errorw("error in downloading release asset", "assetId", asset.Id, "err", err)
+			return nil, &util2.ApiError{HttpStatusCode: http.StatusBadGateway, InternalMessage: err.Error(), UserMessage: "release asset could not be fetched"}
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent:
+		return resp, nil
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusRequestedRangeNotSatisfiable, InternalMessage: fmt.Sprintf("range %s not satisfiable for asset %d", rangeHeader, asset.Id), UserMessage: "requested range not satisfiable"}
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("asset %d not found on github", asset.Id), UserMessage: "release asset not found"}
+	default:
+		resp.Body.Close()
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusBadGateway, InternalMessage: fmt.Sprintf("unexpected status %d downloading asset %d", resp.StatusCode, asset.Id), UserMessage: "release asset could not be fetched"}
+	}
+}
+
+func (impl *ReleaseAssetServiceImpl) getReleaseAssetRepo() (string, string) {
+	if impl.releaseNoteService.GetReleaseSourceMeta().Provenance == ReleaseProvenanceFallback && len(impl.releaseSourceConfig.FallbackGitHubRepo) > 0 {
+		return impl.releaseSourceConfig.FallbackGitHubOrg, impl.releaseSourceConfig.FallbackGitHubRepo
+	}
+	return impl.client.GitHubConfig.GitHubOrg, impl.client.GitHubConfig.GitHubRepo
+}
+
+func (impl *ReleaseAssetServiceImpl) getCachedReleaseAsset(ctx context.Context, asset *common.ReleaseAsset) (*os.File, error) {
+	cacheDir := impl.releaseAssetConfig.CacheDir
+	path := filepath.Join(cacheDir, strconv.FormatInt(asset.Id, 10))
+	file, err := os.Open(path)
+	if err == nil {
+		return file, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	err = os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := impl.fetchReleaseAsset(ctx, asset, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	tmpFile, err := ioutil.TempFile(cacheDir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = io.Copy(tmpFile, impl.limitBody(resp.Body))
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		impl.logger.Errorw("error in caching release asset", "assetId", asset.Id, "err", err)
+		return nil, err
+	}
+	err = os.Rename(tmpFile.Name(), path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// limitBody enforces the size limit while reading, the size in the release may be stale
+func (impl *ReleaseAssetServiceImpl) limitBody(body io.ReadCloser) io.ReadCloser {
+	maxSize := impl.releaseAssetConfig.MaxSize
+	if maxSize <= 0 {
+		return body
+	}
+	return &limitedReadCloser{ReadCloser: body, remaining: maxSize}
+}
+
+type limitedReadCloser struct {
+	io.ReadCloser
+	remaining int64
+}
+
+var errReleaseAssetTooLarge = errors.New("release asset exceeds the size limit")
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errReleaseAssetTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), errReleaseAssetTooLarge
+	}
+	return n, err
+}
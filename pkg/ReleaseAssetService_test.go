@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"context"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownloadReleaseAssetFromServedRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/devtron-labs/devtron/releases/assets/1":
+			w.Write([]byte("primary"))
+		case "/api/v3/repos/devtron-mirror/devtron/releases/assets/1":
+			w.Write([]byte("mirror"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_HOST", server.URL)
+	t.Setenv("GITHUB_ORG", "devtron-labs")
+	client, err := util.NewGitHubClient(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := newTestReleases()[1]
+	release.Assets = []*common.ReleaseAsset{{Id: 1, Name: "devtron.tgz", Size: 7}}
+	impl := newTestReleaseNoteService(t, release)
+	impl.releaseSourceConfig.FallbackGitHubOrg = "devtron-mirror"
+	impl.releaseSourceConfig.FallbackGitHubRepo = "devtron"
+	assetService := NewReleaseAssetServiceImpl(impl.logger, client, impl, impl.releaseAssetConfig, impl.releaseSourceConfig)
+
+	tests := []struct {
+		provenance string
+		content    string
+	}{
+		{provenance: ReleaseProvenancePrimary, content: "primary"},
+		{provenance: ReleaseProvenanceFallback, content: "mirror"},
+	}
+	for _, test := range tests {
+		t.Run(test.provenance, func(t *testing.T) {
+			impl.setReleaseProvenance(test.provenance)
+			download, err := assetService.DownloadReleaseAsset(context.Background(), "v0.7.1", "devtron.tgz", "")
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			defer download.Body.Close()
+			content, err := ioutil.ReadAll(download.Body)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if string(content) != test.content {
+				t.Errorf("expected the asset of the %s repo, got %q", test.content, content)
+			}
+			if length := download.Header.Get("Content-Length"); len(length) > 0 {
+				t.Errorf("expected the length to be dropped while the size is limited, got %s", length)
+			}
+		})
+	}
+}
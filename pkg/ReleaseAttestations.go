@@ -0,0 +1,163 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/google/go-github/github"
+	"net/url"
+	"strings"
+)
+
+const (
+	AttestationKindSbom        = "sbom"
+	AttestationKindProvenance  = "provenance"
+	AttestationFormatSpdx      = "spdx"
+	AttestationFormatCycloneDx = "cyclonedx"
+	AttestationFormatSlsa      = "slsa"
+)
+
+// RawReleaseAsset is a file attached to a release upstream
+type RawReleaseAsset struct {
+	Id          int64
+	Name        string
+	ContentType string
+	URL         string
+	Size        int
+}
+
+var attestationConventions = []struct {
+	suffix string
+	kind   string
+	format string
+}{
+	{".spdx.json", AttestationKindSbom, AttestationFormatSpdx},
+	{".cyclonedx.json", AttestationKindSbom, AttestationFormatCycloneDx},
+	{".cdx.json", AttestationKindSbom, AttestationFormatCycloneDx},
+	{".intoto.jsonl", AttestationKindProvenance, AttestationFormatSlsa},
+	{".provenance.json", AttestationKindProvenance, AttestationFormatSlsa},
+}
+
+func getAttestations(assets []*RawReleaseAsset) []*common.ReleaseAttestation {
+	var attestations []*common.ReleaseAttestation
+	for _, asset := range assets {
+		if asset == nil || len(asset.URL) == 0 {
+			continue
+		}
+		name := strings.ToLower(asset.Name)
+		for _, convention := range attestationConventions {
+			if strings.HasSuffix(name, convention.suffix) {
+				attestations = append(attestations, &common.ReleaseAttestation{
+					Kind:   convention.kind,
+					Format: convention.format,
+					Name:   asset.Name,
+					URL:    asset.URL,
+					Size:   asset.Size,
+				})
+				break
+			}
+		}
+	}
+	return attestations
+}
+
+func (impl *ReleaseNoteServiceImpl) applyAssetProxy(releases []*common.Release) {
+	if !impl.releaseAssetConfig.ProxyEnabled || !isAssetProxySupported(impl.releaseSourceConfig.ReleaseSource) {
+		return
+	}
+	for _, release := range releases {
+		if len(release.Attestations) == 0 {
+			continue
+		}
+		assetNames := make(map[string]bool, len(release.Assets))
+		for _, asset := range release.Assets {
+			assetNames[asset.Name] = true
+		}
+		attestations := make([]*common.ReleaseAttestation, 0, len(release.Attestations))
+		for _, attestation := range release.Attestations {
+			proxied := *attestation
+			if assetNames[attestation.Name] {
+				proxied.URL = getReleaseAssetDownloadPath(release.TagName, attestation.Name)
+			}
+			attestations = append(attestations, &proxied)
+		}
+		release.Attestations = attestations
+	}
+}
+
+// getReleaseAssetDownloadPath is the path of the asset proxy serving the asset of the release
+func getReleaseAssetDownloadPath(tag string, assetName string) string {
+	return fmt.Sprintf("/release-note/%s/assets/%s/download", url.PathEscape(tag), url.PathEscape(assetName))
+}
+
+// getReleaseAssets keeps the assets with an id
+func getReleaseAssets(assets []*RawReleaseAsset) []*common.ReleaseAsset {
+	var releaseAssets []*common.ReleaseAsset
+	for _, asset := range assets {
+		if asset == nil || asset.Id == 0 || len(asset.Name) == 0 {
+			continue
+		}
+		releaseAssets = append(releaseAssets, &common.ReleaseAsset{
+			Id:          asset.Id,
+			Name:        asset.Name,
+			ContentType: asset.ContentType,
+			Size:        asset.Size,
+			URL:         asset.URL,
+		})
+	}
+	return releaseAssets
+}
+
+func getRawReleaseAssets(assets []github.ReleaseAsset) []*RawReleaseAsset {
+	rawAssets := make([]*RawReleaseAsset, 0, len(assets))
+	for _, asset := range assets {
+		rawAssets = append(rawAssets, &RawReleaseAsset{Id: asset.GetID(), Name: asset.GetName(), ContentType: asset.GetContentType(), URL: asset.GetBrowserDownloadURL(), Size: asset.GetSize()})
+	}
+	return rawAssets
+}
+
+// getWebhookReleaseAssets reads the assets of the release in a webhook payload
+func getWebhookReleaseAssets(releaseData map[string]interface{}) []*RawReleaseAsset {
+	items, _ := releaseData["assets"].([]interface{})
+	rawAssets := make([]*RawReleaseAsset, 0, len(items))
+	for _, item := range items {
+		asset, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rawAsset := &RawReleaseAsset{}
+		if id, ok := asset["id"].(float64); ok {
+			rawAsset.Id = int64(id)
+		}
+		rawAsset.Name, _ = asset["name"].(string)
+		rawAsset.ContentType, _ = asset["content_type"].(string)
+		rawAsset.URL, _ = asset["browser_download_url"].(string)
+		if size, ok := asset["size"].(float64); ok {
+			rawAsset.Size = int(size)
+		}
+		rawAssets = append(rawAssets, rawAsset)
+	}
+	return rawAssets
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseAttestations(tag string) ([]*common.ReleaseAttestation, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.Attestations == nil {
+		return []*common.ReleaseAttestation{}, nil
+	}
+	return release.Attestations, nil
+}
+
+// GetReleaseAssets returns the files attached to a release, empty when it has none
+func (impl *ReleaseNoteServiceImpl) GetReleaseAssets(tag string) ([]*common.ReleaseAsset, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.Assets == nil {
+		return []*common.ReleaseAsset{}, nil
+	}
+	return release.Assets, nil
+}
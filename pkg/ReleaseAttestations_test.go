@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+)
+
+func newTestAttestedRelease() *common.Release {
+	release := newTestReleases()[1]
+	release.Assets = []*common.ReleaseAsset{{Id: 1, Name: "devtron.spdx.json", URL: "https://github.com/devtron-labs/devtron/releases/download/v0.7.1/devtron.spdx.json"}}
+	release.Attestations = []*common.ReleaseAttestation{
+		{Kind: AttestationKindSbom, Format: AttestationFormatSpdx, Name: "devtron.spdx.json", URL: "https://github.com/devtron-labs/devtron/releases/download/v0.7.1/devtron.spdx.json"},
+		{Kind: AttestationKindProvenance, Format: AttestationFormatSlsa, Name: "devtron.intoto.jsonl", URL: "https://attestations.example.com/devtron.intoto.jsonl"},
+	}
+	return release
+}
+
+func TestGetReleaseAttestationsThroughAssetProxy(t *testing.T) {
+	release := newTestAttestedRelease()
+	impl := newTestReleaseNoteService(t, release)
+	impl.releaseAssetConfig.ProxyEnabled = true
+
+	attestations, err := impl.GetReleaseAttestations("v0.7.1")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(attestations) != 2 {
+		t.Fatalf("expected 2 attestations, got %d", len(attestations))
+	}
+	if attestations[0].URL != "/release-note/v0.7.1/assets/devtron.spdx.json/download" {
+		t.Errorf("expected the sbom to be served by the asset proxy, got %s", attestations[0].URL)
+	}
+	if attestations[1].URL != "https://attestations.example.com/devtron.intoto.jsonl" {
+		t.Errorf("expected the attestation which isn't an asset of the release to be left as it was, got %s", attestations[1].URL)
+	}
+	if release.Attestations[0].URL == attestations[0].URL {
+		t.Errorf("expected the cached release to be left as it was")
+	}
+}
+
+func TestGetReleaseAttestationsWithoutAssetProxy(t *testing.T) {
+	for _, configure := range []func(impl *ReleaseNoteServiceImpl){
+		func(impl *ReleaseNoteServiceImpl) { impl.releaseAssetConfig.ProxyEnabled = false },
+		func(impl *ReleaseNoteServiceImpl) { impl.releaseSourceConfig.ReleaseSource = util.ReleaseSourceGitlab },
+	} {
+		impl := newTestReleaseNoteService(t, newTestAttestedRelease())
+		impl.releaseAssetConfig.ProxyEnabled = true
+		configure(impl)
+
+		attestations, err := impl.GetReleaseAttestations("v0.7.1")
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if attestations[0].URL != "https://github.com/devtron-labs/devtron/releases/download/v0.7.1/devtron.spdx.json" {
+			t.Errorf("expected the download url of the asset, got %s", attestations[0].URL)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/go-pg/pg"
+	"net/http"
+	"strings"
+)
+
+// MaxBatchLookupTags bounds the tags looked up in one call
+const MaxBatchLookupTags = 50
+
+// getReleasesFromCacheOnly never falls back to github
+func (impl *ReleaseNoteServiceImpl) getReleasesFromCacheOnly() ([]*common.Release, error) {
+	if impl.blobConfig.CloudConfigured {
+		return releaseCache[CACHE_KEY], nil
+	}
+	releaseNoteObj, err := impl.getActiveReleaseNote()
+	if err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return releaseNoteObj.ReleaseNote, nil
+}
+
+// GetReleasesByTags keeps the requested order and resolves the latest alias
+func (impl *ReleaseNoteServiceImpl) GetReleasesByTags(tags []string, installationId string) (*common.ReleaseBatchLookup, error) {
+	var requestedTags []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			requestedTags = append(requestedTags, tag)
+		}
+	}
+	if len(requestedTags) == 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "tags are required", UserMessage: "tags are required"}
+	}
+	if len(requestedTags) > MaxBatchLookupTags {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("%d tags requested", len(requestedTags)), UserMessage: fmt.Sprintf("at most %d tags can be looked up at once", MaxBatchLookupTags)}
+	}
+	releases, err := impl.getReleasesFromCacheOnly()
+	if err != nil {
+		impl.logger.Errorw("error in getting cached releases", "err", err)
+		return nil, err
+	}
+	releases = impl.withDerivedFields(releases)
+	releaseByTag := make(map[string]*common.Release, len(releases))
+	var latest *common.Release
+	for _, release := range releases {
+		releaseByTag[util.NormalizeVersionTag(release.TagName)] = release
+		if latest == nil && impl.isUpgradeTargetFor(release, installationId) {
+			latest = release
+		}
+	}
+	lookup := &common.ReleaseBatchLookup{Releases: make([]*common.Release, 0, len(requestedTags)), NotFound: make([]string, 0)}
+	for _, tag := range requestedTags {
+		release, ok := releaseByTag[util.NormalizeVersionTag(tag)]
+		if impl.isLatestTagAlias(tag) {
+			release, ok = latest, latest != nil
+		}
+		if !ok {
+			lookup.NotFound = append(lookup.NotFound, tag)
+			continue
+		}
+		lookup.Releases = append(lookup.Releases, release)
+	}
+	return lookup, nil
+}
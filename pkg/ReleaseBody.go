@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"unicode/utf8"
+)
+
+const TruncatedBodyNote = "truncated, see GitHub"
+const markdownCodeFence = "```"
+
+func (impl *ReleaseNoteServiceImpl) applyFallbackBody(release *common.Release) {
+	release.Placeholder = false
+	fallbackBody := strings.TrimSpace(impl.releaseBodyConfig.FallbackBody)
+	if len(strings.TrimSpace(release.Body)) > 0 || len(fallbackBody) == 0 {
+		return
+	}
+	if len(release.TagLink) > 0 {
+		fallbackBody = fmt.Sprintf("%s\n\n[%s](%s)", fallbackBody, release.TagName, release.TagLink)
+	}
+	release.Body = fallbackBody
+	release.Placeholder = true
+}
+
+// truncateBody runs after the fields derived from the body as the cut may drop their markers
+func (impl *ReleaseNoteServiceImpl) truncateBody(release *common.Release) {
+	maxBodyBytes := impl.releaseBodyConfig.MaxBodyBytes
+	if maxBodyBytes <= 0 || len(release.Body) <= maxBodyBytes {
+		return
+	}
+	body := release.Body[:maxBodyBytes]
+	if index := strings.LastIndex(body, "\n\n"); index > 0 {
+		body = body[:index]
+	} else if index := strings.LastIndex(body, "\n"); index > 0 {
+		body = body[:index]
+	} else {
+		for len(body) > 0 && !utf8.ValidString(body) {
+			body = body[:len(body)-1]
+		}
+	}
+	body = strings.TrimRight(body, " \t\r\n")
+	if strings.Count(body, markdownCodeFence)%2 == 1 {
+		body += "\n" + markdownCodeFence
+	}
+	suffix := fmt.Sprintf("[%s]", TruncatedBodyNote)
+	if len(release.TagLink) > 0 {
+		suffix = fmt.Sprintf("[%s](%s)", TruncatedBodyNote, release.TagLink)
+	}
+	release.Body = body + "\n\n" + suffix
+	release.BodyTruncated = true
+}
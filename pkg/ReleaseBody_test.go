@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+)
+
+func TestApplyFallbackBody(t *testing.T) {
+	tests := []struct {
+		name         string
+		fallbackBody string
+		release      *common.Release
+		body         string
+		placeholder  bool
+	}{
+		{
+			name:         "empty body",
+			fallbackBody: "See the release page for details.",
+			release:      &common.Release{TagName: "v0.7.1", TagLink: "https://github.com/devtron-labs/devtron/releases/tag/v0.7.1"},
+			body:         "See the release page for details.\n\n[v0.7.1](https://github.com/devtron-labs/devtron/releases/tag/v0.7.1)",
+			placeholder:  true,
+		},
+		{
+			name:         "blank body without tag link",
+			fallbackBody: "See the release page for details.",
+			release:      &common.Release{TagName: "v0.7.1", Body: " \r\n"},
+			body:         "See the release page for details.",
+			placeholder:  true,
+		},
+		{
+			name:         "non empty body",
+			fallbackBody: "See the release page for details.",
+			release:      &common.Release{TagName: "v0.7.1", Body: "## Bug fixes", Placeholder: true},
+			body:         "## Bug fixes",
+		},
+		{
+			name:    "fallback disabled",
+			release: &common.Release{TagName: "v0.7.1", TagLink: "https://github.com/devtron-labs/devtron/releases/tag/v0.7.1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			impl.releaseBodyConfig.FallbackBody = test.fallbackBody
+			impl.applyFallbackBody(test.release)
+			if test.release.Body != test.body {
+				t.Errorf("expected the body %q, got %q", test.body, test.release.Body)
+			}
+			if test.release.Placeholder != test.placeholder {
+				t.Errorf("expected placeholder %v, got %v", test.placeholder, test.release.Placeholder)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const ReleaseProvenanceBootstrap = "bootstrap"
+
+// BootstrapChecksumSuffix is appended to the snapshot url to get its sha256 companion
+const BootstrapChecksumSuffix = ".sha256"
+
+func (impl *ReleaseNoteServiceImpl) bootstrapFromSnapshot() bool {
+	location := impl.releaseSourceConfig.BootstrapSnapshotURL
+	if len(location) == 0 {
+		return false
+	}
+	ctx := context.Background()
+	if timeout := impl.releaseCacheConfig.RefreshTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	source := &staticReleaseSource{logger: impl.logger, location: location, httpClient: http.DefaultClient, normalize: impl.normalizeRelease}
+	content, err := source.read(ctx)
+	if err != nil {
+		impl.logger.Errorw("error in reading bootstrap snapshot", "location", location, "err", err)
+		return false
+	}
+	checksumSource := &staticReleaseSource{logger: impl.logger, location: location + BootstrapChecksumSuffix, httpClient: http.DefaultClient}
+	checksum, err := checksumSource.read(ctx)
+	if err != nil {
+		impl.logger.Errorw("error in reading bootstrap snapshot checksum", "location", checksumSource.location, "err", err)
+		return false
+	}
+	err = verifySha256(content, checksum)
+	if err != nil {
+		impl.logger.Errorw("bootstrap snapshot failed checksum verification, not loaded", "location", location, "err", err)
+		return false
+	}
+	releases, err := parseReleasesJson(content, impl.normalizeRelease)
+	if err != nil {
+		impl.logger.Errorw("malformed bootstrap snapshot", "location", location, "err", err)
+		return false
+	}
+	if len(releases) == 0 {
+		return false
+	}
+	impl.applyCompareURLs(releases)
+	if !impl.primeCache(releases) {
+		return false
+	}
+	impl.setReleaseProvenance(ReleaseProvenanceBootstrap)
+	impl.logger.Infow("release cache bootstrapped from snapshot", "location", location, "count", len(releases))
+	return true
+}
+
+// verifySha256 checks the content against a checksum file in the sha256sum format
+func verifySha256(content []byte, checksum []byte) error {
+	fields := strings.Fields(string(checksum))
+	if len(fields) == 0 {
+		return fmt.Errorf("empty checksum")
+	}
+	sum := sha256.Sum256(content)
+	if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return fmt.Errorf("sha256 mismatch, expected %s", fields[0])
+	}
+	return nil
+}
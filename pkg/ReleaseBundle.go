@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	_ "embed"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/go-pg/pg"
+	"io/ioutil"
+)
+
+// bundledReleases is replaced at build time for air-gapped deployments
+//
+//go:embed bundled-releases.json
+var bundledReleases []byte
+
+func (impl *ReleaseNoteServiceImpl) primeCacheFromBundle() {
+	if !impl.releaseSourceConfig.BundledPrimingEnabled {
+		return
+	}
+	releases, err := impl.readBundledReleases()
+	if err != nil {
+		impl.logger.Errorw("error in reading bundled releases, cache not primed", "path", impl.releaseSourceConfig.BundledReleasesPath, "err", err)
+		return
+	}
+	if len(releases) == 0 {
+		return
+	}
+	impl.applyCompareURLs(releases)
+	if impl.primeCache(releases) {
+		impl.logger.Infow("release cache primed from bundled releases", "count", len(releases))
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) primeCache(releases []*common.Release) bool {
+	if impl.blobConfig.CloudConfigured {
+		if len(releaseCache[CACHE_KEY]) > 0 {
+			return false
+		}
+		impl.setCachedReleases(releases)
+		return true
+	}
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	_, err := impl.getActiveReleaseNote()
+	if err != pg.ErrNoRows {
+		// cache already filled, or its state is unknown
+		return false
+	}
+	err = impl.updateReleaseNotesInDb(releases, false)
+	if err != nil {
+		impl.logger.Errorw("error in priming release notes", "err", err)
+		return false
+	}
+	return true
+}
+
+func (impl *ReleaseNoteServiceImpl) readBundledReleases() ([]*common.Release, error) {
+	content := bundledReleases
+	if path := impl.releaseSourceConfig.BundledReleasesPath; len(path) > 0 {
+		var err error
+		content, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parseReleasesJson(content, impl.normalizeRelease)
+}
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"errors"
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+)
+
+func TestGetReleasesOnInitialisationPrimesCacheFromBundle(t *testing.T) {
+	defer func(releases []*common.Release) { releaseCache[CACHE_KEY] = releases }(releaseCache[CACHE_KEY])
+	defer func(content []byte) { bundledReleases = content }(bundledReleases)
+	bundledReleases = []byte(`[{"tagName": "v0.7.1", "body": "<!--upgrade-prerequisites-required-->\nupgrade the helm chart\n<!--upgrade-prerequisites-required-->"}, {"tagName": "v0.7.0"}]`)
+	sourceErr := errors.New("github unreachable")
+	tests := []struct {
+		name     string
+		cached   []*common.Release
+		disabled bool
+		tags     string
+	}{
+		{name: "empty cache primed", tags: "v0.7.1,v0.7.0"},
+		{name: "filled cache kept", cached: []*common.Release{{TagName: "v0.6.0"}}, tags: "v0.6.0"},
+		{name: "priming disabled", disabled: true, tags: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releaseCache[CACHE_KEY] = test.cached
+			impl := newTestReleaseNoteService(t)
+			impl.blobConfig.CloudConfigured = true
+			impl.releaseSourceConfig.BundledPrimingEnabled = !test.disabled
+			impl.releaseSource = &fakeReleaseSource{errs: []error{sourceErr, sourceErr, sourceErr}}
+
+			impl.GetReleasesOnInitialisation()
+			if tags := getTestTags(releaseCache[CACHE_KEY]); tags != test.tags {
+				t.Fatalf("expected the cache to hold %q, got %q", test.tags, tags)
+			}
+			if len(test.cached) == 0 && !test.disabled && !releaseCache[CACHE_KEY][0].Prerequisite {
+				t.Errorf("expected the bundled releases to be normalized")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const ChangelogTitle = "# Changelog"
+const ChangelogDateLayout = "2006-01-02"
+
+var markdownHeadingRegex = regexp.MustCompile(`(?m)^(#{1,6})(\s)`)
+
+const (
+	ChangelogFormatMarkdown       = "markdown"
+	ChangelogFormatKeepAChangelog = "keepachangelog"
+)
+
+//go:embed templates/changelog.md.tmpl
+var changelogTemplate string
+
+//go:embed templates/keepachangelog.md.tmpl
+var keepAChangelogTemplate string
+
+var changelogTemplates = map[string]*template.Template{
+	ChangelogFormatMarkdown:       newChangelogTemplate(ChangelogFormatMarkdown, changelogTemplate),
+	ChangelogFormatKeepAChangelog: newChangelogTemplate(ChangelogFormatKeepAChangelog, keepAChangelogTemplate),
+}
+
+func newChangelogTemplate(name string, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"date": func(t time.Time) string {
+			return t.UTC().Format(ChangelogDateLayout)
+		},
+		"body":         normalizeChangelogBody,
+		"prerequisite": getChangelogPrerequisite,
+		"link":         getChangelogLink,
+	}).Parse(text))
+}
+
+func (impl *ReleaseNoteServiceImpl) GenerateChangelogDocument(opts *common.ChangelogOptions) (string, error) {
+	releases, err := impl.GetChangelogReleases(opts)
+	if err != nil {
+		return "", err
+	}
+	var document strings.Builder
+	format := ChangelogFormatMarkdown
+	if opts != nil && len(opts.Format) > 0 {
+		format = opts.Format
+	}
+	err = RenderChangelog(&document, releases, format)
+	if err != nil {
+		return "", err
+	}
+	return document.String(), nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetChangelogReleases(opts *common.ChangelogOptions) ([]*common.Release, error) {
+	if opts == nil {
+		opts = &common.ChangelogOptions{}
+	}
+	if _, ok := changelogTemplates[opts.Format]; !ok && len(opts.Format) > 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("unknown changelog format %s", opts.Format), UserMessage: "format should be markdown or keepachangelog"}
+	}
+	var fromVersion, toVersion *util.SemanticVersion
+	var err error
+	if len(opts.FromVersion) > 0 {
+		fromVersion, err = util.ParseSemanticVersion(opts.FromVersion)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid from version"}
+		}
+	}
+	if len(opts.ToVersion) > 0 {
+		toVersion, err = util.ParseSemanticVersion(opts.ToVersion)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid to version"}
+		}
+	}
+	if fromVersion != nil && toVersion != nil && fromVersion.Compare(toVersion) > 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid version range, %s is higher than %s", opts.FromVersion, opts.ToVersion), UserMessage: "from version should not be higher than to version"}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	var included []*common.Release
+	for _, release := range releases {
+		if release.Prerelease && !opts.IncludePrereleases {
+			continue
+		}
+		if fromVersion != nil || toVersion != nil {
+			version, err := util.ParseSemanticVersion(release.TagName)
+			if err != nil {
+				continue
+			}
+			if (fromVersion != nil && version.Compare(fromVersion) < 0) || (toVersion != nil && version.Compare(toVersion) > 0) {
+				continue
+			}
+		}
+		included = append(included, release)
+	}
+	sortReleasesByPublished(included, opts.OldestFirst)
+	return included, nil
+}
+
+func RenderChangelog(writer io.Writer, releases []*common.Release, format string) error {
+	changelogTemplate, ok := changelogTemplates[format]
+	if !ok {
+		return fmt.Errorf("unknown changelog format %s", format)
+	}
+	return changelogTemplate.Execute(writer, struct {
+		Releases []*common.Release
+	}{Releases: releases})
+}
+
+func getChangelogPrerequisite(release *common.Release) string {
+	message := strings.TrimSpace(strings.ReplaceAll(release.PrerequisiteMessage, "\r\n", "\n"))
+	if !release.Prerequisite || len(message) == 0 {
+		return ""
+	}
+	lines := strings.Split("**Prerequisite:** "+message, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func getChangelogLink(release *common.Release) string {
+	if len(release.CompareURL) > 0 {
+		return release.CompareURL
+	}
+	return release.TagLink
+}
+
+func normalizeChangelogBody(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = strings.TrimSpace(body)
+	return markdownHeadingRegex.ReplaceAllStringFunc(body, func(heading string) string {
+		level := len(strings.TrimRight(heading, " \t"))
+		demotedLevel := level + 2
+		if demotedLevel > 6 {
+			demotedLevel = 6
+		}
+		return strings.Repeat("#", demotedLevel) + heading[level:]
+	})
+}
@@ -0,0 +1,164 @@
+package pkg
+
+import (
+	"bytes"
+	"flag"
+	"github.com/devtron-labs/central-api/common"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the tests")
+
+func newTestChangelogReleases() []*common.Release {
+	return []*common.Release{
+		{
+			TagName:             "v0.7.1",
+			PublishedAt:         time.Date(2023, 11, 3, 2, 0, 0, 0, time.FixedZone("IST", 19800)),
+			Body:                "# Bugs\r\n- fixed the rollout of the hyperion chart\r\n##### Notes\r\ncheck the notes",
+			Prerequisite:        true,
+			PrerequisiteMessage: "upgrade the helm chart\r\nbefore upgrading devtron ",
+			TagLink:             "https://github.com/devtron-labs/devtron/releases/tag/v0.7.1",
+			CompareURL:          "https://github.com/devtron-labs/devtron/compare/v0.7.0...v0.7.1",
+		},
+		{
+			TagName:             "v0.7.0",
+			PublishedAt:         time.Date(2023, 10, 1, 8, 0, 0, 0, time.UTC),
+			Body:                "## Enhancements\n- a new dashboard",
+			PrerequisiteMessage: "ignored without the prerequisite flag",
+			TagLink:             "https://github.com/devtron-labs/devtron/releases/tag/v0.7.0",
+		},
+		{
+			TagName: "v0.6.0",
+		},
+	}
+}
+
+// assertGolden compares the content with the golden file at the path under testdata, go test -run <test> -update
+// rewrites the golden file when the change of the output is deliberate
+func assertGolden(t *testing.T, name string, content []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(golden, content) {
+		t.Errorf("output differs from %s, got\n%s", path, content)
+	}
+}
+
+func TestRenderChangelog(t *testing.T) {
+	tests := []struct {
+		format   string
+		releases []*common.Release
+		golden   string
+	}{
+		{format: ChangelogFormatMarkdown, releases: newTestChangelogReleases(), golden: "markdown.golden.md"},
+		{format: ChangelogFormatKeepAChangelog, releases: newTestChangelogReleases(), golden: "keepachangelog.golden.md"},
+		{format: ChangelogFormatMarkdown, golden: "markdown_empty.golden.md"},
+		{format: ChangelogFormatKeepAChangelog, golden: "keepachangelog_empty.golden.md"},
+	}
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			var document bytes.Buffer
+			err := RenderChangelog(&document, test.releases, test.format)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			assertGolden(t, filepath.Join("changelog", test.golden), document.Bytes())
+		})
+	}
+}
+
+func TestRenderChangelogRefusesUnknownFormat(t *testing.T) {
+	var document bytes.Buffer
+	if err := RenderChangelog(&document, newTestChangelogReleases(), "asciidoc"); err == nil {
+		t.Errorf("expected an unknown format to be refused")
+	}
+}
+
+func TestGetChangelogReleases(t *testing.T) {
+	releases := newTestReleases()
+	for i, release := range releases {
+		release.PublishedAt = time.Date(2023, 10, 1+i, 0, 0, 0, 0, time.UTC)
+	}
+	impl := newTestReleaseNoteService(t, releases...)
+	tests := []struct {
+		name string
+		opts *common.ChangelogOptions
+		tags string
+		err  bool
+	}{
+		{name: "no options", tags: "v0.7.0,v0.7.1"},
+		{name: "prereleases", opts: &common.ChangelogOptions{IncludePrereleases: true}, tags: "v0.7.0,v0.7.1,v0.8.0-rc.1"},
+		{name: "oldest first", opts: &common.ChangelogOptions{IncludePrereleases: true, OldestFirst: true}, tags: "v0.8.0-rc.1,v0.7.1,v0.7.0"},
+		{name: "from version", opts: &common.ChangelogOptions{FromVersion: "v0.7.1"}, tags: "v0.7.1"},
+		{name: "to version", opts: &common.ChangelogOptions{ToVersion: "0.7.0"}, tags: "v0.7.0"},
+		{name: "unknown format", opts: &common.ChangelogOptions{Format: "asciidoc"}, err: true},
+		{name: "invalid from version", opts: &common.ChangelogOptions{FromVersion: "latest"}, err: true},
+		{name: "inverted range", opts: &common.ChangelogOptions{FromVersion: "v0.7.1", ToVersion: "v0.7.0"}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			included, err := impl.GetChangelogReleases(test.opts)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected the options to be refused")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var tags []string
+			for _, release := range included {
+				tags = append(tags, release.TagName)
+			}
+			if strings.Join(tags, ",") != test.tags {
+				t.Errorf("expected %s, got %v", test.tags, tags)
+			}
+		})
+	}
+}
+
+func TestGenerateChangelogDocument(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestChangelogReleases()...)
+	tests := []struct {
+		name     string
+		opts     *common.ChangelogOptions
+		headings []string
+	}{
+		{name: "newest first", headings: []string{"# Changelog", "## v0.7.1 - 2023-11-02", "## v0.7.0 - 2023-10-01", "## v0.6.0"}},
+		{name: "oldest first", opts: &common.ChangelogOptions{OldestFirst: true}, headings: []string{"# Changelog", "## v0.6.0", "## v0.7.0 - 2023-10-01", "## v0.7.1 - 2023-11-02"}},
+		{name: "version range", opts: &common.ChangelogOptions{FromVersion: "v0.7.0", ToVersion: "v0.7.0"}, headings: []string{"# Changelog", "## v0.7.0 - 2023-10-01"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			document, err := impl.GenerateChangelogDocument(test.opts)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var headings []string
+			for _, line := range strings.Split(document, "\n") {
+				if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+					headings = append(headings, line)
+				}
+			}
+			if strings.Join(headings, "|") != strings.Join(test.headings, "|") {
+				t.Errorf("expected the headings %v, got %v", test.headings, headings)
+			}
+			if strings.Contains(document, "\r") {
+				t.Errorf("expected the line endings of the bodies to be normalized")
+			}
+		})
+	}
+}
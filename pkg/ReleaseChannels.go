@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"sort"
+	"strings"
+)
+
+const (
+	ReleaseChannelStable = "stable"
+	ReleaseChannelBeta   = "beta"
+	// ReleaseChannelEdge holds the nightly builds, never an upgrade target
+	ReleaseChannelEdge = "edge"
+	// ReleaseChannelAll asks a list for the releases of every channel, edge included
+	ReleaseChannelAll = "all"
+)
+
+// getReleaseChannel takes the first matching tag pattern, else beta for pre-releases and stable otherwise
+func (impl *ReleaseNoteServiceImpl) getReleaseChannel(release *common.Release) string {
+	if impl.releaseChannelConfig != nil {
+		for _, channelPattern := range impl.releaseChannelConfig.ChannelPatterns {
+			if channelPattern.Pattern.MatchString(release.TagName) {
+				return channelPattern.Channel
+			}
+		}
+	}
+	if release.Prerelease {
+		return ReleaseChannelBeta
+	}
+	return ReleaseChannelStable
+}
+
+func (impl *ReleaseNoteServiceImpl) applyReleaseChannels(releases []*common.Release) {
+	for _, release := range releases {
+		release.Channel = impl.getReleaseChannel(release)
+	}
+}
+
+// matchesReleaseChannel leaves edge releases out unless the edge or every channel is asked for
+func matchesReleaseChannel(release *common.Release, channel string) bool {
+	switch channel {
+	case ReleaseChannelAll:
+		return true
+	case "":
+		return release.Channel != ReleaseChannelEdge
+	default:
+		return release.Channel == channel
+	}
+}
+
+func filterReleaseChannel(releases []*common.Release, channel string) []*common.Release {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == ReleaseChannelAll {
+		return releases
+	}
+	filtered := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		if matchesReleaseChannel(release, channel) {
+			filtered = append(filtered, release)
+		}
+	}
+	return filtered
+}
+
+func (impl *ReleaseNoteServiceImpl) trimEdgeReleases(releases []*common.Release) []*common.Release {
+	if impl.releaseChannelConfig == nil || impl.releaseChannelConfig.ReleaseChannelConfig.EdgeRetention <= 0 {
+		return releases
+	}
+	retention := impl.releaseChannelConfig.ReleaseChannelConfig.EdgeRetention
+	var edgeReleases []*common.Release
+	for _, release := range releases {
+		if impl.getReleaseChannel(release) == ReleaseChannelEdge {
+			edgeReleases = append(edgeReleases, release)
+		}
+	}
+	if len(edgeReleases) <= retention {
+		return releases
+	}
+	sort.SliceStable(edgeReleases, func(i, j int) bool {
+		return edgeReleases[i].PublishedAt.After(edgeReleases[j].PublishedAt)
+	})
+	trimmed := make(map[*common.Release]bool, len(edgeReleases)-retention)
+	for _, release := range edgeReleases[retention:] {
+		trimmed[release] = true
+	}
+	kept := make([]*common.Release, 0, len(releases)-len(trimmed))
+	for _, release := range releases {
+		if !trimmed[release] {
+			kept = append(kept, release)
+		}
+	}
+	impl.logger.Debugw("trimmed edge releases beyond retention", "trimmed", len(trimmed), "retention", retention)
+	return kept
+}
@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func newTestChannelService(t *testing.T, channelPatterns string, edgeRetention string) *ReleaseNoteServiceImpl {
+	t.Setenv("RELEASE_CHANNEL_PATTERNS", channelPatterns)
+	t.Setenv("RELEASE_EDGE_RETENTION", edgeRetention)
+	releaseChannelConfig, err := util.NewReleaseChannelConfig(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	impl := newTestReleaseNoteService(t)
+	impl.releaseChannelConfig = releaseChannelConfig
+	return impl
+}
+
+func TestGetReleaseChannel(t *testing.T) {
+	tests := []struct {
+		name            string
+		channelPatterns string
+		release         *common.Release
+		channel         string
+	}{
+		{name: "no pattern by default", release: &common.Release{TagName: "nightly-20240518", Prerelease: true}, channel: ReleaseChannelBeta},
+		{name: "stable", channelPatterns: "edge=^nightly-", release: &common.Release{TagName: "v0.7.1"}, channel: ReleaseChannelStable},
+		{name: "pre-release", channelPatterns: "edge=^nightly-", release: &common.Release{TagName: "v0.8.0-rc.1", Prerelease: true}, channel: ReleaseChannelBeta},
+		{name: "matching pattern", channelPatterns: "edge=^nightly-", release: &common.Release{TagName: "nightly-20240518", Prerelease: true}, channel: ReleaseChannelEdge},
+		{name: "pattern wins over the pre-release flag", channelPatterns: "Edge=^nightly-", release: &common.Release{TagName: "nightly-20240518"}, channel: ReleaseChannelEdge},
+		{name: "first matching pattern wins", channelPatterns: "rc=-rc\\.[0-9]+$;edge=^v0\\.8\\.", release: &common.Release{TagName: "v0.8.0-rc.1", Prerelease: true}, channel: "rc"},
+		{name: "later pattern matches", channelPatterns: "rc=-rc\\.[0-9]+$;edge=^v0\\.8\\.", release: &common.Release{TagName: "v0.8.0"}, channel: ReleaseChannelEdge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestChannelService(t, test.channelPatterns, "0")
+			if channel := impl.getReleaseChannel(test.release); channel != test.channel {
+				t.Errorf("expected channel %s, got %s", test.channel, channel)
+			}
+		})
+	}
+}
+
+func TestNewReleaseChannelConfigRefusesInvalidPattern(t *testing.T) {
+	for _, channelPatterns := range []string{"edge", "=^nightly-", "edge=", "edge=(nightly"} {
+		t.Setenv("RELEASE_CHANNEL_PATTERNS", channelPatterns)
+		if _, err := util.NewReleaseChannelConfig(zap.NewNop().Sugar()); err == nil {
+			t.Errorf("expected %q to be refused", channelPatterns)
+		}
+	}
+}
+
+func TestFilterReleaseChannelExcludesEdgeByDefault(t *testing.T) {
+	impl := newTestChannelService(t, "edge=^nightly-", "0")
+	releases := append(newTestReleases(), &common.Release{TagName: "nightly-20240518", Prerelease: true})
+	impl.applyReleaseChannels(releases)
+
+	tests := []struct {
+		channel string
+		count   int
+	}{
+		{channel: "", count: 3},
+		{channel: ReleaseChannelAll, count: 4},
+		{channel: ReleaseChannelEdge, count: 1},
+		{channel: ReleaseChannelBeta, count: 1},
+		{channel: " Stable ", count: 2},
+	}
+	for _, test := range tests {
+		if filtered := filterReleaseChannel(releases, test.channel); len(filtered) != test.count {
+			t.Errorf("expected %d releases on channel %q, got %d", test.count, test.channel, len(filtered))
+		}
+	}
+}
+
+func TestTrimEdgeReleasesKeepsLatestNightlies(t *testing.T) {
+	impl := newTestChannelService(t, "edge=^nightly-", "2")
+	publishedAt := time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC)
+	releases := []*common.Release{
+		{TagName: "nightly-20240516", PublishedAt: publishedAt.Add(-48 * time.Hour)},
+		{TagName: "v0.7.1", PublishedAt: publishedAt.Add(-720 * time.Hour)},
+		{TagName: "nightly-20240518", PublishedAt: publishedAt},
+		{TagName: "nightly-20240517", PublishedAt: publishedAt.Add(-24 * time.Hour)},
+	}
+
+	trimmed := impl.trimEdgeReleases(releases)
+	var tags []string
+	for _, release := range trimmed {
+		tags = append(tags, release.TagName)
+	}
+	if len(tags) != 3 || tags[0] != "v0.7.1" || tags[1] != "nightly-20240518" || tags[2] != "nightly-20240517" {
+		t.Errorf("expected the 2 latest nightlies to be kept in order, got %v", tags)
+	}
+	if len(releases) != 4 {
+		t.Errorf("expected the given releases to be left as they were")
+	}
+}
@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"sort"
+	"strings"
+)
+
+// applyCompareURLs has to run on the whole list, a backport changes the previous tag of the next release
+func (impl *ReleaseNoteServiceImpl) applyCompareURLs(releases []*common.Release) {
+	config := impl.client.GitHubConfig
+	compareURLPrefix := fmt.Sprintf("%s/%s/%s/compare", strings.TrimSuffix(config.GitHubHost, "/"), config.GitHubOrg, config.GitHubRepo)
+	for _, release := range releases {
+		release.CompareURL = ""
+	}
+	versioned := parseVersionedReleases(releases)
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return versioned[i].version.Compare(versioned[j].version) < 0
+	})
+	for index := 1; index < len(versioned); index++ {
+		previous, current := versioned[index-1].release, versioned[index].release
+		current.CompareURL = fmt.Sprintf("%s/%s...%s", compareURLPrefix, previous.TagName, current.TagName)
+	}
+}
@@ -0,0 +1,166 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ComponentsMatcher delimits the "<component>: <version>" lines of a release body
+const ComponentsMatcher = "<!--release-components-->"
+
+func (impl *ReleaseNoteServiceImpl) getBreakingChange(releaseInfo *common.Release) {
+	releaseInfo.BreakingChange = strings.Contains(releaseInfo.Body, BreakingChangesMatcher)
+}
+
+// getComponents parses the components block of the release body into the Components map
+func (impl *ReleaseNoteServiceImpl) getComponents(releaseInfo *common.Release) {
+	releaseInfo.Components = nil
+	start := strings.Index(releaseInfo.Body, ComponentsMatcher)
+	if start < 0 {
+		return
+	}
+	block := releaseInfo.Body[start+len(ComponentsMatcher):]
+	if end := strings.Index(block, ComponentsMatcher); end >= 0 {
+		block = block[:end]
+	}
+	components := make(map[string]string)
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*"))
+		separator := strings.IndexAny(line, ":=")
+		if separator <= 0 {
+			continue
+		}
+		component := strings.Trim(strings.TrimSpace(line[:separator]), "`")
+		version := strings.Trim(strings.TrimSpace(line[separator+1:]), "`")
+		if len(component) == 0 || len(version) == 0 {
+			continue
+		}
+		components[component] = version
+	}
+	if len(components) > 0 {
+		releaseInfo.Components = components
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) isLatestTagAlias(tag string) bool {
+	alias := impl.client.GitHubConfig.LatestTagAlias
+	return len(alias) > 0 && strings.EqualFold(strings.TrimSpace(tag), alias)
+}
+
+func (impl *ReleaseNoteServiceImpl) findCachedRelease(tag string) (*common.Release, error) {
+	if impl.isLatestTagAlias(tag) {
+		// the alias resolves like /release/latest, so that both agree on what the latest release is
+		release, err := impl.GetLatestReleaseForInstallation("")
+		if err != nil {
+			return nil, err
+		}
+		if release == nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: "no latest release found", UserMessage: "release not found"}
+		}
+		return release, nil
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if util.IsSameVersionTag(release.TagName, tag) {
+			return release, nil
+		}
+	}
+	return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("release not found, tag: %s", tag), UserMessage: "release not found"}
+}
+
+// GetReleaseComponents returns the companion component versions pinned by a release
+func (impl *ReleaseNoteServiceImpl) GetReleaseComponents(tag string) (map[string]string, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.Components == nil {
+		return map[string]string{}, nil
+	}
+	return release.Components, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetComponentVersions(component string) ([]*common.ComponentVersion, error) {
+	if len(component) == 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "component is required", UserMessage: "component is required"}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	componentVersions := make([]*common.ComponentVersion, 0)
+	componentVersionByVersion := make(map[string]*common.ComponentVersion)
+	for _, release := range releases {
+		version, ok := release.Components[component]
+		if !ok {
+			continue
+		}
+		componentVersion, ok := componentVersionByVersion[version]
+		if !ok {
+			componentVersion = &common.ComponentVersion{Version: version}
+			componentVersionByVersion[version] = componentVersion
+			componentVersions = append(componentVersions, componentVersion)
+		}
+		componentVersion.Releases = append(componentVersion.Releases, release.TagName)
+	}
+	sort.SliceStable(componentVersions, func(i, j int) bool {
+		iVersion, iErr := util.ParseSemanticVersion(componentVersions[i].Version)
+		jVersion, jErr := util.ParseSemanticVersion(componentVersions[j].Version)
+		if iErr != nil || jErr != nil {
+			// non semver versions go last, in the order they were found
+			return iErr == nil && jErr != nil
+		}
+		return iVersion.Compare(jVersion) > 0
+	})
+	return componentVersions, nil
+}
+
+// GetReleaseByTag looks a tag missing from the cache up on github without caching it
+func (impl *ReleaseNoteServiceImpl) GetReleaseByTag(tag string) (*common.Release, error) {
+	release, err := impl.findCachedRelease(tag)
+	if apiErr, ok := err.(*util.ApiError); !ok || apiErr.HttpStatusCode != http.StatusNotFound || impl.isLatestTagAlias(tag) {
+		return release, err
+	}
+	if _, ok := impl.releaseSource.(*githubReleaseSource); !ok {
+		// releases of the other sources are only known through the cache
+		return release, err
+	}
+	gitHubConfig := impl.client.GitHubConfig
+	if impl.tagLookupMisses.isMissing(tag, time.Now()) {
+		return release, err
+	}
+	ctx := context.Background()
+	if gitHubConfig.GitHubTagLookupTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gitHubConfig.GitHubTagLookupTimeout)
+		defer cancel()
+	}
+	item, err := impl.getGithubReleaseByTag(ctx, gitHubConfig.GitHubOrg, gitHubConfig.GitHubRepo, tag)
+	if err != nil {
+		if isNotFoundError(err) {
+			impl.tagLookupMisses.addMissing(tag, time.Now(), gitHubConfig.GitHubTagLookupNotFoundTTL)
+			return nil, &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("release not found, tag: %s", tag), UserMessage: "release not found"}
+		}
+		impl.logger.Errorw("error in fetching release by tag from github", "tag", tag, "err", err)
+		return nil, err
+	}
+	release = impl.NormalizeRelease(getRawGithubRelease(item, TagLink))
+	impl.applyYankedReleases([]*common.Release{release})
+	// support depends on the other lines, it is evaluated along with the cached releases
+	cachedReleases, err := impl.getReleasesFromCacheOnly()
+	if err != nil {
+		impl.logger.Errorw("error in getting cached releases", "err", err)
+		return nil, err
+	}
+	releases := impl.withDerivedFields(append(append([]*common.Release{}, cachedReleases...), release))
+	return releases[len(releases)-1], nil
+}
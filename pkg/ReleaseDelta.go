@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"strings"
+	"time"
+)
+
+// releaseSourceContent leaves out the fields derived on read, like the age
+type releaseSourceContent struct {
+	TagName     string                 `json:"tagName"`
+	ReleaseName string                 `json:"releaseName"`
+	Body        string                 `json:"body"`
+	PublishedAt time.Time              `json:"publishedAt"`
+	Assets      []*common.ReleaseAsset `json:"assets"`
+}
+
+func getReleaseEtag(release *common.Release) (string, error) {
+	return util.GetContentHash(&releaseSourceContent{
+		TagName:     release.TagName,
+		ReleaseName: release.ReleaseName,
+		Body:        release.Body,
+		PublishedAt: release.PublishedAt,
+		Assets:      release.Assets,
+	})
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleasesDelta(knownTag string, knownEtag string) (*common.ReleaseDelta, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	knownIndex := -1
+	for index, release := range releases {
+		if util.IsSameVersionTag(release.TagName, knownTag) {
+			knownIndex = index
+			break
+		}
+	}
+	delta := &common.ReleaseDelta{Releases: releases, Etags: make(map[string]string)}
+	if knownIndex < 0 {
+		delta.Resync = true
+	} else {
+		// releases are ordered newest first
+		delta.Releases = releases[:knownIndex]
+		if len(knownEtag) > 0 {
+			etag, err := getReleaseEtag(releases[knownIndex])
+			if err != nil {
+				return nil, err
+			}
+			delta.KnownTagModified = etag != strings.Trim(knownEtag, "\"")
+		}
+	}
+	for _, release := range delta.Releases {
+		etag, err := getReleaseEtag(release)
+		if err != nil {
+			return nil, err
+		}
+		delta.Etags[release.TagName] = etag
+	}
+	return delta, nil
+}
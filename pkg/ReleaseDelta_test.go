@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"testing"
+)
+
+func TestReleaseEtagIgnoresDerivedFields(t *testing.T) {
+	release := newTestReleases()[1]
+	etag, err := getReleaseEtag(release)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	derived := *release
+	ageDays := 400
+	derived.AgeDays = &ageDays
+	derived.Supported = true
+	derived.Blocked = true
+	derived.Channel = ReleaseChannelEdge
+	derivedEtag, err := getReleaseEtag(&derived)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if derivedEtag != etag {
+		t.Errorf("expected the etag to ignore the fields derived on read")
+	}
+	edited := *release
+	edited.Body = "edited notes"
+	editedEtag, err := getReleaseEtag(&edited)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if editedEtag == etag {
+		t.Errorf("expected the etag to change with the body")
+	}
+	edited = *release
+	edited.Assets = []*common.ReleaseAsset{{Id: 1, Name: "devtron.spdx.json"}}
+	editedEtag, err = getReleaseEtag(&edited)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if editedEtag == etag {
+		t.Errorf("expected the etag to change with the assets")
+	}
+}
+
+func TestGetReleasesDelta(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	knownEtag, err := getReleaseEtag(newTestReleases()[2])
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	delta, err := impl.GetReleasesDelta("0.7.0", `"`+knownEtag+`"`)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if delta.Resync || delta.KnownTagModified {
+		t.Errorf("expected the known tag to be found unmodified, got resync %v modified %v", delta.Resync, delta.KnownTagModified)
+	}
+	if len(delta.Releases) != 2 || delta.Releases[0].TagName != "v0.8.0-rc.1" || delta.Releases[1].TagName != "v0.7.1" {
+		t.Errorf("expected the two releases newer than v0.7.0, got %d releases", len(delta.Releases))
+	}
+	if len(delta.Etags) != 2 || len(delta.Etags["v0.7.1"]) == 0 {
+		t.Errorf("expected an etag for every newer release, got %v", delta.Etags)
+	}
+
+	delta, err = impl.GetReleasesDelta("v0.5.0", "")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !delta.Resync || len(delta.Releases) != 3 {
+		t.Errorf("expected every release with resync for an unknown tag, got resync %v and %d releases", delta.Resync, len(delta.Releases))
+	}
+}
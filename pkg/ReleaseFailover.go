@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/google/go-github/github"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ReleaseProvenancePrimary  = "primary"
+	ReleaseProvenanceFallback = "fallback"
+)
+
+// releaseProvenanceState tells which source the current releases were fetched from
+type releaseProvenanceState struct {
+	mutex      sync.RWMutex
+	provenance string
+	fetchedAt  time.Time
+}
+
+func isNotFoundError(err error) bool {
+	var responseErr *github.ErrorResponse
+	return errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusNotFound
+}
+
+// shouldFallback falls back right away on a missing repo, otherwise once the retries are exhausted
+func shouldFallback(config *util.ReleaseSourceConfig, primaryErr error, retriesExhausted bool) bool {
+	if isNotFoundError(primaryErr) {
+		return config.FallbackOnNotFound
+	}
+	return retriesExhausted && config.FallbackOnRetriesExhausted
+}
+
+func newFallbackReleaseSource(logger *zap.SugaredLogger, config *util.ReleaseSourceConfig, service *ReleaseNoteServiceImpl) (ReleaseSource, error) {
+	mirrorConfigured := len(config.FallbackGitHubOrg) > 0 || len(config.FallbackGitHubRepo) > 0
+	if mirrorConfigured && len(config.FallbackSnapshotURL) > 0 {
+		return nil, fmt.Errorf("only one of fallback github repo and fallback snapshot url can be configured")
+	}
+	if mirrorConfigured {
+		if len(config.FallbackGitHubOrg) == 0 || len(config.FallbackGitHubRepo) == 0 {
+			return nil, fmt.Errorf("fallback github repo needs both RELEASE_FALLBACK_GITHUB_ORG and RELEASE_FALLBACK_GITHUB_REPO")
+		}
+		return &githubMirrorReleaseSource{service: service, org: config.FallbackGitHubOrg, repo: config.FallbackGitHubRepo}, nil
+	}
+	if len(config.FallbackSnapshotURL) > 0 {
+		return &staticReleaseSource{logger: logger, location: config.FallbackSnapshotURL, httpClient: http.DefaultClient, normalize: service.normalizeRelease}, nil
+	}
+	return nil, nil
+}
+
+// githubMirrorReleaseSource reads the releases of a mirror repo, tag links point to the mirror
+type githubMirrorReleaseSource struct {
+	service *ReleaseNoteServiceImpl
+	org     string
+	repo    string
+}
+
+func (source *githubMirrorReleaseSource) FetchReleases(ctx context.Context) ([]*common.Release, error) {
+	tagLinkPrefix := fmt.Sprintf("%s/%s/%s/releases/tag", strings.TrimSuffix(source.service.client.GitHubConfig.GitHubHost, "/"), source.org, source.repo)
+	return source.service.fetchGithubRepoReleases(ctx, source.org, source.repo, tagLinkPrefix)
+}
+
+func (impl *ReleaseNoteServiceImpl) fetchFallbackReleases(ctx context.Context, primaryErr error, retriesExhausted bool) ([]*common.Release, error) {
+	if impl.fallbackReleaseSource == nil || !shouldFallback(impl.releaseSourceConfig, primaryErr, retriesExhausted) {
+		return nil, primaryErr
+	}
+	impl.logger.Warnw("primary release source failed, reading releases from fallback", "err", primaryErr)
+	releases, err := impl.fallbackReleaseSource.FetchReleases(ctx)
+	if err != nil {
+		impl.logger.Errorw("error in fetching releases from fallback source", "err", err)
+		return nil, err
+	}
+	impl.setReleaseProvenance(ReleaseProvenanceFallback)
+	return releases, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) setReleaseProvenance(provenance string) {
+	impl.releaseProvenance.mutex.Lock()
+	defer impl.releaseProvenance.mutex.Unlock()
+	impl.releaseProvenance.provenance = provenance
+	impl.releaseProvenance.fetchedAt = time.Now()
+}
+
+func (impl *ReleaseNoteServiceImpl) isServingFallback() bool {
+	impl.releaseProvenance.mutex.RLock()
+	defer impl.releaseProvenance.mutex.RUnlock()
+	return impl.releaseProvenance.provenance == ReleaseProvenanceFallback || impl.releaseProvenance.provenance == ReleaseProvenanceBootstrap
+}
+
+func (impl *ReleaseNoteServiceImpl) recoverFromFallbackPeriodically(ctx context.Context) {
+	interval := impl.releaseSourceConfig.FallbackRecoveryInterval
+	if (impl.fallbackReleaseSource == nil && len(impl.releaseSourceConfig.BootstrapSnapshotURL) == 0) || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if impl.isServingFallback() {
+				impl.refreshReleasesWithTimeout(ctx)
+			}
+		}
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseSourceMeta() *common.ReleaseSourceMeta {
+	impl.releaseProvenance.mutex.RLock()
+	defer impl.releaseProvenance.mutex.RUnlock()
+	meta := &common.ReleaseSourceMeta{
+		Source:     impl.releaseSourceConfig.ReleaseSource,
+		Provenance: impl.releaseProvenance.provenance,
+	}
+	if !impl.releaseProvenance.fetchedAt.IsZero() {
+		fetchedAt := impl.releaseProvenance.fetchedAt
+		meta.FetchedAt = &fetchedAt
+	}
+	if meta.Provenance == ReleaseProvenanceFallback {
+		meta.FallbackLocation = impl.releaseSourceConfig.FallbackSnapshotURL
+		if len(impl.releaseSourceConfig.FallbackGitHubRepo) > 0 {
+			meta.FallbackLocation = impl.releaseSourceConfig.FallbackGitHubOrg + "/" + impl.releaseSourceConfig.FallbackGitHubRepo
+		}
+	}
+	if meta.Provenance == ReleaseProvenanceBootstrap {
+		meta.BootstrapLocation = impl.releaseSourceConfig.BootstrapSnapshotURL
+	}
+	return meta
+}
@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/go-pg/pg"
+	"github.com/google/go-github/github"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var errRepoNotFound = &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+func TestShouldFallback(t *testing.T) {
+	transient := errors.New("connection reset")
+	tests := []struct {
+		name               string
+		onNotFound         bool
+		onRetriesExhausted bool
+		primaryErr         error
+		retriesExhausted   bool
+		fallback           bool
+	}{
+		{name: "missing repo", onNotFound: true, primaryErr: errRepoNotFound, fallback: true},
+		{name: "missing repo without fallback on not found", onRetriesExhausted: true, primaryErr: errRepoNotFound, retriesExhausted: true},
+		{name: "retries exhausted", onRetriesExhausted: true, primaryErr: transient, retriesExhausted: true, fallback: true},
+		{name: "retries left", onRetriesExhausted: true, primaryErr: transient},
+		{name: "retries exhausted without fallback on retries", onNotFound: true, primaryErr: transient, retriesExhausted: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &util.ReleaseSourceConfig{FallbackOnNotFound: test.onNotFound, FallbackOnRetriesExhausted: test.onRetriesExhausted}
+			if fallback := shouldFallback(config, test.primaryErr, test.retriesExhausted); fallback != test.fallback {
+				t.Errorf("expected fallback %v, got %v", test.fallback, fallback)
+			}
+		})
+	}
+}
+
+func TestFetchFallbackReleases(t *testing.T) {
+	transient := errors.New("connection reset")
+	tests := []struct {
+		name           string
+		primaryErrs    []error
+		fallbackErrs   []error
+		primaryCalls   int
+		fallbackCalls  int
+		provenance     string
+		expectReleases bool
+	}{
+		{name: "primary recovers on retry", primaryErrs: []error{transient, transient}, primaryCalls: 3, provenance: ReleaseProvenancePrimary, expectReleases: true},
+		{name: "missing repo falls back right away", primaryErrs: []error{errRepoNotFound}, primaryCalls: 1, fallbackCalls: 1, provenance: ReleaseProvenanceFallback, expectReleases: true},
+		{name: "exhausted retries fall back", primaryErrs: []error{transient, transient, transient}, primaryCalls: 3, fallbackCalls: 1, provenance: ReleaseProvenanceFallback, expectReleases: true},
+		{name: "failing fallback", primaryErrs: []error{transient, transient, transient}, fallbackErrs: []error{transient}, primaryCalls: 3, fallbackCalls: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			primary := &fakeReleaseSource{errs: test.primaryErrs, releases: newTestReleases()}
+			fallback := &fakeReleaseSource{errs: test.fallbackErrs, releases: newTestReleases()[1:]}
+			impl.releaseSource = primary
+			impl.fallbackReleaseSource = fallback
+
+			releases, err := impl.getReleasesFromGithubWithRetry(context.Background())
+			if test.expectReleases && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			} else if !test.expectReleases && err == nil {
+				t.Fatalf("expected an error once the fallback failed too")
+			}
+			if primary.getCalls() != test.primaryCalls || fallback.getCalls() != test.fallbackCalls {
+				t.Errorf("expected %d primary and %d fallback fetches, got %d and %d", test.primaryCalls, test.fallbackCalls, primary.getCalls(), fallback.getCalls())
+			}
+			if provenance := impl.GetReleaseSourceMeta().Provenance; provenance != test.provenance {
+				t.Errorf("expected provenance %q, got %q", test.provenance, provenance)
+			}
+			if test.provenance == ReleaseProvenanceFallback && len(releases) != len(newTestReleases())-1 {
+				t.Errorf("expected the releases of the fallback, got %d releases", len(releases))
+			}
+		})
+	}
+}
+
+func TestRecoverFromFallbackStopsWithService(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	primary := &fakeReleaseSource{releases: []*common.Release{{TagName: "v0.7.1"}}}
+	impl.releaseSource = primary
+	impl.fallbackReleaseSource = &fakeReleaseSource{}
+	impl.releaseSourceConfig.FallbackRecoveryInterval = 10 * time.Millisecond
+	impl.releaseCacheConfig.TTL = 0
+	impl.releaseCacheConfig.RefreshInterval = 0
+	// nothing listens on the port, storing the recovered releases fails without stopping the recovery
+	db := pg.Connect(&pg.Options{Addr: "127.0.0.1:1"})
+	defer db.Close()
+	impl.releaseNoteRepository = &fakeReleaseNoteRepository{db: db}
+	impl.setReleaseProvenance(ReleaseProvenanceFallback)
+
+	impl.Start(context.Background())
+	deadline := time.Now().Add(5 * time.Second)
+	for impl.isServingFallback() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	impl.Stop()
+	if impl.isServingFallback() {
+		t.Fatalf("expected the primary source to be read again while serving the fallback")
+	}
+
+	calls := primary.getCalls()
+	impl.setReleaseProvenance(ReleaseProvenanceFallback)
+	time.Sleep(50 * time.Millisecond)
+	if primary.getCalls() != calls {
+		t.Errorf("expected the recovery to stop with the service, got %d more fetches", primary.getCalls()-calls)
+	}
+}
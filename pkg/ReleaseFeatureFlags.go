@@ -0,0 +1,167 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var featureFlagsHeadingRegex = regexp.MustCompile(`(?im)^(#{1,6})\s*feature\s+flags\s*:?\s*$`)
+
+var markdownTableSeparatorRegex = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+
+// featureFlagColumns maps the accepted header names to the field of the flag they fill
+var featureFlagColumns = map[string]string{
+	"flag":        "name",
+	"name":        "name",
+	"feature":     "name",
+	"env":         "name",
+	"default":     "default",
+	"description": "description",
+	"details":     "description",
+}
+
+// getFeatureFlags parses the feature flags table of the release body
+func (impl *ReleaseNoteServiceImpl) getFeatureFlags(releaseInfo *common.Release) {
+	releaseInfo.FeatureFlags, _ = parseFeatureFlags(releaseInfo.Body)
+}
+
+func parseFeatureFlags(body string) ([]*common.FeatureFlag, []string) {
+	heading := featureFlagsHeadingRegex.FindStringSubmatchIndex(body)
+	if heading == nil {
+		return nil, nil
+	}
+	headingLevel := heading[3] - heading[2]
+	var tableLines []string
+	for _, line := range strings.Split(strings.ReplaceAll(body[heading[1]:], "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if level := markdownHeadingLevel(line); level > 0 && level <= headingLevel {
+			break
+		}
+		if strings.HasPrefix(line, "|") {
+			tableLines = append(tableLines, line)
+		} else if len(tableLines) > 0 {
+			// the table ends at the first line not being a row
+			break
+		}
+	}
+	if len(tableLines) < 2 || !markdownTableSeparatorRegex.MatchString(tableLines[1]) {
+		return nil, []string{"feature flags heading has no markdown table below it"}
+	}
+	columns := splitTableRow(tableLines[0])
+	nameColumn := -1
+	for i, column := range columns {
+		if featureFlagColumns[strings.ToLower(column)] == "name" {
+			nameColumn = i
+			break
+		}
+	}
+	if nameColumn < 0 {
+		return nil, []string{"feature flags table has no flag column"}
+	}
+	var flags []*common.FeatureFlag
+	var problems []string
+	for i, line := range tableLines[2:] {
+		cells := splitTableRow(line)
+		if len(cells) <= nameColumn || len(strings.Trim(cells[nameColumn], "`")) == 0 {
+			problems = append(problems, fmt.Sprintf("feature flags table row %d has no flag name", i+1))
+			continue
+		}
+		if len(cells) != len(columns) {
+			problems = append(problems, fmt.Sprintf("feature flags table row %d has %d cells for %d columns", i+1, len(cells), len(columns)))
+		}
+		flag := &common.FeatureFlag{}
+		for j, cell := range cells {
+			if j >= len(columns) {
+				break
+			}
+			switch featureFlagColumns[strings.ToLower(columns[j])] {
+			case "name":
+				flag.Name = strings.Trim(cell, "`")
+			case "default":
+				flag.Default = strings.Trim(cell, "`")
+			case "description":
+				flag.Description = cell
+			}
+		}
+		flags = append(flags, flag)
+	}
+	return flags, problems
+}
+
+func markdownHeadingLevel(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || (len(line) > level && line[level] != ' ' && line[level] != '\t') {
+		return 0
+	}
+	return level
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+func lintFeatureFlags(release *common.Release) []string {
+	_, problems := parseFeatureFlags(release.Body)
+	return problems
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseFeatureFlags(tag string) ([]*common.FeatureFlag, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.FeatureFlags == nil {
+		return []*common.FeatureFlag{}, nil
+	}
+	return release.FeatureFlags, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetFeatureFlagsSince(since string) ([]*common.FeatureFlag, error) {
+	var sinceVersion *util.SemanticVersion
+	if len(since) > 0 {
+		var err error
+		sinceVersion, err = util.ParseSemanticVersion(since)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: fmt.Sprintf("invalid since version %s", since)}
+		}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	versioned := parseVersionedReleases(releases)
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return versioned[i].version.Compare(versioned[j].version) < 0
+	})
+	flags := make([]*common.FeatureFlag, 0)
+	seen := make(map[string]bool)
+	for _, item := range versioned {
+		if sinceVersion != nil && item.version.Compare(sinceVersion) <= 0 {
+			// flags of the releases up to the version are known already
+			for _, flag := range item.release.FeatureFlags {
+				seen[flag.Name] = true
+			}
+			continue
+		}
+		for _, flag := range item.release.FeatureFlags {
+			if seen[flag.Name] {
+				continue
+			}
+			seen[flag.Name] = true
+			introduced := *flag
+			introduced.IntroducedIn = item.release.TagName
+			flags = append(flags, &introduced)
+		}
+	}
+	return flags, nil
+}
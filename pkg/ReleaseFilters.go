@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"sort"
+	"time"
+)
+
+func (impl *ReleaseNoteServiceImpl) GetRecentReleasesByDays(days int) ([]*common.Release, error) {
+	if days <= 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid days %d", days), UserMessage: "days should be greater than 0"}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	recentReleases := make([]*common.Release, 0)
+	for _, release := range releases {
+		if isPublishedBetween(release, cutoff, time.Time{}) {
+			recentReleases = append(recentReleases, release)
+		}
+	}
+	return recentReleases, nil
+}
+
+func isPublishedBetween(release *common.Release, from time.Time, to time.Time) bool {
+	if from.IsZero() && to.IsZero() {
+		return true
+	}
+	if release.PublishedAt.IsZero() {
+		return false
+	}
+	return (from.IsZero() || !release.PublishedAt.Before(from)) && (to.IsZero() || release.PublishedAt.Before(to))
+}
+
+// GetReleaseList keeps cache order, after and before are exclusive version bounds
+func (impl *ReleaseNoteServiceImpl) GetReleaseList(filter *common.ReleaseListFilter) ([]*common.Release, error) {
+	var after, before *util.SemanticVersion
+	var err error
+	if len(filter.After) > 0 {
+		after, err = util.ParseSemanticVersion(filter.After)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: []*common.FieldError{{Field: "after", Message: "should be a version like 0.6.0"}}}
+		}
+	}
+	if len(filter.Before) > 0 {
+		before, err = util.ParseSemanticVersion(filter.Before)
+		if err != nil {
+			return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: []*common.FieldError{{Field: "before", Message: "should be a version like 0.6.0"}}}
+		}
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	releases = filterReleaseChannel(releases, filter.Channel)
+	filtered := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		if filter.Prerequisite != nil && release.Prerequisite != *filter.Prerequisite {
+			continue
+		}
+		if filter.Breaking != nil && release.BreakingChange != *filter.Breaking {
+			continue
+		}
+		if after != nil || before != nil {
+			version, err := util.ParseSemanticVersion(release.TagName)
+			if err != nil || (after != nil && version.Compare(after) <= 0) || (before != nil && version.Compare(before) >= 0) {
+				continue
+			}
+		}
+		filtered = append(filtered, release)
+	}
+	return filtered, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseTags(channel string) (*common.ReleaseTagList, error) {
+	releases, err := impl.GetReleaseList(&common.ReleaseListFilter{Channel: channel})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*common.ReleaseTag, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, &common.ReleaseTag{
+			TagName:     release.TagName,
+			PublishedAt: release.PublishedAt,
+			Channel:     release.Channel,
+			Yanked:      release.Yanked,
+			Supported:   release.Supported,
+		})
+	}
+	return &common.ReleaseTagList{Count: len(tags), Tags: tags}, nil
+}
+
+const ReleaseFrequencyMonthLayout = "2006-01"
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseFrequency() ([]*common.ReleaseFrequency, error) {
+	view, err := impl.getDerivedView(DerivedViewFrequency, func(releases []*common.Release) (interface{}, error) {
+		return countReleasesPerMonth(releases), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return view.([]*common.ReleaseFrequency), nil
+}
+
+func countReleasesPerMonth(releases []*common.Release) []*common.ReleaseFrequency {
+	counts := make(map[string]int)
+	var months []string
+	for _, release := range releases {
+		if release.PublishedAt.IsZero() {
+			continue
+		}
+		month := release.PublishedAt.UTC().Format(ReleaseFrequencyMonthLayout)
+		if _, ok := counts[month]; !ok {
+			months = append(months, month)
+		}
+		counts[month]++
+	}
+	// the layout sorts lexically in chronological order
+	sort.Strings(months)
+	frequency := make([]*common.ReleaseFrequency, 0, len(months))
+	for _, month := range months {
+		frequency = append(frequency, &common.ReleaseFrequency{Month: month, Count: counts[month]})
+	}
+	return frequency
+}
+
+func applyReleaseAge(releases []*common.Release, now time.Time) {
+	for _, release := range releases {
+		if release.PublishedAt.IsZero() {
+			release.AgeDays = nil
+			continue
+		}
+		ageDays := int(now.Sub(release.PublishedAt).Hours() / 24)
+		if ageDays < 0 {
+			ageDays = 0
+		}
+		release.AgeDays = &ageDays
+	}
+}
@@ -0,0 +1,136 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetReleaseListCombinesFilters(t *testing.T) {
+	impl := newTestReleaseNoteService(t,
+		&common.Release{TagName: "v0.8.0", Prerequisite: true, BreakingChange: true},
+		&common.Release{TagName: "v0.7.1", Prerequisite: true},
+		&common.Release{TagName: "v0.7.0", BreakingChange: true},
+		&common.Release{TagName: "v0.6.0", Prerequisite: true, BreakingChange: true},
+		&common.Release{TagName: "hotfix-1", Prerequisite: true, BreakingChange: true},
+	)
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter *common.ReleaseListFilter
+		tags   string
+	}{
+		{name: "no filter", filter: &common.ReleaseListFilter{}, tags: "v0.8.0,v0.7.1,v0.7.0,v0.6.0,hotfix-1"},
+		{name: "prerequisite", filter: &common.ReleaseListFilter{Prerequisite: &yes}, tags: "v0.8.0,v0.7.1,v0.6.0,hotfix-1"},
+		{name: "without prerequisite", filter: &common.ReleaseListFilter{Prerequisite: &no}, tags: "v0.7.0"},
+		{name: "prerequisite and breaking", filter: &common.ReleaseListFilter{Prerequisite: &yes, Breaking: &yes}, tags: "v0.8.0,v0.6.0,hotfix-1"},
+		{name: "breaking between versions", filter: &common.ReleaseListFilter{Breaking: &yes, After: "0.6.0", Before: "0.8.0"}, tags: "v0.7.0"},
+		{name: "prerequisite after version", filter: &common.ReleaseListFilter{Prerequisite: &yes, After: "v0.6.0"}, tags: "v0.8.0,v0.7.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releases, err := impl.GetReleaseList(test.filter)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var tags []string
+			for _, release := range releases {
+				tags = append(tags, release.TagName)
+			}
+			if strings.Join(tags, ",") != test.tags {
+				t.Errorf("expected %s, got %v", test.tags, tags)
+			}
+		})
+	}
+}
+
+func TestGetReleaseListRefusesInvalidBound(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	if _, err := impl.GetReleaseList(&common.ReleaseListFilter{After: "latest"}); err == nil {
+		t.Errorf("expected a bound which isn't a version to be refused")
+	}
+}
+
+func TestGetRecentReleasesByDays(t *testing.T) {
+	now := time.Now()
+	impl := newTestReleaseNoteService(t,
+		&common.Release{TagName: "v0.8.0", PublishedAt: now.Add(-time.Hour)},
+		&common.Release{TagName: "v0.7.1", PublishedAt: now.AddDate(0, 0, -29)},
+		&common.Release{TagName: "v0.7.0", PublishedAt: now.AddDate(0, 0, -31)},
+		&common.Release{TagName: "v0.6.0"},
+	)
+	tests := []struct {
+		days int
+		tags string
+	}{
+		{days: 1, tags: "v0.8.0"},
+		{days: 30, tags: "v0.8.0,v0.7.1"},
+		{days: 365, tags: "v0.8.0,v0.7.1,v0.7.0"},
+	}
+	for _, test := range tests {
+		releases, err := impl.GetRecentReleasesByDays(test.days)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if tags := getTestTags(releases); tags != test.tags {
+			t.Errorf("expected %q within %d days, got %q", test.tags, test.days, tags)
+		}
+	}
+	for _, days := range []int{0, -7} {
+		_, err := impl.GetRecentReleasesByDays(days)
+		if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+			t.Errorf("expected %d days to be refused, got %v", days, err)
+		}
+	}
+}
+
+func TestGetReleaseFrequency(t *testing.T) {
+	impl := newTestReleaseNoteService(t,
+		&common.Release{TagName: "v0.8.0", PublishedAt: time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)},
+		&common.Release{TagName: "v0.7.2", PublishedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// still february in UTC
+		&common.Release{TagName: "v0.7.1", PublishedAt: time.Date(2024, 3, 1, 3, 0, 0, 0, time.FixedZone("IST", 19800))},
+		&common.Release{TagName: "v0.7.0", PublishedAt: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+		&common.Release{TagName: "v0.6.0", PublishedAt: time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC)},
+		&common.Release{TagName: "v0.5.0"},
+	)
+
+	frequency, err := impl.GetReleaseFrequency()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var counts []string
+	for _, month := range frequency {
+		counts = append(counts, fmt.Sprintf("%s:%d", month.Month, month.Count))
+	}
+	if strings.Join(counts, ",") != "2023-12:1,2024-02:2,2024-03:2" {
+		t.Errorf("expected the counts per month in chronological order, got %v", counts)
+	}
+}
+
+func TestApplyReleaseAge(t *testing.T) {
+	now := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	releases := []*common.Release{
+		{TagName: "v0.8.0", PublishedAt: time.Date(2024, 3, 15, 6, 37, 10, 0, time.UTC)},
+		{TagName: "v0.7.1", PublishedAt: time.Date(2024, 3, 15, 6, 37, 11, 0, time.UTC)},
+		{TagName: "v0.7.0", PublishedAt: now.Add(time.Hour)},
+		{TagName: "v0.6.0"},
+	}
+	applyReleaseAge(releases, now)
+
+	var ages []string
+	for _, release := range releases {
+		if release.AgeDays == nil {
+			ages = append(ages, "nil")
+			continue
+		}
+		ages = append(ages, fmt.Sprint(*release.AgeDays))
+	}
+	if strings.Join(ages, ",") != "3,2,0,nil" {
+		t.Errorf("expected the whole days since publishing, got %v", ages)
+	}
+}
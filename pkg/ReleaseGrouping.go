@@ -0,0 +1,111 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type versionedRelease struct {
+	release *common.Release
+	version *util.SemanticVersion
+}
+
+func parseVersionedReleases(releases []*common.Release) []*versionedRelease {
+	var versioned []*versionedRelease
+	for _, release := range releases {
+		version, err := util.ParseSemanticVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		versioned = append(versioned, &versionedRelease{release: release, version: version})
+	}
+	return versioned
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleasesGroupedByMinor() ([]*common.MinorReleaseLine, error) {
+	view, err := impl.getDerivedView(DerivedViewGroupedByMinor, func(releases []*common.Release) (interface{}, error) {
+		return groupReleasesByMinor(releases), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return view.([]*common.MinorReleaseLine), nil
+}
+
+func groupReleasesByMinor(releases []*common.Release) []*common.MinorReleaseLine {
+	versioned := parseVersionedReleases(releases)
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return versioned[i].version.Compare(versioned[j].version) > 0
+	})
+
+	var minorLines []*common.MinorReleaseLine
+	minorLineByName := make(map[string]*common.MinorReleaseLine)
+	for _, item := range versioned {
+		lineName := item.version.MinorLine()
+		minorLine, ok := minorLineByName[lineName]
+		if !ok {
+			minorLine = &common.MinorReleaseLine{
+				Line:             lineName,
+				PrerequisiteTags: []string{},
+			}
+			minorLineByName[lineName] = minorLine
+			minorLines = append(minorLines, minorLine)
+		}
+		// releases are visited newest first, so the first stable release seen is the latest patch
+		if len(minorLine.LatestPatch) == 0 && !item.version.IsPrerelease() && !item.release.Prerelease {
+			minorLine.LatestPatch = item.release.TagName
+		}
+		if item.release.Prerequisite {
+			minorLine.PrerequisiteTags = append(minorLine.PrerequisiteTags, item.release.TagName)
+		}
+		minorLine.Releases = append(minorLine.Releases, item.release)
+		minorLine.Count++
+	}
+	for _, minorLine := range minorLines {
+		if len(minorLine.LatestPatch) == 0 {
+			minorLine.LatestPatch = minorLine.Releases[0].TagName
+		}
+	}
+	return minorLines
+}
+
+// GetLatestPatch also takes the minor line, like "0.6"
+func (impl *ReleaseNoteServiceImpl) GetLatestPatch(version string) (*common.Release, error) {
+	parsedVersion, err := util.ParseSemanticVersion(version)
+	if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid version"}
+	}
+	line := parsedVersion.MinorLine()
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	var latest *versionedRelease
+	var availableLines []string
+	seenLines := make(map[string]bool)
+	for _, item := range parseVersionedReleases(releases) {
+		itemLine := item.version.MinorLine()
+		if !seenLines[itemLine] {
+			seenLines[itemLine] = true
+			availableLines = append(availableLines, itemLine)
+		}
+		if itemLine != line || item.version.IsPrerelease() || item.release.Prerelease || !isUpgradeTarget(item.release) {
+			continue
+		}
+		if latest == nil || item.version.Compare(latest.version) > 0 {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return nil, &util.ApiError{
+			HttpStatusCode:  http.StatusNotFound,
+			InternalMessage: fmt.Sprintf("no release found for line %s", line),
+			UserMessage:     fmt.Sprintf("no release found for line %s, available lines: %s", line, strings.Join(availableLines, ", ")),
+		}
+	}
+	return latest.release, nil
+}
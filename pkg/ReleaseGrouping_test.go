@@ -0,0 +1,34 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+func TestGroupReleasesByMinor(t *testing.T) {
+	releases := []*common.Release{
+		{TagName: "v0.7.0"},
+		{TagName: "v0.8.0-rc.1", Prerelease: true},
+		{TagName: "v0.6.19.1"},
+		{TagName: "v0.7.2-beta.1"},
+		{TagName: "v0.7.1", Prerequisite: true},
+		{TagName: "v0.6.19"},
+		{TagName: "nightly"},
+	}
+	lines := groupReleasesByMinor(releases)
+
+	var summary []string
+	for _, line := range lines {
+		summary = append(summary, fmt.Sprintf("%s:%s:%d:%s:%s", line.Line, line.LatestPatch, line.Count, getTestTags(line.Releases), strings.Join(line.PrerequisiteTags, ",")))
+	}
+	expected := []string{
+		"0.8:v0.8.0-rc.1:1:v0.8.0-rc.1:",
+		"0.7:v0.7.1:3:v0.7.2-beta.1,v0.7.1,v0.7.0:v0.7.1",
+		"0.6:v0.6.19.1:2:v0.6.19.1,v0.6.19:",
+	}
+	if strings.Join(summary, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected the lines %v, got %v", expected, summary)
+	}
+}
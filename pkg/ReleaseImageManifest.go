@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+)
+
+const ImagesMatcher = "<!--release-images-->"
+
+type releaseImageManifest struct {
+	Images     []*common.ReleaseImage   `json:"images"`
+	Signatures []*releaseImageSignature `json:"signatures"`
+}
+
+type releaseImageSignature struct {
+	Image string `json:"image"`
+	common.ImageSignature
+}
+
+func (impl *ReleaseNoteServiceImpl) getImages(releaseInfo *common.Release) {
+	releaseInfo.Images = nil
+	start := strings.Index(releaseInfo.Body, ImagesMatcher)
+	if start < 0 {
+		return
+	}
+	block := releaseInfo.Body[start+len(ImagesMatcher):]
+	if end := strings.Index(block, ImagesMatcher); end >= 0 {
+		block = block[:end]
+	}
+	block = strings.TrimSpace(block)
+	block = strings.TrimPrefix(strings.TrimPrefix(block, "```json"), "```")
+	block = strings.TrimSuffix(strings.TrimSpace(block), "```")
+	manifest := &releaseImageManifest{}
+	err := json.Unmarshal([]byte(block), manifest)
+	if err != nil {
+		impl.logger.Warnw("ignoring invalid images manifest in release body", "tagName", releaseInfo.TagName, "err", err)
+		return
+	}
+	signatureByImage := make(map[string]*common.ImageSignature)
+	for _, signature := range manifest.Signatures {
+		if signature != nil && len(signature.Image) > 0 {
+			imageSignature := signature.ImageSignature
+			signatureByImage[signature.Image] = &imageSignature
+		}
+	}
+	for _, image := range manifest.Images {
+		if image == nil || len(image.Image) == 0 {
+			continue
+		}
+		image.Signature = signatureByImage[image.Image]
+		image.Signed = image.Signature != nil
+		releaseInfo.Images = append(releaseInfo.Images, image)
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseImages(tag string) ([]*common.ReleaseImage, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.Images == nil {
+		return []*common.ReleaseImage{}, nil
+	}
+	return release.Images, nil
+}
@@ -0,0 +1,159 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"html"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const MaxCachedImageDimensions = 1024
+
+// maxImageHeaderBytes is enough for the decoders to read the dimensions
+const maxImageHeaderBytes = 64 * 1024
+
+var (
+	markdownImageRegex = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?(https?://[^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	htmlImageRegex     = regexp.MustCompile(`(?i)<img\s[^>]*>`)
+	htmlImageSrcRegex  = regexp.MustCompile(`(?i)\ssrc\s*=\s*["'](https?://[^"']+)["']`)
+	htmlImageSizeRegex = regexp.MustCompile(`(?i)\s(width|height)\s*=`)
+)
+
+type imageDimensions struct {
+	width  int
+	height int
+}
+
+// imageDimensionEntry with nil dimensions marks an image which couldn't be read at checkedAt
+type imageDimensionEntry struct {
+	dimensions *imageDimensions
+	checkedAt  time.Time
+}
+
+type imageDimensionCache struct {
+	mutex   sync.Mutex
+	entries map[string]*imageDimensionEntry
+	pending map[string]bool
+	fetches sync.WaitGroup
+}
+
+// annotateImageDimensions applies the dimensions already read and fetches the others in the background
+func (impl *ReleaseNoteServiceImpl) annotateImageDimensions(release *common.Release) {
+	if !impl.releaseBodyConfig.ImageDimensionsEnabled {
+		return
+	}
+	release.Body = replaceOutsideCodeFences(release.Body, impl.annotateImages)
+}
+
+func (impl *ReleaseNoteServiceImpl) annotateImages(body string) string {
+	body = markdownImageRegex.ReplaceAllStringFunc(body, func(match string) string {
+		groups := markdownImageRegex.FindStringSubmatch(match)
+		dimensions := impl.getImageDimensions(groups[2])
+		if dimensions == nil {
+			return match
+		}
+		return fmt.Sprintf(`<img src="%s" alt="%s" width="%d" height="%d">`, html.EscapeString(groups[2]), html.EscapeString(groups[1]), dimensions.width, dimensions.height)
+	})
+	return htmlImageRegex.ReplaceAllStringFunc(body, func(tag string) string {
+		if htmlImageSizeRegex.MatchString(tag) {
+			return tag
+		}
+		src := htmlImageSrcRegex.FindStringSubmatch(tag)
+		if src == nil {
+			return tag
+		}
+		dimensions := impl.getImageDimensions(html.UnescapeString(src[1]))
+		if dimensions == nil {
+			return tag
+		}
+		end := strings.TrimRight(strings.TrimSuffix(strings.TrimSuffix(tag, ">"), "/"), " ")
+		return fmt.Sprintf(`%s width="%d" height="%d"%s`, end, dimensions.width, dimensions.height, strings.Replace(tag[len(end):], " ", "", -1))
+	})
+}
+
+func replaceOutsideCodeFences(body string, replace func(text string) string) string {
+	var result, text strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(body, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		isFence := strings.HasPrefix(trimmedLine, markdownCodeFence) || strings.HasPrefix(trimmedLine, "~~~")
+		if !inFence && !isFence {
+			text.WriteString(line)
+			continue
+		}
+		if !inFence {
+			result.WriteString(replace(text.String()))
+			text.Reset()
+		}
+		if isFence {
+			inFence = !inFence
+		}
+		result.WriteString(line)
+	}
+	result.WriteString(replace(text.String()))
+	return result.String()
+}
+
+func (impl *ReleaseNoteServiceImpl) getImageDimensions(url string) *imageDimensions {
+	cache := &impl.imageDimensions
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	entry, ok := cache.entries[url]
+	if ok && (entry.dimensions != nil || time.Since(entry.checkedAt) < impl.releaseBodyConfig.ImageFailureTTL) {
+		return entry.dimensions
+	}
+	if cache.pending[url] || len(cache.pending) >= MaxCachedImageDimensions {
+		return nil
+	}
+	if cache.pending == nil {
+		cache.pending = make(map[string]bool)
+	}
+	cache.pending[url] = true
+	cache.fetches.Add(1)
+	go impl.readImageDimensions(url)
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) readImageDimensions(url string) {
+	cache := &impl.imageDimensions
+	defer cache.fetches.Done()
+	dimensions, err := impl.fetchImageDimensions(url)
+	if err != nil {
+		impl.logger.Warnw("error in reading image dimensions, leaving image unannotated", "url", url, "err", err)
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	delete(cache.pending, url)
+	if cache.entries == nil {
+		cache.entries = make(map[string]*imageDimensionEntry)
+	}
+	if _, ok := cache.entries[url]; ok || len(cache.entries) < MaxCachedImageDimensions {
+		cache.entries[url] = &imageDimensionEntry{dimensions: dimensions, checkedAt: time.Now()}
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) fetchImageDimensions(url string) (*imageDimensions, error) {
+	client := &http.Client{Timeout: impl.releaseBodyConfig.ImageFetchTimeout}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	config, _, err := image.DecodeConfig(io.LimitReader(response.Body, maxImageHeaderBytes))
+	if err != nil {
+		return nil, err
+	}
+	return &imageDimensions{width: config.Width, height: config.Height}, nil
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newImageServer(t *testing.T, requests *int32) *httptest.Server {
+	var content bytes.Buffer
+	err := png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path != "/dashboard.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content.Bytes())
+	}))
+}
+
+func TestAnnotateImageDimensionsInBackground(t *testing.T) {
+	var requests int32
+	server := newImageServer(t, &requests)
+	defer server.Close()
+	impl := newTestReleaseNoteService(t)
+	impl.releaseBodyConfig.ImageDimensionsEnabled = true
+	body := fmt.Sprintf("![dashboard](%s/dashboard.png)\n```\n![fenced](%s/dashboard.png)\n```\n", server.URL, server.URL)
+
+	release := &common.Release{Body: body}
+	impl.annotateImageDimensions(release)
+	if release.Body != body {
+		t.Errorf("expected the body to be left as it was until the image is read, got %q", release.Body)
+	}
+	impl.imageDimensions.fetches.Wait()
+
+	release = &common.Release{Body: body}
+	impl.annotateImageDimensions(release)
+	annotated := fmt.Sprintf(`<img src="%s/dashboard.png" alt="dashboard" width="640" height="480">`, server.URL)
+	if !strings.HasPrefix(release.Body, annotated) {
+		t.Errorf("expected the image to be annotated once read, got %q", release.Body)
+	}
+	if !strings.Contains(release.Body, fmt.Sprintf("```\n![fenced](%s/dashboard.png)\n```", server.URL)) {
+		t.Errorf("expected the fenced image to be left as it was, got %q", release.Body)
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Errorf("expected the image to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestAnnotateImageDimensionsRetriesFailuresAfterTTL(t *testing.T) {
+	var requests int32
+	server := newImageServer(t, &requests)
+	defer server.Close()
+	impl := newTestReleaseNoteService(t)
+	impl.releaseBodyConfig.ImageDimensionsEnabled = true
+	body := fmt.Sprintf("![missing](%s/missing.png)", server.URL)
+
+	impl.annotateImageDimensions(&common.Release{Body: body})
+	impl.imageDimensions.fetches.Wait()
+	impl.annotateImageDimensions(&common.Release{Body: body})
+	impl.imageDimensions.fetches.Wait()
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Errorf("expected the failure to be remembered within the ttl, got %d requests", requests)
+	}
+
+	impl.releaseBodyConfig.ImageFailureTTL = 0
+	impl.annotateImageDimensions(&common.Release{Body: body})
+	impl.imageDimensions.fetches.Wait()
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("expected the image to be fetched again after the ttl, got %d requests", requests)
+	}
+}
@@ -0,0 +1,136 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var knownIssuesHeadingRegex = regexp.MustCompile(`(?im)^(#{1,6})\s*known\s+issues?\s*:?\s*$`)
+
+var fixesHeadingRegex = regexp.MustCompile(`(?im)^(#{1,6})[^\n]*\bfix(es|ed)?\b[^\n]*$`)
+
+var issueReferenceRegex = regexp.MustCompile(`(?:https?://[^/\s]+/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+))|(?:\b([\w.-]+)/([\w.-]+))?#(\d+)\b`)
+
+var listItemRegex = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.*)$`)
+
+func (impl *ReleaseNoteServiceImpl) getKnownIssues(releaseInfo *common.Release) {
+	releaseInfo.KnownIssues = nil
+	for _, item := range getSectionListItems(releaseInfo.Body, knownIssuesHeadingRegex) {
+		releaseInfo.KnownIssues = append(releaseInfo.KnownIssues, &common.KnownIssue{
+			Id:          impl.getIssueReference(item),
+			Description: item,
+		})
+	}
+}
+
+func getSectionListItems(body string, headingRegex *regexp.Regexp) []string {
+	var items []string
+	for _, heading := range headingRegex.FindAllStringSubmatchIndex(body, -1) {
+		headingLevel := heading[3] - heading[2]
+		for _, line := range strings.Split(strings.ReplaceAll(body[heading[1]:], "\r\n", "\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if level := markdownHeadingLevel(line); level > 0 && level <= headingLevel {
+				break
+			}
+			if match := listItemRegex.FindStringSubmatch(line); match != nil && len(strings.TrimSpace(match[1])) > 0 {
+				items = append(items, strings.TrimSpace(match[1]))
+			}
+		}
+	}
+	return items
+}
+
+// getIssueReference writes issues of the release repo as #123 so that they match across releases
+func (impl *ReleaseNoteServiceImpl) getIssueReference(text string) string {
+	references := impl.getIssueReferences(text)
+	if len(references) == 0 {
+		return ""
+	}
+	return references[0]
+}
+
+func (impl *ReleaseNoteServiceImpl) getIssueReferences(text string) []string {
+	var references []string
+	for _, match := range issueReferenceRegex.FindAllStringSubmatch(text, -1) {
+		org, repo, number := match[1], match[2], match[3]
+		if len(number) == 0 {
+			org, repo, number = match[4], match[5], match[6]
+		}
+		references = append(references, impl.formatIssueReference(org, repo, number))
+	}
+	return references
+}
+
+func (impl *ReleaseNoteServiceImpl) formatIssueReference(org string, repo string, number string) string {
+	if len(org) == 0 {
+		return "#" + number
+	}
+	gitHubConfig := impl.client.GitHubConfig
+	if strings.EqualFold(org, gitHubConfig.GitHubOrg) && strings.EqualFold(repo, gitHubConfig.GitHubRepo) {
+		return "#" + number
+	}
+	return fmt.Sprintf("%s/%s#%s", strings.ToLower(org), strings.ToLower(repo), number)
+}
+
+// reconcileKnownIssues sets FixedIn to the oldest later release referencing the issue in its fixes
+func (impl *ReleaseNoteServiceImpl) reconcileKnownIssues(releases []*common.Release) []*common.Release {
+	versioned := parseVersionedReleases(releases)
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return versioned[i].version.Compare(versioned[j].version) < 0
+	})
+	fixes := make([]map[string]bool, len(versioned))
+	for i, item := range versioned {
+		fixes[i] = make(map[string]bool)
+		for _, fix := range getSectionListItems(item.release.Body, fixesHeadingRegex) {
+			for _, reference := range impl.getIssueReferences(fix) {
+				fixes[i][reference] = true
+			}
+		}
+	}
+	reconciledKnownIssues := make(map[*common.Release][]*common.KnownIssue)
+	for i, item := range versioned {
+		if len(item.release.KnownIssues) == 0 {
+			continue
+		}
+		knownIssues := make([]*common.KnownIssue, 0, len(item.release.KnownIssues))
+		for _, knownIssue := range item.release.KnownIssues {
+			reconciled := *knownIssue
+			reconciled.FixedIn = ""
+			for j := i + 1; j < len(versioned) && len(reconciled.Id) > 0; j++ {
+				if fixes[j][reconciled.Id] {
+					reconciled.FixedIn = versioned[j].release.TagName
+					break
+				}
+			}
+			knownIssues = append(knownIssues, &reconciled)
+		}
+		reconciledKnownIssues[item.release] = knownIssues
+	}
+	if len(reconciledKnownIssues) == 0 {
+		return releases
+	}
+	reconciledReleases := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		if knownIssues, ok := reconciledKnownIssues[release]; ok {
+			reconciledRelease := *release
+			reconciledRelease.KnownIssues = knownIssues
+			release = &reconciledRelease
+		}
+		reconciledReleases = append(reconciledReleases, release)
+	}
+	return reconciledReleases
+}
+
+func (impl *ReleaseNoteServiceImpl) GetReleaseKnownIssues(tag string) ([]*common.KnownIssue, error) {
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release.KnownIssues == nil {
+		return []*common.KnownIssue{}, nil
+	}
+	return release.KnownIssues, nil
+}
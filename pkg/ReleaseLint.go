@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+)
+
+type releaseLintRule func(release *common.Release) []string
+
+func (impl *ReleaseNoteServiceImpl) getReleaseLintRules() []releaseLintRule {
+	return []releaseLintRule{
+		lintMissingComponents,
+		lintFeatureFlags,
+		lintUpgradeDuration,
+		lintRollbackGuidance,
+		newBreakingChangeLintRule(impl.releaseLintConfig.BreakingChangeHeuristics),
+	}
+}
+
+func newBreakingChangeLintRule(heuristics []string) releaseLintRule {
+	return func(release *common.Release) []string {
+		if release.BreakingChange {
+			return nil
+		}
+		body := strings.ToLower(release.Body)
+		var findings []string
+		for _, heuristic := range heuristics {
+			heuristic = strings.ToLower(strings.TrimSpace(heuristic))
+			if len(heuristic) > 0 && strings.Contains(body, heuristic) {
+				findings = append(findings, fmt.Sprintf("body mentions %q but has no %s marker", heuristic, BreakingChangesMatcher))
+			}
+		}
+		return findings
+	}
+}
+
+func lintMissingComponents(release *common.Release) []string {
+	if len(release.Components) == 0 {
+		return []string{"no companion component versions found, add a " + ComponentsMatcher + " block"}
+	}
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) lintRelease(release *common.Release) []string {
+	var findings []string
+	for _, rule := range impl.getReleaseLintRules() {
+		findings = append(findings, rule(release)...)
+	}
+	return findings
+}
+
+// GetReleaseLintReport lints every cached release and reports the ones having findings
+func (impl *ReleaseNoteServiceImpl) GetReleaseLintReport() ([]*common.ReleaseLintResult, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	lintResults := make([]*common.ReleaseLintResult, 0)
+	for _, release := range releases {
+		findings := impl.lintRelease(release)
+		if len(findings) == 0 {
+			continue
+		}
+		lintResults = append(lintResults, &common.ReleaseLintResult{
+			TagName:  release.TagName,
+			Findings: findings,
+		})
+	}
+	return lintResults, nil
+}
+
+func ValidateReleaseBody(body string) error {
+	markerCount := strings.Count(body, PrerequisitesMatcher)
+	if markerCount == 0 {
+		return nil
+	}
+	if markerCount%2 != 0 {
+		return fmt.Errorf("unclosed prerequisite block, found %d %s markers", markerCount, PrerequisitesMatcher)
+	}
+	parts := strings.Split(body, PrerequisitesMatcher)
+	for index := 1; index < len(parts); index += 2 {
+		if len(strings.TrimSpace(parts[index])) == 0 {
+			return fmt.Errorf("empty prerequisite block between %s markers", PrerequisitesMatcher)
+		}
+	}
+	return nil
+}
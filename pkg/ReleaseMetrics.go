@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"net/http"
+	"time"
+)
+
+const (
+	ReleaseCacheHit         = "hit"
+	ReleaseCacheMiss        = "miss"
+	WebhookOutcomeProcessed = "processed"
+	// githubStatusError labels the github requests which got no response
+	githubStatusError = "error"
+)
+
+var githubLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type releaseMetrics struct {
+	cacheRequests      *prometheus.CounterVec
+	githubRequests     *prometheus.CounterVec
+	githubErrors       prometheus.Counter
+	githubDuration     *prometheus.HistogramVec
+	webhookEvents      *prometheus.CounterVec
+	moduleLintFailures prometheus.Gauge
+}
+
+func newReleaseMetrics() *releaseMetrics {
+	return &releaseMetrics{
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "central_api_release_cache_requests_total",
+			Help: "Reads of the cached releases by result, a miss fetches them from the source.",
+		}, []string{"result"}),
+		githubRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "central_api_github_requests_total",
+			Help: "Requests made to github by status class.",
+		}, []string{"status"}),
+		githubErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "central_api_github_errors_total",
+			Help: "Requests made to github which failed or got an error status.",
+		}),
+		githubDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "central_api_github_request_duration_seconds",
+			Help:    "Latency of the requests made to github.",
+			Buckets: githubLatencyBuckets,
+		}, []string{"status"}),
+		webhookEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "central_api_webhook_events_total",
+			Help: "Release webhook events by action and outcome.",
+		}, []string{"action", "outcome"}),
+		moduleLintFailures: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "central_api_module_lint_failures",
+			Help: "Modules of the catalog with findings when it was last validated against the releases.",
+		}),
+	}
+}
+
+func (metrics *releaseMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{metrics.cacheRequests, metrics.githubRequests, metrics.githubErrors, metrics.githubDuration, metrics.webhookEvents, metrics.moduleLintFailures}
+}
+
+func (metrics *releaseMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range metrics.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+func (metrics *releaseMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range metrics.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+func (metrics *releaseMetrics) getWebhookEventsProcessed() uint64 {
+	return uint64(sumCounter(metrics.webhookEvents, "outcome", WebhookOutcomeProcessed))
+}
+
+// sumCounter sums the series of the counter whose label has the value
+func sumCounter(counter *prometheus.CounterVec, labelName string, labelValue string) float64 {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		counter.Collect(metrics)
+		close(metrics)
+	}()
+	var sum float64
+	for metric := range metrics {
+		value := &dto.Metric{}
+		if metric.Write(value) != nil {
+			continue
+		}
+		for _, label := range value.GetLabel() {
+			if label.GetName() == labelName && label.GetValue() == labelValue {
+				sum += value.GetCounter().GetValue()
+			}
+		}
+	}
+	return sum
+}
+
+func (metrics *releaseMetrics) observeGithubRequest(duration time.Duration, resp *http.Response, err error) {
+	status := githubStatusError
+	if err == nil {
+		status = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	metrics.githubRequests.WithLabelValues(status).Inc()
+	metrics.githubDuration.WithLabelValues(status).Observe(duration.Seconds())
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		metrics.githubErrors.Inc()
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) GetMetricsCollector() prometheus.Collector {
+	return impl.metrics
+}
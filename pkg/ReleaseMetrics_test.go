@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/go-pg/pg"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReleaseMetricsCountCacheMissFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/devtron-labs/devtron/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "tag_name": "v0.7.0", "name": "v0.7.0", "body": "fixes", "published_at": "2024-03-18T06:37:10Z"}]`)
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_HOST", server.URL)
+	t.Setenv("GITHUB_ORG", "devtron-labs")
+	client, err := util.NewGitHubClient(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	impl := newTestReleaseNoteService(t)
+	impl.client = client
+	client.SetRequestObserver(impl.metrics.observeGithubRequest)
+	impl.releaseSource = &githubReleaseSource{service: impl}
+	// nothing listens on the port, storing the fetched releases fails without failing the read
+	db := pg.Connect(&pg.Options{Addr: "127.0.0.1:1"})
+	defer db.Close()
+	impl.releaseNoteRepository = &fakeReleaseNoteRepository{db: db}
+
+	releases, err := impl.GetReleases()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(releases) != 1 || releases[0].TagName != "v0.7.0" {
+		t.Fatalf("expected v0.7.0 fetched from github, got %d releases", len(releases))
+	}
+	if misses := sumCounter(impl.metrics.cacheRequests, "result", ReleaseCacheMiss); misses != 1 {
+		t.Errorf("expected a cache miss, got %v", misses)
+	}
+	if hits := sumCounter(impl.metrics.cacheRequests, "result", ReleaseCacheHit); hits != 0 {
+		t.Errorf("expected no cache hit, got %v", hits)
+	}
+	if requests := sumCounter(impl.metrics.githubRequests, "status", "2xx"); requests < 1 {
+		t.Errorf("expected the github request to be counted, got %v", requests)
+	}
+
+	registry := prometheus.NewRegistry()
+	err = registry.Register(impl.GetMetricsCollector())
+	if err != nil {
+		t.Fatalf("expected the collector to be registerable, got %v", err)
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	names := make(map[string]bool)
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	for _, name := range []string{"central_api_release_cache_requests_total", "central_api_github_requests_total", "central_api_github_request_duration_seconds"} {
+		if !names[name] {
+			t.Errorf("expected %s to be gathered, got %v", name, names)
+		}
+	}
+}
+
+func TestWebhookEventsProcessedDerivedFromCounter(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.metrics.webhookEvents.WithLabelValues("published", WebhookOutcomeProcessed).Inc()
+	impl.metrics.webhookEvents.WithLabelValues("edited", WebhookOutcomeProcessed).Inc()
+	impl.metrics.webhookEvents.WithLabelValues("created", WebhookOutcomeIgnored).Inc()
+
+	if processed := impl.GetOperationalStats().WebhookEventsProcessed; processed != 2 {
+		t.Errorf("expected the status to count the 2 processed events, got %d", processed)
+	}
+	if processed := impl.GetWebhookStatus().EventsProcessed; processed != 2 {
+		t.Errorf("expected the webhook status to count the 2 processed events, got %d", processed)
+	}
+}
@@ -0,0 +1,104 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"time"
+)
+
+type RawRelease struct {
+	GithubReleaseID int64
+	TagName         string
+	ReleaseName     string
+	Body            string
+	CreatedAt       time.Time
+	PublishedAt     time.Time
+	Prerelease      bool
+	DiscussionURL   *string
+	TargetCommitish string
+	Assets          []*RawReleaseAsset
+	// TagLinkPrefix is the releases page of the repo, the tag is appended to it
+	TagLinkPrefix string
+}
+
+// releaseNormalizationStep overwrites what it derives, steps run in order
+type releaseNormalizationStep func(release *common.Release)
+
+// normalizeOptions zero value normalizes the releases stored
+type normalizeOptions struct {
+	// preview skips the steps fetching from the network or filling the caches
+	preview bool
+}
+
+func (impl *ReleaseNoteServiceImpl) getReleaseNormalizationSteps(options normalizeOptions) []releaseNormalizationStep {
+	steps := []releaseNormalizationStep{
+		normalizeReleaseTimes,
+		impl.applyFallbackBody,
+		impl.getPrerequisiteContent,
+		impl.getPrerequisiteBlocks,
+		impl.getPrerequisiteId,
+		impl.getComponents,
+		impl.getImages,
+		impl.getFeatureFlags,
+		impl.getKnownIssues,
+		impl.getBreakingChange,
+		impl.getUpgradeDuration,
+		impl.getRollbackGuidance,
+	}
+	if !options.preview {
+		steps = append(steps, impl.annotateImageDimensions)
+	}
+	return append(steps, impl.truncateBody)
+}
+
+// NormalizeRelease is shared by every path receiving releases
+func (impl *ReleaseNoteServiceImpl) NormalizeRelease(raw *RawRelease) *common.Release {
+	return impl.normalizeRawRelease(raw, normalizeOptions{})
+}
+
+func (impl *ReleaseNoteServiceImpl) normalizeRawRelease(raw *RawRelease, options normalizeOptions) *common.Release {
+	release := &common.Release{
+		GithubReleaseID: raw.GithubReleaseID,
+		TagName:         raw.TagName,
+		ReleaseName:     impl.getReleaseDisplayName(raw.ReleaseName, raw.TagName),
+		CreatedAt:       raw.CreatedAt,
+		PublishedAt:     raw.PublishedAt,
+		Body:            raw.Body,
+		Prerelease:      raw.Prerelease,
+		DiscussionURL:   getDiscussionURL(raw.DiscussionURL),
+		TargetCommitish: raw.TargetCommitish,
+		Attestations:    getAttestations(raw.Assets),
+		Assets:          getReleaseAssets(raw.Assets),
+	}
+	if len(raw.TagName) > 0 {
+		release.TagLink = fmt.Sprintf("%s/%s", raw.TagLinkPrefix, raw.TagName)
+	}
+	impl.runNormalizationSteps(release, options)
+	return release
+}
+
+func (impl *ReleaseNoteServiceImpl) normalizeRelease(release *common.Release) {
+	impl.runNormalizationSteps(release, normalizeOptions{})
+}
+
+func (impl *ReleaseNoteServiceImpl) runNormalizationSteps(release *common.Release, options normalizeOptions) {
+	for _, step := range impl.getReleaseNormalizationSteps(options) {
+		step(release)
+	}
+}
+
+func normalizeReleaseTimes(release *common.Release) {
+	release.CreatedAt = normalizeReleaseTime(release.CreatedAt)
+	release.PublishedAt = normalizeReleaseTime(release.PublishedAt)
+	release.PublishedAtUnix = 0
+	if !release.PublishedAt.IsZero() {
+		release.PublishedAtUnix = release.PublishedAt.Unix()
+	}
+}
+
+func normalizeReleaseTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+	return t.UTC().Truncate(time.Second)
+}
@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"encoding/json"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestWireReleases normalizes releases whose times come with a zone and below second precision like the
+// webhook payloads and go-github timestamps do
+func newTestWireReleases(t *testing.T) []*common.Release {
+	impl := newTestReleaseNoteService(t)
+	ist := time.FixedZone("IST", 19800)
+	discussionURL := "https://github.com/devtron-labs/devtron/discussions/4500"
+	raws := []*RawRelease{
+		{
+			GithubReleaseID: 142,
+			TagName:         "v0.7.1",
+			ReleaseName:     "v0.7.1",
+			Body:            "## Bugs\n- fixed the rollout\n<!--upgrade-prerequisites-required-->\nupgrade the helm chart\n<!--upgrade-prerequisites-required-->",
+			CreatedAt:       time.Date(2024, 3, 18, 11, 55, 2, 123456789, ist),
+			PublishedAt:     time.Date(2024, 3, 18, 12, 7, 10, 987654321, ist),
+			DiscussionURL:   &discussionURL,
+		},
+		{
+			GithubReleaseID: 141,
+			TagName:         "v0.7.0",
+			ReleaseName:     "v0.7.0",
+			Body:            "## Enhancements\n- a new dashboard",
+			CreatedAt:       time.Date(2024, 2, 29, 23, 59, 59, 500000000, time.UTC),
+			PublishedAt:     time.Date(2024, 3, 1, 5, 30, 0, 0, ist),
+		},
+		{
+			GithubReleaseID: 140,
+			TagName:         "v0.6.0",
+			ReleaseName:     "v0.6.0",
+			Body:            "## Enhancements\n- release without publish time",
+		},
+	}
+	var releases []*common.Release
+	for _, raw := range raws {
+		raw.TagLinkPrefix = TagLink
+		releases = append(releases, impl.NormalizeRelease(raw))
+	}
+	return releases
+}
+
+func TestReleaseWireFormat(t *testing.T) {
+	releases := newTestWireReleases(t)
+	content, err := json.MarshalIndent(releases, "", "  ")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertGolden(t, filepath.Join("wire", "releases.golden.json"), append(content, '\n'))
+
+	impl := newTestReleaseNoteService(t, releases...)
+	partitioned, err := impl.GetReleasesPartitioned("v0.6.0", "v0.7.1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// the age is relative to now, it is the only field left out of the golden file
+	for _, release := range append(partitioned.ActionRequired, partitioned.Informational...) {
+		release.AgeDays = nil
+	}
+	content, err = json.MarshalIndent(partitioned, "", "  ")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	assertGolden(t, filepath.Join("wire", "partitioned.golden.json"), append(content, '\n'))
+}
+
+func TestNormalizeReleaseWithMismatchedNameAndTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseName string
+		tagName     string
+		displayName string
+		shownName   string
+		tagLink     string
+	}{
+		{name: "name without v", releaseName: "0.6.1", tagName: "v0.6.1", displayName: util.ReleaseDisplayNameFromName, shownName: "0.6.1", tagLink: TagLink + "/v0.6.1"},
+		{name: "tag without v", releaseName: "v0.6.1", tagName: "0.6.1", displayName: util.ReleaseDisplayNameFromName, shownName: "v0.6.1", tagLink: TagLink + "/0.6.1"},
+		{name: "shown by tag", releaseName: "0.6.1", tagName: "v0.6.1", displayName: util.ReleaseDisplayNameFromTag, shownName: "v0.6.1", tagLink: TagLink + "/v0.6.1"},
+		{name: "blank name", releaseName: " ", tagName: "v0.6.1", displayName: util.ReleaseDisplayNameFromName, shownName: "v0.6.1", tagLink: TagLink + "/v0.6.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			impl.client.GitHubConfig.ReleaseDisplayName = test.displayName
+			release := impl.NormalizeRelease(&RawRelease{TagName: test.tagName, ReleaseName: test.releaseName, TagLinkPrefix: TagLink})
+			if release.TagName != test.tagName || release.ReleaseName != test.shownName || release.TagLink != test.tagLink {
+				t.Errorf("expected tag %s shown as %s linking to %s, got %s shown as %s linking to %s",
+					test.tagName, test.shownName, test.tagLink, release.TagName, release.ReleaseName, release.TagLink)
+			}
+			for _, tag := range []string{"v0.6.1", "0.6.1"} {
+				if !isSameRelease(release, &common.Release{TagName: tag}) {
+					t.Errorf("expected %s to match the release tagged %s", tag, test.tagName)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckReleaseToleratesMissingVPrefix(t *testing.T) {
+	releases := newTestReleases()
+	releases[2].Yanked = true
+	impl := newTestReleaseNoteService(t, releases...)
+	for _, version := range []string{"v0.7.0", "0.7.0"} {
+		releaseCheck, err := impl.CheckRelease(version)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !releaseCheck.UpgradeAvailable || !releaseCheck.Yanked {
+			t.Errorf("expected %s to be found as the yanked v0.7.0 with an upgrade available, got %+v", version, releaseCheck)
+		}
+	}
+}
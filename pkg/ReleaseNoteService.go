@@ -6,12 +6,17 @@ import (
 	"fmt"
 	util "github.com/devtron-labs/central-api/client"
 	"github.com/devtron-labs/central-api/common"
+	logger2 "github.com/devtron-labs/central-api/internal/logger"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg/adminState"
 	"github.com/devtron-labs/central-api/pkg/releaseNote"
 	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
 	"github.com/go-pg/pg"
-	"github.com/google/go-github/github"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,10 +25,74 @@ import (
 type ReleaseNoteService interface {
 	GetModules() ([]*common.Module, error)
 	GetReleases() ([]*common.Release, error)
+	GetLatestRelease(includePrerelease bool) (*common.Release, error)
+	GetLatestReleaseForInstallation(installationId string) (*common.Release, error)
+	GenerateChangelogDocument(opts *common.ChangelogOptions) (string, error)
+	GetChangelogReleases(opts *common.ChangelogOptions) ([]*common.Release, error)
+	GetReleasesGroupedByMinor() ([]*common.MinorReleaseLine, error)
+	GetLatestPatch(version string) (*common.Release, error)
+	GetPrerequisiteSummary(fromVersion string, toVersion string, installedModules []string) (*common.PrerequisiteSummary, error)
+	GetLatestPrerequisite() (*common.Release, error)
+	GetOperationalStats() *common.OperationalStats
+	QueryReleases(q common.ReleaseQuery) (*common.ReleaseListPage, error)
+	DryRunWebhook(requestBodyBytes []byte) (*common.WebhookDryRunResult, error)
+	GetReleasesPartitioned(fromTag string, toTag string, installedModules []string) (*common.PartitionedReleases, error)
+	GetUnacknowledgedReleases(acknowledgedTag string) ([]*common.Release, error)
+	GetUnacknowledgedCount(acknowledgedTag string) (int, error)
+	GetSupportPolicy() (*common.SupportPolicy, error)
+	SetLineEolDate(line string, eolDate string) error
+	RemoveLineEolDate(line string) error
+	GetRecentReleasesByDays(days int) ([]*common.Release, error)
+	GetReleasesByTags(tags []string, installationId string) (*common.ReleaseBatchLookup, error)
+	GetReleaseComponents(tag string) (map[string]string, error)
+	GetReleaseImages(tag string) ([]*common.ReleaseImage, error)
+	GetReleaseAssets(tag string) ([]*common.ReleaseAsset, error)
+	GetReleaseList(filter *common.ReleaseListFilter) ([]*common.Release, error)
+	GetReleaseTags(channel string) (*common.ReleaseTagList, error)
+	GetReleaseKnownIssues(tag string) ([]*common.KnownIssue, error)
+	GetReleaseByTag(tag string) (*common.Release, error)
+	HandlePing(body []byte, formEncoded bool) (*common.WebhookPing, error)
+	GetWebhookStatus() *common.WebhookStatus
+	GetLastModified() time.Time
+	GetReleaseFeatureFlags(tag string) ([]*common.FeatureFlag, error)
+	GetFeatureFlagsSince(since string) ([]*common.FeatureFlag, error)
+	GetReleaseAttestations(tag string) ([]*common.ReleaseAttestation, error)
+	GetComponentVersions(component string) ([]*common.ComponentVersion, error)
+	GetReleaseLintReport() ([]*common.ReleaseLintResult, error)
+	DetectVersionGaps() ([]string, error)
+	GetModuleLintReport() ([]*common.ModuleLintResult, error)
+	GetMergedChangelog() ([]*common.Release, error)
+	GetRepositoryReadme() (string, error)
+	GetReleasesDelta(knownTag string, knownEtag string) (*common.ReleaseDelta, error)
+	GetReleaseFrequency() ([]*common.ReleaseFrequency, error)
+	CheckRelease(version string) (*common.ReleaseCheck, error)
+	CheckReleaseForInstallation(version string, installationId string) (*common.ReleaseCheck, error)
+	SetRolloutPercentage(tag string, percentage int) error
+	BlockRelease(tag string, reason string) error
+	UnblockRelease(tag string) error
+	GetReleaseRollouts() ([]*common.ReleaseRollout, error)
 	UpdateReleases(requestBodyBytes []byte) (bool, error)
+	UpdateReleasesWithAck(requestBodyBytes []byte) (*common.ReleaseWebhookAck, error)
 	GetModulesV2() ([]*common.Module, error)
+	GetModulesForProfile(profile string) ([]*common.Module, error)
+	ResolveCatalogProfile(profile string, signature string) (string, error)
 	GetModuleByName(name string) (*common.Module, error)
+	GetModuleById(id int) (*common.Module, error)
+	GetModulesByVersion(devtronVersion string) ([]*common.Module, error)
+	GetIntroducingRelease(moduleId int) (*common.Release, error)
+	GetTokenScopes() ([]string, error)
+	GetEffectiveConfig() (*common.EffectiveConfig, error)
+	GetReleaseSourceMeta() *common.ReleaseSourceMeta
+	RebuildModuleIndex()
+	GetModuleUninstallInfo(name string) (*common.ModuleUninstallInfo, error)
+	GetModuleRecentChanges(name string, limit int) ([]*common.ModuleChange, error)
+	ResolveModuleName(name string) (string, bool)
+	GetNewModulesBetween(fromVersion string, toVersion string) ([]*common.Module, error)
+	DisableImpact(name string) ([]*common.Module, error)
 	GetReleasesOnInitialisation()
+	Start(ctx context.Context)
+	Stop()
+	GetMetricsCollector() prometheus.Collector
 }
 
 type ReleaseNoteServiceImpl struct {
@@ -34,10 +103,46 @@ type ReleaseNoteServiceImpl struct {
 	releaseNoteRepository releaseNote.ReleaseNoteRepository
 	blobConfig            *util.BlobConfigVariables
 	blobStorageService    *blob_storage.BlobStorageServiceImpl
+	releaseCacheConfig    *util.ReleaseCacheConfig
+	supportPolicyConfig   *util.SupportPolicyConfig
+	releaseLintConfig     *util.ReleaseLintConfig
+	releaseSourceConfig   *util.ReleaseSourceConfig
+	releaseBodyConfig     *util.ReleaseBodyConfig
+	releaseChannelConfig  *util.ReleaseChannelConfig
+	releaseAssetConfig    *util.ReleaseAssetConfig
+	releaseSource         ReleaseSource
+	fallbackReleaseSource ReleaseSource
+	releaseProvenance     releaseProvenanceState
+	adminStateRepository  adminState.AdminStateRepository
+	adminStateMutex       sync.RWMutex
+	adminState            *adminState.AdminState
+	yankMutex             sync.RWMutex
+	yankedReleases        map[string]string
+	mergedChangelog       mergedChangelogCache
+	readme                readmeCache
+	webhookRateLimiter    *webhookRateLimiter
+	moduleIndex           moduleIndexCache
+	catalogModules        []*common.Module
+	catalogProfiles       map[string]*common.CatalogProfile
+	derivedViews          derivedViewCache
+	refresher             releaseRefresherState
+	tokenScopes           tokenScopesCache
+	imageDimensions       imageDimensionCache
+	lastSync              syncState
+	metrics               *releaseMetrics
+	webhookStatus         webhookStatusState
+	releasesGeneration    releasesGenerationState
+	tagLookupMisses       tagLookupMissCache
+	lastModified          lastModifiedState
 }
 
 func NewReleaseNoteServiceImpl(logger *zap.SugaredLogger, client *util.GitHubClient,
-	moduleConfig *util.ModuleConfig, blobConfig *util.BlobConfigVariables, blobStorageService *blob_storage.BlobStorageServiceImpl) (*ReleaseNoteServiceImpl, error) {
+	moduleConfig *util.ModuleConfig, blobConfig *util.BlobConfigVariables, blobStorageService *blob_storage.BlobStorageServiceImpl,
+	releaseCacheConfig *util.ReleaseCacheConfig, supportPolicyConfig *util.SupportPolicyConfig,
+	adminStateRepository adminState.AdminStateRepository, releaseLintConfig *util.ReleaseLintConfig,
+	releaseSourceConfig *util.ReleaseSourceConfig, releaseBodyConfig *util.ReleaseBodyConfig,
+	releaseChannelConfig *util.ReleaseChannelConfig, releaseAssetConfig *util.ReleaseAssetConfig) (*ReleaseNoteServiceImpl, error) {
+	logger = logger.Named(logger2.ReleaseLoggerName)
 	var releaseNoteRepository releaseNote.ReleaseNoteRepository
 	var err error
 	if !blobConfig.CloudConfigured {
@@ -53,6 +158,56 @@ func NewReleaseNoteServiceImpl(logger *zap.SugaredLogger, client *util.GitHubCli
 		releaseNoteRepository: releaseNoteRepository,
 		blobConfig:            blobConfig,
 		blobStorageService:    blobStorageService,
+		releaseCacheConfig:    releaseCacheConfig,
+		supportPolicyConfig:   supportPolicyConfig,
+		releaseLintConfig:     releaseLintConfig,
+		releaseSourceConfig:   releaseSourceConfig,
+		releaseBodyConfig:     releaseBodyConfig,
+		releaseChannelConfig:  releaseChannelConfig,
+		releaseAssetConfig:    releaseAssetConfig,
+		adminStateRepository:  adminStateRepository,
+		webhookRateLimiter:    newWebhookRateLimiter(client.GitHubConfig.GitHubWebhookRateLimit, client.GitHubConfig.GitHubWebhookRateBurst),
+	}
+	serviceImpl.metrics = newReleaseMetrics()
+	client.SetRequestObserver(serviceImpl.metrics.observeGithubRequest)
+	serviceImpl.releaseSource, err = newReleaseSource(logger, releaseSourceConfig, serviceImpl)
+	if err != nil {
+		logger.Errorw("error in creating release source", "err", err)
+		return nil, err
+	}
+	serviceImpl.fallbackReleaseSource, err = newFallbackReleaseSource(logger, releaseSourceConfig, serviceImpl)
+	if err != nil {
+		logger.Errorw("error in creating fallback release source", "err", err)
+		return nil, err
+	}
+	err = serviceImpl.loadAdminState()
+	if err != nil {
+		return nil, err
+	}
+	serviceImpl.markContentModified()
+	err = serviceImpl.loadModuleCatalog()
+	if err != nil {
+		logger.Errorw("error in loading module catalog file", "catalogFile", moduleConfig.ModuleConfig.CatalogFile, "err", err)
+		return nil, err
+	}
+	err = serviceImpl.validateCatalogProfiles()
+	if err != nil {
+		logger.Errorw("invalid module catalog profiles", "err", err)
+		return nil, err
+	}
+	modules, err := serviceImpl.GetModulesV2()
+	if err != nil {
+		return nil, err
+	}
+	err = validateModuleCatalog(modules)
+	if err != nil {
+		logger.Errorw("invalid module catalog", "err", err)
+		return nil, err
+	}
+	// min versions partly come from the environment, so a conflict is reported without failing the startup
+	err = ValidateModuleDependencyVersions(modules)
+	if err != nil {
+		logger.Warnw("module catalog validation failed", "err", err)
 	}
 	// Async Call for getting releases from Github
 	serviceImpl.logger.Infow("getting release from github")
@@ -62,222 +217,505 @@ func NewReleaseNoteServiceImpl(logger *zap.SugaredLogger, client *util.GitHubCli
 
 const ActionPublished = "published"
 const ActionEdited = "edited"
+
+// ActionReleased and ActionPrereleased are sent when the pre-release flag of a release is changed
+const ActionReleased = "released"
+const ActionPrereleased = "prereleased"
+
+// ActionDeleted and ActionUnpublished take a release off github, it is removed from the cached releases
+const ActionDeleted = "deleted"
+const ActionUnpublished = "unpublished"
 const EventTypeRelease = "release"
 const TimeFormatLayout = "2006-01-02T15:04:05Z"
 const TagLink = "https://github.com/devtron-labs/devtron/releases/tag"
 const PrerequisitesMatcher = "<!--upgrade-prerequisites-required-->"
+const BreakingChangesMatcher = "<!--breaking-changes-->"
 const CACHE_KEY = "latest"
 const LATEST_FILENAME = CACHE_KEY + ".txt"                      // TODO:Will Remove this before merging
 const BLOB_LATEST_RELEASE_FILE_NAME = "/tmp/" + LATEST_FILENAME // TODO:Have to change it to "/latest.txt"
 
 var releaseCache = make(map[string][]*common.Release)
 
+// UpdateReleases applies a release webhook event and tells whether the cached releases were updated
 func (impl *ReleaseNoteServiceImpl) UpdateReleases(requestBodyBytes []byte) (bool, error) {
+	ack, err := impl.UpdateReleasesWithAck(requestBodyBytes)
+	return ack.Processed, err
+}
+
+// UpdateReleasesWithAck acks with the action and tag so that the delivery can be correlated on github
+func (impl *ReleaseNoteServiceImpl) UpdateReleasesWithAck(requestBodyBytes []byte) (*common.ReleaseWebhookAck, error) {
+	ack := &common.ReleaseWebhookAck{}
+	if !impl.webhookRateLimiter.allow(time.Now()) {
+		impl.logger.Warnw("webhook event throttled, rate limit exceeded", "ratePerSec", impl.client.GitHubConfig.GitHubWebhookRateLimit)
+		return ack, &util2.ApiError{HttpStatusCode: http.StatusTooManyRequests, InternalMessage: "webhook rate limit exceeded", UserMessage: "webhook event throttled"}
+	}
+	releaseInfo, _, err := impl.parseWebhookRelease(requestBodyBytes, ack, normalizeOptions{})
+	if err != nil {
+		return ack, err
+	}
+	if releaseInfo == nil {
+		impl.metrics.webhookEvents.WithLabelValues(ack.Action, WebhookOutcomeIgnored).Inc()
+		return ack, nil
+	}
+
+	if !isReleaseRemovalAction(ack.Action) {
+		if findings := impl.lintRelease(releaseInfo); len(findings) > 0 {
+			impl.logger.Warnw("release lint findings on webhook", "tagName", releaseInfo.TagName, "findings", findings)
+		}
+	}
+	// the event is applied on the releases stored when it is persisted, so that an update persisted meanwhile is kept
+	found := true
+	applyEvent := func(releaseNotes []*common.Release) []*common.Release {
+		var releaseList []*common.Release
+		if isReleaseRemovalAction(ack.Action) {
+			releaseList, found = removeWebhookRelease(releaseNotes, releaseInfo)
+		} else {
+			releaseList, _ = mergeWebhookRelease(releaseNotes, releaseInfo)
+		}
+		// compare urls are set on copies, the cached releases may be being served meanwhile
+		releaseList = copyReleases(releaseList)
+		impl.applyCompareURLs(releaseList)
+		return releaseList
+	}
+	if impl.blobConfig.CloudConfigured {
+		impl.mutex.Lock()
+		releaseNotes := releaseCache[CACHE_KEY]
+		releaseList := applyEvent(releaseNotes)
+		if found {
+			impl.setCachedReleases(releaseList)
+		}
+		impl.mutex.Unlock()
+		if !found {
+			impl.logger.Infow("release of webhook event not found in cache, nothing removed", "action", ack.Action, "tagName", releaseInfo.TagName)
+			impl.metrics.webhookEvents.WithLabelValues(ack.Action, WebhookOutcomeIgnored).Inc()
+			return ack, nil
+		}
+		err = impl.persistWithRetry("blob", func() error {
+			return impl.publishReleasesGeneration(releaseList)
+		})
+		if err != nil {
+			if impl.releaseCacheConfig.PersistRevertOnFailure {
+				impl.logger.Warnw("reverting cached release, webhook update not persisted", "tagName", releaseInfo.TagName)
+				impl.revertCachedRelease(releaseNotes, releaseInfo)
+			}
+			return ack, err
+		}
+	} else {
+		// the db write is transactional, the lock is only held during an attempt
+		err = impl.persistWithRetry("db", func() error {
+			impl.mutex.Lock()
+			defer impl.mutex.Unlock()
+			releaseNotes, err := impl.getStoredReleases()
+			if err != nil {
+				return err
+			}
+			releaseList := applyEvent(releaseNotes)
+			if !found {
+				return nil
+			}
+			return impl.updateReleaseNotesInDb(releaseList, true)
+		})
+		if err != nil {
+			return ack, err
+		}
+		if !found {
+			impl.logger.Infow("release of webhook event not found in cache, nothing removed", "action", ack.Action, "tagName", releaseInfo.TagName)
+			impl.metrics.webhookEvents.WithLabelValues(ack.Action, WebhookOutcomeIgnored).Inc()
+			return ack, nil
+		}
+	}
+	ack.Processed = true
+	impl.metrics.webhookEvents.WithLabelValues(ack.Action, WebhookOutcomeProcessed).Inc()
+	// the released tags changed, a module may now refer to a tag which was deleted or just published
+	impl.logModuleLintReport()
+	return ack, nil
+}
+
+// revertCachedRelease leaves the other cached releases as they may have been updated meanwhile
+func (impl *ReleaseNoteServiceImpl) revertCachedRelease(previousReleases []*common.Release, releaseInfo *common.Release) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	releaseList, _ := removeWebhookRelease(releaseCache[CACHE_KEY], releaseInfo)
+	for _, release := range previousReleases {
+		if isSameRelease(release, releaseInfo) {
+			releaseList = append(releaseList, release)
+		}
+	}
+	impl.setCachedReleases(releaseList)
+}
+
+// isReleaseRemovalAction tells the actions taking a release off the published releases
+func isReleaseRemovalAction(action string) bool {
+	return action == ActionDeleted || action == ActionUnpublished
+}
+
+func removeWebhookRelease(releaseNotes []*common.Release, releaseInfo *common.Release) ([]*common.Release, bool) {
+	releaseList := make([]*common.Release, 0, len(releaseNotes))
+	removed := false
+	for _, release := range releaseNotes {
+		if isSameRelease(release, releaseInfo) {
+			removed = true
+			continue
+		}
+		releaseList = append(releaseList, release)
+	}
+	return releaseList, removed
+}
+
+// parseWebhookRelease returns nil for actions which don't change releases
+func (impl *ReleaseNoteServiceImpl) parseWebhookRelease(requestBodyBytes []byte, ack *common.ReleaseWebhookAck, options normalizeOptions) (*common.Release, []string, error) {
 	data := make(map[string]interface{})
 	err := json.Unmarshal(requestBodyBytes, &data)
 	if err != nil {
 		impl.logger.Errorw("unmarshal error", "err", err)
-		return false, err
-	}
-	action := data["action"].(string)
-	if action != ActionPublished && action != ActionEdited {
-		impl.logger.Warnw("handling only published and edited action, ignored other actions", "action", action)
-		return false, nil
-	}
-	releaseData := data["release"].(map[string]interface{})
-	releaseName := releaseData["name"].(string)
-	tagName := releaseData["tag_name"].(string)
-	createdAtString := releaseData["created_at"].(string)
-	createdAt, error := time.Parse(TimeFormatLayout, createdAtString)
-	if error != nil {
-		impl.logger.Errorw("error on time parsing, ignored this key", "err", error)
+		return nil, nil, newInvalidWebhookPayloadError(fmt.Sprintf("webhook payload is not a json object, %v", err))
+	}
+	action, ok := data["action"].(string)
+	if !ok {
+		return nil, nil, newInvalidWebhookPayloadError(fmt.Sprintf("action missing or not a string in webhook payload, got %T", data["action"]))
+	}
+	ack.Action = action
+	if action != ActionPublished && action != ActionEdited && action != ActionReleased && action != ActionPrereleased && !isReleaseRemovalAction(action) {
+		impl.logger.Warnw("handling only published, edited, released, prereleased, deleted and unpublished action, ignored other actions", "action", action)
+		return nil, nil, nil
+	}
+	releaseData, ok := data["release"].(map[string]interface{})
+	if !ok {
+		return nil, nil, newInvalidWebhookPayloadError(fmt.Sprintf("release missing or not an object in webhook payload, got %T", data["release"]))
+	}
+	tagName, ok := releaseData["tag_name"].(string)
+	if !ok {
+		return nil, nil, newInvalidWebhookPayloadError(fmt.Sprintf("release.tag_name missing or not a string in webhook payload, got %T", releaseData["tag_name"]))
+	}
+	ack.TagName = tagName
+	// github sends null for a release without name or body and for the publish time of a draft
+	releaseName, err := getOptionalWebhookString(releaseData, "name")
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := getOptionalWebhookString(releaseData, "body")
+	if err != nil {
+		return nil, nil, err
+	}
+	createdAtString, err := getOptionalWebhookString(releaseData, "created_at")
+	if err != nil {
+		return nil, nil, err
+	}
+	publishedAtString, err := getOptionalWebhookString(releaseData, "published_at")
+	if err != nil {
+		return nil, nil, err
+	}
+	createdAt, parseErr := time.Parse(TimeFormatLayout, createdAtString)
+	if parseErr != nil {
+		impl.logger.Errorw("error on time parsing, ignored this key", "err", parseErr)
 		//return false, nil
 	}
-	publishedAtString := releaseData["published_at"].(string)
-	publishedAt, error := time.Parse(TimeFormatLayout, publishedAtString)
-	if error != nil {
-		impl.logger.Errorw("error on time parsing, ignored this key", "err", error)
+	publishedAt, parseErr := time.Parse(TimeFormatLayout, publishedAtString)
+	if parseErr != nil {
+		impl.logger.Errorw("error on time parsing, ignored this key", "err", parseErr)
 		//return false, nil
 	}
-	body := releaseData["body"].(string)
-	releaseInfo := &common.Release{
-		TagName:     tagName,
-		ReleaseName: releaseName,
-		Body:        body,
-		CreatedAt:   createdAt,
-		PublishedAt: publishedAt,
-		TagLink:     fmt.Sprintf("%s/%s", TagLink, tagName),
+	var warnings []string
+	prerelease, _ := releaseData["prerelease"].(bool)
+	discussionURL, _ := releaseData["discussion_url"].(string)
+	// the body of a release being removed is not stored, it is not worth rejecting the removal for
+	if err := ValidateReleaseBody(body); err != nil && !isReleaseRemovalAction(action) {
+		if impl.releaseLintConfig.StrictWebhook {
+			impl.logger.Errorw("rejected webhook event with malformed release body", "tagName", tagName, "err", err)
+			return nil, nil, &util2.ApiError{HttpStatusCode: http.StatusUnprocessableEntity, InternalMessage: err.Error(), UserMessage: "malformed release body"}
+		}
+		impl.logger.Warnw("accepted webhook event with malformed release body", "tagName", tagName, "err", err)
+		warnings = append(warnings, fmt.Sprintf("malformed release body, %v", err))
 	}
-	impl.getPrerequisiteContent(releaseInfo)
+	// the commit sha is not part of the payload, it is resolved on the next sync
+	targetCommitish, _ := releaseData["target_commitish"].(string)
+	// json numbers decode as float64, github release ids are far below its exact integer range
+	githubReleaseId, _ := releaseData["id"].(float64)
+	releaseInfo := impl.normalizeRawRelease(&RawRelease{
+		GithubReleaseID: int64(githubReleaseId),
+		TagName:         tagName,
+		ReleaseName:     releaseName,
+		Body:            body,
+		CreatedAt:       createdAt,
+		PublishedAt:     publishedAt,
+		Prerelease:      prerelease,
+		DiscussionURL:   &discussionURL,
+		TargetCommitish: targetCommitish,
+		Assets:          getWebhookReleaseAssets(releaseData),
+		TagLinkPrefix:   TagLink,
+	}, options)
+	impl.applyYankedReleases([]*common.Release{releaseInfo})
+	return releaseInfo, warnings, nil
+}
 
-	//updating cache, fetch existing object and append new item
-	var releaseList []*common.Release
-	var releaseNotes []*common.Release
+func newInvalidWebhookPayloadError(internalMessage string) error {
+	return &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: internalMessage, UserMessage: "invalid webhook payload"}
+}
+
+// getOptionalWebhookString is empty for a missing or null value and fails on another type
+func getOptionalWebhookString(releaseData map[string]interface{}, key string) (string, error) {
+	value, ok := releaseData[key]
+	if !ok || value == nil {
+		return "", nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", newInvalidWebhookPayloadError(fmt.Sprintf("release.%s in webhook payload should be a string, got %T", key, value))
+	}
+	return text, nil
+}
+
+// isSameRelease matches on the github id, which survives tag edits, or else on the tag
+func isSameRelease(stored *common.Release, releaseInfo *common.Release) bool {
+	if stored.GithubReleaseID != 0 && stored.GithubReleaseID == releaseInfo.GithubReleaseID {
+		return true
+	}
+	// tag is mandatory while drafting a new release
+	return util2.IsSameVersionTag(stored.TagName, releaseInfo.TagName)
+}
+
+// getStoredReleases returns the releases currently kept in the blob cache or the db
+func (impl *ReleaseNoteServiceImpl) getStoredReleases() ([]*common.Release, error) {
 	if impl.blobConfig.CloudConfigured {
-		releaseNotes = releaseCache[CACHE_KEY]
-	} else {
-		releaseNoteObj, err := impl.getActiveReleaseNote()
-		if err != nil {
-			impl.logger.Errorw("error in getting release notes from DB", "err", err)
-			return false, err
-		}
-		releaseNotes = releaseNoteObj.ReleaseNote
+		return releaseCache[CACHE_KEY], nil
+	}
+	releaseNoteObj, err := impl.getActiveReleaseNote()
+	if err != nil {
+		impl.logger.Errorw("error in getting release notes from DB", "err", err)
+		return nil, err
 	}
-	//releaseList = append(releaseList, releaseInfo)
+	return releaseNoteObj.ReleaseNote, nil
+}
 
+// mergeWebhookRelease copies a replaced release before updating it
+func mergeWebhookRelease(releaseNotes []*common.Release, releaseInfo *common.Release) ([]*common.Release, bool) {
+	var releaseList []*common.Release
 	if len(releaseNotes) > 0 {
 		releaseList = append(releaseList, releaseNotes...)
 	}
 
 	isNew := true
-	for _, release := range releaseList {
-		// tag is mandatory while drafting a new release
-		if release.TagName == releaseInfo.TagName {
+	for i := range releaseList {
+		if isSameRelease(releaseList[i], releaseInfo) {
+			// the cached release is copied so that it is left untouched if the update can't be persisted
+			updatedRelease := *releaseList[i]
+			release := &updatedRelease
+			releaseList[i] = release
+			// the tag may have been edited, the release is kept as one under its new tag
+			release.GithubReleaseID = releaseInfo.GithubReleaseID
+			release.TagName = releaseInfo.TagName
+			release.TagLink = releaseInfo.TagLink
 			release.ReleaseName = releaseInfo.ReleaseName
 			release.Body = releaseInfo.Body
+			release.Placeholder = releaseInfo.Placeholder
+			release.BodyTruncated = releaseInfo.BodyTruncated
+			release.Prerequisite = releaseInfo.Prerequisite
+			release.PrerequisiteMessage = releaseInfo.PrerequisiteMessage
+			release.PrerequisiteMessages = releaseInfo.PrerequisiteMessages
+			release.PrerequisiteId = releaseInfo.PrerequisiteId
+			release.PrerequisiteBlocks = releaseInfo.PrerequisiteBlocks
+			// a promoted pre-release moves to the stable channel, an edit without the flag change keeps it as is
+			release.Prerelease = releaseInfo.Prerelease
+			if !releaseInfo.PublishedAt.IsZero() {
+				release.PublishedAt = releaseInfo.PublishedAt
+				release.PublishedAtUnix = releaseInfo.PublishedAtUnix
+			}
+			release.Components = releaseInfo.Components
+			release.Images = releaseInfo.Images
+			release.FeatureFlags = releaseInfo.FeatureFlags
+			release.Attestations = releaseInfo.Attestations
+			release.Assets = releaseInfo.Assets
+			release.BreakingChange = releaseInfo.BreakingChange
+			release.EstimatedUpgradeDuration = releaseInfo.EstimatedUpgradeDuration
+			release.KnownIssues = releaseInfo.KnownIssues
+			release.RollbackSafe = releaseInfo.RollbackSafe
+			release.RollbackNotes = releaseInfo.RollbackNotes
+			release.DiscussionURL = releaseInfo.DiscussionURL
+			release.TargetCommitish = releaseInfo.TargetCommitish
 			isNew = false
 		}
 	}
 	if isNew {
 		releaseList = append([]*common.Release{releaseInfo}, releaseList...)
 	}
-	if impl.blobConfig.CloudConfigured {
-		releaseCache[CACHE_KEY] = releaseList
-		return impl.updateTagToBlobStorage(releaseInfo)
-	} else {
-		impl.mutex.Lock()
-		defer impl.mutex.Unlock()
-		impl.updateReleaseNotesInDb(releaseList, true)
-		return true, nil
-	}
-	return true, err
+	return releaseList, isNew
 }
 
-func (impl *ReleaseNoteServiceImpl) updateTagToBlobStorage(releaseInfo *common.Release) (bool, error) {
-	artifactUploaded := false
-	err := impl.createFileAndUpdateDataForBlob(releaseInfo.TagName)
+// releasesGenerationState is the content hash of the releases this instance last published or found on blob
+type releasesGenerationState struct {
+	mutex      sync.Mutex
+	generation string
+}
+
+func (impl *ReleaseNoteServiceImpl) getReleasesGeneration() string {
+	impl.releasesGeneration.mutex.Lock()
+	defer impl.releasesGeneration.mutex.Unlock()
+	return impl.releasesGeneration.generation
+}
+
+func (impl *ReleaseNoteServiceImpl) setReleasesGeneration(generation string) {
+	impl.releasesGeneration.mutex.Lock()
+	defer impl.releasesGeneration.mutex.Unlock()
+	impl.releasesGeneration.generation = generation
+}
+
+// publishReleasesGeneration lets the other instances notice that their cache is behind
+func (impl *ReleaseNoteServiceImpl) publishReleasesGeneration(releases []*common.Release) error {
+	generation, err := util2.GetContentHash(releases)
 	if err != nil {
-		return artifactUploaded, err
+		impl.logger.Errorw("error in computing releases generation", "err", err)
+		return err
+	}
+	err = impl.createFileAndUpdateDataForBlob(generation)
+	if err != nil {
+		return err
 	}
 	request := impl.createBlobStorageRequest(impl.blobConfig.BlobStorageType, BLOB_LATEST_RELEASE_FILE_NAME, LATEST_FILENAME)
 	err = impl.blobStorageService.UploadToBlobWithSession(request)
 	if err != nil {
-		return artifactUploaded, err
+		return err
 	}
-	artifactUploaded = true
-	return artifactUploaded, err
+	impl.setReleasesGeneration(generation)
+	return nil
 }
 
-func (impl *ReleaseNoteServiceImpl) createFileAndUpdateDataForBlob(tagName string) error {
+func (impl *ReleaseNoteServiceImpl) createFileAndUpdateDataForBlob(generation string) error {
 	file, err := os.Create(BLOB_LATEST_RELEASE_FILE_NAME)
 	defer file.Close()
 	if err != nil {
 		impl.logger.Errorw("error in creating file", "err", err)
 		return err
 	}
-	data := []byte(tagName)
+	data := []byte(generation)
 	_, err = file.Write(data)
 
 	if err != nil {
-		impl.logger.Errorw("error in writing file", "tagName", tagName, "err", err)
+		impl.logger.Errorw("error in writing file", "generation", generation, "err", err)
 		return err
 	}
 	return err
 }
 
-func (impl *ReleaseNoteServiceImpl) GetReleasesFromGithub() ([]*common.Release, bool) {
-	operationComplete := false
+func (impl *ReleaseNoteServiceImpl) GetReleasesFromGithub(ctx context.Context) ([]*common.Release, bool) {
+	releases, err := impl.fetchReleasesFromGithub(ctx)
+	return releases, err == nil
+}
+
+func (impl *ReleaseNoteServiceImpl) fetchReleasesFromGithub(ctx context.Context) ([]*common.Release, error) {
+	releases, err := impl.fetchGithubRepoReleases(ctx, impl.client.GitHubConfig.GitHubOrg, impl.client.GitHubConfig.GitHubRepo, TagLink)
+	if err == nil && impl.client.GitHubConfig.GitHubResolveCommitSha {
+		impl.resolveCommitShas(ctx, impl.client.GitHubConfig.GitHubRepo, releases)
+	}
+	return releases, err
+}
+
+func (impl *ReleaseNoteServiceImpl) getReleasesFromGithubRepo(ctx context.Context, repo string, tagLinkPrefix string) ([]*common.Release, bool) {
+	releasesDto, err := impl.fetchGithubRepoReleases(ctx, impl.client.GitHubConfig.GitHubOrg, repo, tagLinkPrefix)
+	return releasesDto, err == nil
+}
+
+// fetchGithubRepoReleases fetches and normalizes the releases of a repo, the error tells a missing repo apart through isNotFoundError
+func (impl *ReleaseNoteServiceImpl) fetchGithubRepoReleases(ctx context.Context, org string, repo string, tagLinkPrefix string) ([]*common.Release, error) {
 	var releasesDto []*common.Release
-	releases, _, err := impl.client.GitHubClient.Repositories.ListReleases(context.Background(), impl.client.GitHubConfig.GitHubOrg, impl.client.GitHubConfig.GitHubRepo, &github.ListOptions{})
+	releases, err := impl.listGithubReleases(ctx, org, repo)
 	if err != nil {
-		responseErr, ok := err.(*github.ErrorResponse)
-		if !ok || responseErr.Response.StatusCode != 404 {
+		if !isNotFoundError(err) {
 			impl.logger.Errorw("error in fetching releases from github", "err", err, "config", "config")
 			//todo - any specific message
-			return releasesDto, operationComplete
+			return releasesDto, err
 		} else {
 			impl.logger.Errorw("error in fetching releases from github", "err", err)
-			return releasesDto, operationComplete
+			return releasesDto, err
 		}
 	}
-	if err == nil {
-		operationComplete = true
-	}
 
 	for _, item := range releases {
 		if item == nil {
 			impl.logger.Warnw("error while getting release from repository", "err", err)
 			continue
 		}
-		var tagName, releaseName, body, tagLink string
-		var createdAt, publishedAt time.Time
-		if item.TagName != nil {
-			tagName = *item.TagName
-		}
-		if item.Name != nil {
-			releaseName = *item.Name
-		}
-		if item.Body != nil {
-			body = *item.Body
-		}
-		if item.TagName != nil {
-			tagLink = fmt.Sprintf("%s/%s", TagLink, *item.TagName)
-		}
-		if item.CreatedAt != nil {
-			createdAt = item.CreatedAt.Time
-		}
-		if item.PublishedAt != nil {
-			publishedAt = item.PublishedAt.Time
-		}
-		dto := &common.Release{
-			TagName:     tagName,
-			ReleaseName: releaseName,
-			CreatedAt:   createdAt,
-			PublishedAt: publishedAt,
-			Body:        body,
-			TagLink:     tagLink,
-		}
-		impl.getPrerequisiteContent(dto)
+		dto := impl.NormalizeRelease(getRawGithubRelease(item, tagLinkPrefix))
 		releasesDto = append(releasesDto, dto)
 	}
 
-	return releasesDto, operationComplete
+	return releasesDto, nil
+}
+
+// getReleaseDisplayName returns the label shown for a release, the name is never used to derive versions or links
+func (impl *ReleaseNoteServiceImpl) getReleaseDisplayName(releaseName string, tagName string) string {
+	if impl.client.GitHubConfig.ReleaseDisplayName == util.ReleaseDisplayNameFromTag || len(strings.TrimSpace(releaseName)) == 0 {
+		return tagName
+	}
+	return releaseName
 }
 
 func (impl *ReleaseNoteServiceImpl) GetReleases() ([]*common.Release, error) {
+	releaseList, err := impl.getCachedReleases()
+	if err != nil {
+		return releaseList, err
+	}
+	return impl.withDerivedFields(releaseList), nil
+}
+
+// withDerivedFields sets the fields derived on read on copies, the cached releases are shared
+func (impl *ReleaseNoteServiceImpl) withDerivedFields(releases []*common.Release) []*common.Release {
+	releases = copyReleases(releases)
+	impl.applySupportPolicy(releases)
+	impl.applyBlockedReleases(releases)
+	impl.applyReleaseChannels(releases)
+	impl.applyAssetProxy(releases)
+	applyReleaseAge(releases, time.Now())
+	return releases
+}
+
+// copyReleases returns shallow copies of the releases, fields are only ever replaced on a copy and never updated in place
+func copyReleases(releases []*common.Release) []*common.Release {
+	if releases == nil {
+		return nil
+	}
+	copies := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		copied := *release
+		copies = append(copies, &copied)
+	}
+	return copies
+}
+
+// getCachedReleases returns the releases from the cache, fetching them from github when the cache is stale or empty
+func (impl *ReleaseNoteServiceImpl) getCachedReleases() ([]*common.Release, error) {
 	var releaseList []*common.Release
 	// Removing Postgres dependancy if cloud is configured
 	if impl.blobConfig.CloudConfigured {
-		// Getting from blob with latest tagName
-		latestTagFromBlob, err := impl.getLatestTagFromBlobStorage()
+		// the cache is current as long as it was filled at the generation on blob
+		generationFromBlob, err := impl.getReleasesGenerationFromBlobStorage()
 		if err != nil {
 			return releaseList, err
 		}
-		var tagNameFromCache string
-		if len(releaseCache) > 0 {
-			tagNameFromCache = releaseCache[CACHE_KEY][0].TagName
-		}
-		// if latest release tag is same with cache, return from cache
-		if tagNameFromCache == latestTagFromBlob {
-			return releaseCache[CACHE_KEY], nil
-		} else if tagNameFromCache != latestTagFromBlob {
-			// If tagName differ get it from github and update cache and upload to blob
+		cachedReleases := releaseCache[CACHE_KEY]
+		if (len(cachedReleases) > 0 && generationFromBlob == impl.getReleasesGeneration()) || (len(cachedReleases) == 0 && len(generationFromBlob) == 0) {
+			impl.metrics.cacheRequests.WithLabelValues(ReleaseCacheHit).Inc()
+			return cachedReleases, nil
+		} else {
+			impl.metrics.cacheRequests.WithLabelValues(ReleaseCacheMiss).Inc()
+			// If generation differ get it from github and update cache
 			releaseList, err = impl.GetReleasesFromGithubWithRetry()
 			if err != nil {
 				return releaseList, err
 			}
-			// Updating Cache and Updating tagName on blob
 			if len(releaseList) > 0 {
-				releaseCache[CACHE_KEY] = releaseList
-				releaseInfo := releaseList[0]
-				_, err = impl.updateTagToBlobStorage(releaseInfo)
+				impl.setCachedReleases(releaseList)
+				if len(generationFromBlob) > 0 {
+					// publishing another generation would make the other instances refresh in turn
+					impl.setReleasesGeneration(generationFromBlob)
+					return releaseList, nil
+				}
+				err = impl.publishReleasesGeneration(releaseList)
 				if err != nil {
 					return releaseList, err
 				}
 			}
 			return releaseList, nil
 		}
-		return releaseList, err
 	}
 	releaseNoteObj, err := impl.getActiveReleaseNote()
 	if err != nil && err != pg.ErrNoRows {
@@ -290,7 +728,10 @@ func (impl *ReleaseNoteServiceImpl) GetReleases() ([]*common.Release, error) {
 			releaseList = append(releaseList, releaseNotes...)
 		}
 	}
-	if releaseList == nil {
+	if releaseList != nil {
+		impl.metrics.cacheRequests.WithLabelValues(ReleaseCacheHit).Inc()
+	} else {
+		impl.metrics.cacheRequests.WithLabelValues(ReleaseCacheMiss).Inc()
 		releaseList, err = impl.GetReleasesFromGithubWithRetry()
 		if err != nil {
 			return releaseList, err
@@ -302,26 +743,133 @@ func (impl *ReleaseNoteServiceImpl) GetReleases() ([]*common.Release, error) {
 	return releaseList, nil
 }
 
+// isUpgradeTarget tells whether a release can be offered as the version to install or upgrade to.
+func isUpgradeTarget(release *common.Release) bool {
+	return !release.Yanked && !release.Blocked && release.Channel != ReleaseChannelEdge
+}
+
+// GetLatestRelease never returns a non semver tag, pre-releases are skipped unless included
+func (impl *ReleaseNoteServiceImpl) GetLatestRelease(includePrerelease bool) (*common.Release, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	var latest *versionedRelease
+	for _, item := range parseVersionedReleases(releases) {
+		if !isUpgradeTarget(item.release) {
+			continue
+		}
+		if !includePrerelease && (item.version.IsPrerelease() || item.release.Prerelease) {
+			continue
+		}
+		if latest == nil || item.version.Compare(latest.version) > 0 {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return latest.release, nil
+}
+
+// GetLatestReleaseForInstallation skips a release still being rolled out to other installations
+func (impl *ReleaseNoteServiceImpl) GetLatestReleaseForInstallation(installationId string) (*common.Release, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if impl.isUpgradeTargetFor(release, installationId) {
+			return release, nil
+		}
+	}
+	return nil, nil
+}
+
+func (impl *ReleaseNoteServiceImpl) CheckRelease(version string) (*common.ReleaseCheck, error) {
+	return impl.CheckReleaseForInstallation(version, "")
+}
+
+func (impl *ReleaseNoteServiceImpl) CheckReleaseForInstallation(version string, installationId string) (*common.ReleaseCheck, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	releaseCheck := &common.ReleaseCheck{Version: version}
+	// releases are ordered newest first, so an upgrade is available when the latest target comes before the version
+	latestIndex, versionIndex := -1, -1
+	for index, release := range releases {
+		if latestIndex < 0 && impl.isUpgradeTargetFor(release, installationId) {
+			latestIndex = index
+			releaseCheck.LatestRelease = release
+		}
+		if versionIndex < 0 && util2.IsSameVersionTag(release.TagName, version) {
+			versionIndex = index
+			releaseCheck.Yanked = release.Yanked
+			releaseCheck.YankedReason = release.YankedReason
+			// the known issues of the version affect the installation until it upgrades past their fix
+			releaseCheck.KnownIssues = release.KnownIssues
+		}
+	}
+	releaseCheck.UpgradeAvailable = latestIndex >= 0 && (versionIndex < 0 || latestIndex < versionIndex)
+	if parsedVersion, err := util2.ParseSemanticVersion(version); err == nil {
+		now := time.Now()
+		for _, lineInfo := range impl.evaluateSupportPolicy(releases, now) {
+			if lineInfo.Line == parsedVersion.MinorLine() {
+				releaseCheck.Supported = lineInfo.Supported
+				releaseCheck.EolDate = lineInfo.EolDate
+				releaseCheck.EolWarning = impl.getEolWarning(lineInfo, now)
+			}
+		}
+	}
+	return releaseCheck, nil
+}
+
 func (impl *ReleaseNoteServiceImpl) GetReleasesFromGithubWithRetry() ([]*common.Release, error) {
+	return impl.getReleasesFromGithubWithRetry(context.Background())
+}
+
+func (impl *ReleaseNoteServiceImpl) getReleasesFromGithubWithRetry(ctx context.Context) ([]*common.Release, error) {
 	var releaseList []*common.Release
 	operationComplete := false
 	retryCount := 0
-	for !operationComplete && retryCount < 3 {
+	var primaryErr error
+	for !operationComplete && retryCount < 3 && ctx.Err() == nil {
 		retryCount = retryCount + 1
-		releasesDto, releaseStatus := impl.GetReleasesFromGithub()
-		if !releaseStatus {
+		releasesDto, err := impl.releaseSource.FetchReleases(ctx)
+		if err != nil {
+			primaryErr = err
+			if isNotFoundError(err) {
+				// a missing repo doesn't come back on retry
+				break
+			}
 			continue
 		}
-		operationComplete = releaseStatus
+		operationComplete = true
 		releaseList = releasesDto
 	}
+	if ctx.Err() != nil {
+		return releaseList, ctx.Err()
+	}
 	if !operationComplete {
-		return releaseList, fmt.Errorf("failed operation on fetching releases from github, attempted 3 times")
+		fallbackReleases, err := impl.fetchFallbackReleases(ctx, primaryErr, retryCount >= 3)
+		if err != nil {
+			return releaseList, fmt.Errorf("failed operation on fetching releases from %s, attempted %d times", impl.releaseSourceConfig.ReleaseSource, retryCount)
+		}
+		releaseList = fallbackReleases
+	} else {
+		impl.setReleaseProvenance(ReleaseProvenancePrimary)
+	}
+	if _, ok := impl.releaseSource.(*githubReleaseSource); ok {
+		impl.fetchYankedReleases(ctx)
 	}
+	impl.applyYankedReleases(releaseList)
+	impl.applyCompareURLs(releaseList)
+	sortReleasesByVersion(releaseList)
 	return releaseList, nil
 }
 
-func (impl *ReleaseNoteServiceImpl) getLatestTagFromBlobStorage() (string, error) {
+func (impl *ReleaseNoteServiceImpl) getReleasesGenerationFromBlobStorage() (string, error) {
 	blobStorageService := blob_storage.NewBlobStorageServiceImpl(nil)
 	request := impl.createBlobStorageRequest(impl.blobConfig.BlobStorageType, LATEST_FILENAME, BLOB_LATEST_RELEASE_FILE_NAME)
 	status, _, err := blobStorageService.Get(request)
@@ -338,22 +886,9 @@ func (impl *ReleaseNoteServiceImpl) getLatestTagFromBlobStorage() (string, error
 		impl.logger.Errorw("error in reading file downloaded from s3")
 		return "", err
 	}
-	latestTagFromBlob := string(content)
-	latestTagFromBlob = strings.ReplaceAll(latestTagFromBlob, "\n", "")
-	return latestTagFromBlob, nil
-}
-
-func (impl *ReleaseNoteServiceImpl) getPrerequisiteContent(releaseInfo *common.Release) {
-	if strings.Contains(releaseInfo.Body, PrerequisitesMatcher) {
-		releaseInfo.Prerequisite = true
-		start := strings.Index(releaseInfo.Body, PrerequisitesMatcher)
-		end := strings.LastIndex(releaseInfo.Body, PrerequisitesMatcher)
-		if end == 0 {
-			return
-		}
-		prerequisiteMessage := strings.ReplaceAll(releaseInfo.Body[start:end], PrerequisitesMatcher, "")
-		releaseInfo.PrerequisiteMessage = prerequisiteMessage
-	}
+	generationFromBlob := string(content)
+	generationFromBlob = strings.ReplaceAll(generationFromBlob, "\n", "")
+	return generationFromBlob, nil
 }
 
 func (impl *ReleaseNoteServiceImpl) GetModules() ([]*common.Module, error) {
@@ -370,10 +905,25 @@ func (impl *ReleaseNoteServiceImpl) GetModules() ([]*common.Module, error) {
 		Assets:                        impl.moduleConfig.ModuleConfig.Assets,
 		DependentModules:              []int{},
 	})
+	impl.applyModuleFeatureFlags(modules)
 	return modules, nil
 }
 
+// GetModulesV2 returns the module catalog of the profile this deployment serves
 func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
+	return impl.GetModulesForProfile(impl.moduleConfig.ModuleConfig.CatalogProfile)
+}
+
+// getBaseModules copies the modules as callers set fields on them
+func (impl *ReleaseNoteServiceImpl) getBaseModules() []*common.Module {
+	if impl.catalogModules != nil {
+		modules := make([]*common.Module, 0, len(impl.catalogModules))
+		for _, module := range impl.catalogModules {
+			moduleCopy := *module
+			modules = append(modules, &moduleCopy)
+		}
+		return modules
+	}
 	var modules []*common.Module
 	modules = append(modules, &common.Module{
 		Id:                            1,
@@ -390,6 +940,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 	modules = append(modules, &common.Module{
 		Id:                            2,
 		Name:                          "argo-cd",
+		Aliases:                       []string{"argocd", "gitops"},
 		BaseMinVersionSupported:       "v0.6.0",
 		IsIncludedInLegacyFullPackage: true,
 		Description:                   "<div class=\"module-details__feature-info fs-14 fw-4\"><p>GitOps is an operational framework that takes DevOps best practices used for application development such as version control, collaboration, compliance and applies them to infrastructure automation. Similar to how teams use application source code, operations teams that adopt GitOps use configuration files stored as code (infrastructure as code).</p><p>Devtron uses GitOps to automate the process of provisioning infrastructure. GitOps configuration files generate the same infrastructure environment every time it’s deployed, just as application source code generates the same application binaries every time it’s built.</p><h3 class=\"module-details__features-list-heading fs-14 fw-6\">Features:</h3><ul class=\"module-details__features-list pl-22 mb-24\"><li>Implements GitOps to manage the state of Kubernetes applications.</li><li>Simplified and abstracted integration with ArgoCD for GitOps operation.</li><li>No prior knowledge of ArgoCD is required.</li></ul></div>",
@@ -398,6 +949,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 		Info:                          "Declarative GitOps CD for Kubernetes powered by Argo CD",
 		Assets:                        []string{"https://cdn.devtron.ai/images/img-gitops-1.png"},
 		DependentModules:              []int{1},
+		UninstallWarnings:             []string{"Applications deployed through GitOps will no longer be synced to the cluster."},
 		ResourceFilter: &common.ResourceFilter{
 			GlobalFilter: &common.ResourceIdentifier{
 				Labels: map[string]string{
@@ -410,6 +962,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 	modules = append(modules, &common.Module{
 		Id:                            3,
 		Name:                          "security.clair",
+		Aliases:                       []string{"security-clair", "clair"},
 		BaseMinVersionSupported:       "v0.6.0",
 		IsIncludedInLegacyFullPackage: true,
 		Description:                   "<div class=\"module-details__feature-info fs-14 fw-4\"><p>When you work with containers (Docker) you are not only packaging your application but also part of the OS. It is crucial to know what kind of libraries might be vulnerable in your container. One way to find this information is to look at the Docker registry [Hub or Quay.io] security scan. This means your vulnerable image is already on the Docker registry.</p><p>What you want is a scan as a part of CI/CD pipeline that stops the Docker image push on vulnerabilities:</p><ul class=\"module-details__features-list pl-22 mb-24\" style=\"\n    list-style: decimal;\n\"><li>Build and test your application\n</li><li>Build the container\n</li><li>Test the container for vulnerabilities\n</li><li>Check the vulnerabilities against allowed ones, if everything is allowed then pass otherwise fail\n</li></ul><p>This straightforward process is not that easy to achieve when using the services like Docker Hub or Quay.io. This is because they work asynchronously which makes it harder to do straightforward CI/CD pipeline.</p><h3 class=\"module-details__features-list-heading fs-14 fw-6\">Features:</h3><ul class=\"module-details__features-list pl-22 mb-24\"><li>Scans an image against Clair server</li><li>Compares the vulnerabilities against a whitelist</li><li>Blocks images from deployment if blacklisted / blocked vulnerabilities are detected</li><li>Ability to define hierarchical security policy (Global / Cluster / Environment / Application) to allow / block vulnerabilities based on criticality (High / Moderate / Low)</li><li>Shows security vulnerabilities detected in deployed applications</li></ul></div>",
@@ -424,6 +977,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 	modules = append(modules, &common.Module{
 		Id:                            4,
 		Name:                          "notifier",
+		Aliases:                       []string{"notification", "notifications"},
 		BaseMinVersionSupported:       "v0.6.0",
 		IsIncludedInLegacyFullPackage: true,
 		Description:                   "<div class=\"module-details__feature-info fs-14 fw-4\"><p>Receive alerts for build and deployment pipelines on trigger, success, and failure events. An alert will be sent to desired slack channel and Email(supports SES and SMTP configurations) with the required information to take be able to quick actions whenever required.</p><h3 class=\"module-details__features-list-heading fs-14 fw-6\">Features:</h3><ul class=\"module-details__features-list pl-22 mb-24\"><li>Receive alerts for start, success, and failure events on desired build pipelines</li><li>Receive alerts for start, success, and failure events on desired deployment pipelines</li><li>Receive alerts on desired Slack channels via webhook</li><li>Receive alerts on your email address (supports SES and SMTP)</li></ul><h3 class=\"module-details__features-list-heading fs-14 fw-6\">How to use the Integration?</h3><span>After you install the integration, you can configure notifications from Global configurations &gt; Notifications section. For more details on how to configure notifications, refer\n<a href=\"https://docs.devtron.ai/getting-started/global-configurations/manage-notification\" target=\"_blank\">here</a>.\n</span></div>",
@@ -437,6 +991,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 	modules = append(modules, &common.Module{
 		Id:                            5,
 		Name:                          "monitoring.grafana",
+		Aliases:                       []string{"monitoring-grafana", "grafana"},
 		BaseMinVersionSupported:       "v0.6.0",
 		IsIncludedInLegacyFullPackage: true,
 		Description:                   "<div class=\"module-details__feature-info fs-14 fw-4\"><p>Devtron leverages the power of Grafana to show application metrics like CPU, Memory utilization, Status 4xx/ 5xx/ 2xx, Throughput, and Latency.</p><h3 class=\"module-details__features-list-heading fs-14 fw-6\">Features:</h3><ul class=\"module-details__features-list pl-22 mb-24\"><li>CPU usage: Displays the overall utilization of CPU by an application. It is available as aggregated or per pod.</li><li>Memory usage: Displays the overall utilization of memory by an application. It is available as aggregated or per pod.</li><li>Throughput: Indicates the number of requests processed by an application per minute.</li><li>Status codes: Indicates the application’s response to the client’s request with a specific status code as shown below:<ul class=\"module-details__features-list pl-22 mb-24\"><li>1xx: Communicates transfer protocol level information</li><li>2xx: Client’s request is processed successfully</li><li>3xx: Client must take some additional action to complete their request</li><li>4xx: There is an error on the client side</li><li>5xx: There is an error on the server side</li></ul></li></ul><h3 class=\"module-details__features-list-heading fs-14 fw-6\">How to use the Integration?</h3><span>After you install the integration, you can enable application metrics for all or specific environments in an application. For more details on how to enable application metrics, refer \n<a href=\"https://docs.devtron.ai/v/v0.5/usage/applications/app-details/app-metrics\" target=\"_blank\">here</a>\n</span></div>",
@@ -456,6 +1011,7 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 	modules = append(modules, &common.Module{
 		Id:                            6,
 		Name:                          "security.trivy",
+		Aliases:                       []string{"security-trivy", "trivy"},
 		BaseMinVersionSupported:       "v0.6.18",
 		IsIncludedInLegacyFullPackage: false,
 		Description:                   "<div class=\"module-details__feature-info fs-14 fw-4\"><p>When you work with containers (Docker) you are not only packaging your application but also part of the OS. It is crucial to know what kind of libraries might be vulnerable in your container. One way to find this information is to look at the Docker registry [Hub or Quay.io] security scan. This means your vulnerable image is already on the Docker registry.</p><p>What you want is a scan as a part of CI/CD pipeline that stops the Docker image push on vulnerabilities:</p><ul class=\"module-details__features-list pl-22 mb-24\" style=\"\n    list-style: decimal;\n\"><li>Build and test your application\n</li><li>Build the container\n</li><li>Test the container for vulnerabilities\n</li><li>Check the vulnerabilities against allowed ones, if everything is allowed then pass otherwise fail\n</li></ul><p>This straightforward process is not that easy to achieve when using the services like Docker Hub or Quay.io. This is because they work asynchronously which makes it harder to do straightforward CI/CD pipeline.</p><h3 class=\"module-details__features-list-heading fs-14 fw-6\">Features:</h3><ul class=\"module-details__features-list pl-22 mb-24\"><li>Scans an image against Trivy CLI</li><li>Compares the vulnerabilities against a whitelist</li><li>Blocks images from deployment if blacklisted / blocked vulnerabilities are detected</li><li>Ability to define hierarchical security policy (Global / Cluster / Environment / Application) to allow / block vulnerabilities based on criticality (High / Moderate / Low)</li><li>Shows security vulnerabilities detected in deployed applications</li></ul></div>",
@@ -466,22 +1022,76 @@ func (impl *ReleaseNoteServiceImpl) GetModulesV2() ([]*common.Module, error) {
 		DependentModules:              []int{1},
 		ModuleType:                    "security",
 	})
-	return modules, nil
+	return modules
 }
 
 func (impl *ReleaseNoteServiceImpl) GetModuleByName(name string) (*common.Module, error) {
-	module := &common.Module{}
+	index, err := impl.getModuleIndex()
+	if err != nil {
+		return &common.Module{}, err
+	}
+	name, _ = index.resolveName(name)
+	item, ok := index.byName[name]
+	if !ok {
+		return &common.Module{}, nil
+	}
+	// the indexed module is shared, the derived warnings go on a copy
+	module := *item
+	module.UninstallWarnings = append([]string{}, item.UninstallWarnings...)
+	err = addDependentUninstallWarnings(index.modules, &module)
+	if err != nil {
+		return &module, err
+	}
+	return &module, nil
+}
+
+// DisableImpact orders dependents by distance from the module and then by id
+func (impl *ReleaseNoteServiceImpl) DisableImpact(name string) ([]*common.Module, error) {
 	modules, err := impl.GetModulesV2()
 	if err != nil {
 		impl.logger.Errorw("error on fetching modules", "err", err)
-		return module, err
+		return nil, err
 	}
+	name, _ = impl.ResolveModuleName(name)
+	return getDisableImpact(modules, name)
+}
+
+func getDisableImpact(modules []*common.Module, name string) ([]*common.Module, error) {
+	var target *common.Module
+	dependentsById := make(map[int][]*common.Module)
 	for _, item := range modules {
 		if item.Name == name {
-			module = item
+			target = item
+		}
+		for _, dependencyId := range item.DependentModules {
+			dependentsById[dependencyId] = append(dependentsById[dependencyId], item)
+		}
+	}
+	if target == nil {
+		return nil, &util2.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: fmt.Sprintf("module not found, name: %s", name), UserMessage: "module not found"}
+	}
+
+	var impacted []*common.Module
+	visited := map[int]bool{target.Id: true}
+	currentLevel := []*common.Module{target}
+	for len(currentLevel) > 0 {
+		var nextLevel []*common.Module
+		for _, module := range currentLevel {
+			for _, dependent := range dependentsById[module.Id] {
+				if visited[dependent.Id] {
+					continue
+				}
+				visited[dependent.Id] = true
+				nextLevel = append(nextLevel, dependent)
+			}
 		}
+		sort.SliceStable(nextLevel, func(i, j int) bool {
+			return nextLevel[i].Id < nextLevel[j].Id
+		})
+		impacted = append(impacted, nextLevel...)
+		currentLevel = nextLevel
 	}
-	return module, nil
+	return impacted, nil
 }
 
 func (impl *ReleaseNoteServiceImpl) getActiveReleaseNote() (*releaseNote.ReleaseNote, error) {
@@ -494,15 +1104,9 @@ func (impl *ReleaseNoteServiceImpl) getActiveReleaseNote() (*releaseNote.Release
 }
 
 func (impl *ReleaseNoteServiceImpl) updateReleaseNotesInDb(releaseList []*common.Release, webhookResult bool) error {
-	// initiate tx
-	dbConnection := impl.releaseNoteRepository.GetConnection()
-	tx, err := dbConnection.Begin()
-	if err != nil {
-		return err
-	}
-	// rollback tx on error.
-	defer tx.Rollback()
-
+	releaseList = impl.trimEdgeReleases(releaseList)
+	releaseList = impl.reconcileKnownIssues(releaseList)
+	sortReleasesByVersion(releaseList)
 	// STEP-1 - mark inactive in DB
 	releaseNoteObj, err := impl.getActiveReleaseNote()
 	if err != nil {
@@ -513,6 +1117,19 @@ func (impl *ReleaseNoteServiceImpl) updateReleaseNotesInDb(releaseList []*common
 			return err
 		}
 	}
+	if releaseNoteObj != nil && releaseNoteObj.Id > 0 && isSameReleasesContent(releaseNoteObj.ReleaseNote, releaseList) {
+		// nothing changed since the active row was stored, replacing it would only add a row on every refresh
+		return nil
+	}
+
+	// initiate tx
+	dbConnection := impl.releaseNoteRepository.GetConnection()
+	tx, err := dbConnection.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback tx on error.
+	defer tx.Rollback()
 
 	// mark inactive
 	if releaseNoteObj != nil && releaseNoteObj.Id > 0 {
@@ -536,69 +1153,50 @@ func (impl *ReleaseNoteServiceImpl) updateReleaseNotesInDb(releaseList []*common
 	}
 
 	err = tx.Commit()
-	return err
+	if err != nil {
+		return err
+	}
+	impl.invalidateDerivedViews()
+	return nil
+}
+
+// isSameReleasesContent compares the content hashes of the releases, releases which can't be hashed are never the same
+func isSameReleasesContent(stored []*common.Release, releases []*common.Release) bool {
+	storedHash, err := util2.GetContentHash(stored)
+	if err != nil {
+		return false
+	}
+	hash, err := util2.GetContentHash(releases)
+	if err != nil {
+		return false
+	}
+	return storedHash == hash
 }
 
 func (impl *ReleaseNoteServiceImpl) GetReleasesOnInitialisation() {
 	// Getting releases from github on Initialisation(will try 3 times if failed)
 	releases, err := impl.GetReleasesFromGithubWithRetry()
+	impl.recordSync(len(releases), err)
 	if err != nil {
 		impl.logger.Errorw("error in getting releases from github on initialisation", "err", fmt.Errorf("failed operation on fetching releases from github, attempted 3 times"))
+		if !impl.bootstrapFromSnapshot() {
+			impl.primeCacheFromBundle()
+		}
 		return
 	}
 	if len(releases) > 0 {
-		releaseCache[CACHE_KEY] = releases
-		releaseInfo := releases[0]
-		_, err = impl.updateTagToBlobStorage(releaseInfo)
+		impl.setCachedReleases(releases)
+		err = impl.publishReleasesGeneration(releases)
 		if err != nil {
-			impl.logger.Errorw("error in updating on blob", "err", err, "tagName", releaseInfo.TagName)
+			impl.logger.Errorw("error in updating on blob", "err", err)
 		}
 
 	}
+	impl.logModuleLintReport()
 }
 
 func (impl *ReleaseNoteServiceImpl) createBlobStorageRequest(cloudProvider blob_storage.BlobStorageType, sourceKey string, destinationKey string) *blob_storage.BlobStorageRequest {
-	request := &blob_storage.BlobStorageRequest{
-		StorageType:    cloudProvider,
-		SourceKey:      sourceKey,
-		DestinationKey: destinationKey,
-	}
-	switch impl.blobConfig.BlobStorageType {
-	case blob_storage.BLOB_STORAGE_S3:
-		{
-			var awsS3BaseConfig *blob_storage.AwsS3BaseConfig
-
-			awsS3BaseConfig = &blob_storage.AwsS3BaseConfig{
-				AccessKey:         impl.blobConfig.S3AccessKey,
-				Passkey:           impl.blobConfig.S3Passkey,
-				EndpointUrl:       impl.blobConfig.S3EndpointUrl,
-				IsInSecure:        impl.blobConfig.S3IsInSecure,
-				BucketName:        impl.blobConfig.S3BucketName,
-				Region:            impl.blobConfig.S3Region,
-				VersioningEnabled: impl.blobConfig.S3VersioningEnabled,
-			}
-			request.AwsS3BaseConfig = awsS3BaseConfig
-
-		}
-	case blob_storage.BLOB_STORAGE_AZURE:
-		{
-			azureBlobBaseConfig := &blob_storage.AzureBlobBaseConfig{
-				AccountKey:        impl.blobConfig.AzureAccountKey,
-				AccountName:       impl.blobConfig.AzureAccountName,
-				Enabled:           impl.blobConfig.AzureEnabled,
-				BlobContainerName: impl.blobConfig.AzureBlobContainerName,
-			}
-			request.AzureBlobBaseConfig = azureBlobBaseConfig
-
-		}
-	case blob_storage.BLOB_STORAGE_GCP:
-		{
-			gcpBlobBaseConfig := &blob_storage.GcpBlobBaseConfig{
-				CredentialFileJsonData: impl.blobConfig.GcpCredentialFileJsonData,
-				BucketName:             impl.blobConfig.GcpBucketName,
-			}
-			request.GcpBlobBaseConfig = gcpBlobBaseConfig
-		}
-	}
+	request := impl.blobConfig.NewBlobStorageRequest(sourceKey, destinationKey)
+	request.StorageType = cloudProvider
 	return request
 }
@@ -0,0 +1,162 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"github.com/caarlos0/env"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"github.com/devtron-labs/central-api/pkg/releaseNote"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+)
+
+var errNotSupportedInTest = errors.New("not supported in test")
+
+// fakeReleaseNoteRepository serves the active release note from memory, writes need a database and fail. The
+// connection is nil unless db is set.
+type fakeReleaseNoteRepository struct {
+	releaseNote *releaseNote.ReleaseNote
+	db          *pg.DB
+}
+
+func (repo *fakeReleaseNoteRepository) GetConnection() *pg.DB {
+	return repo.db
+}
+
+func (repo *fakeReleaseNoteRepository) Save(releaseNote *releaseNote.ReleaseNote, tx *pg.Tx) error {
+	return errNotSupportedInTest
+}
+
+func (repo *fakeReleaseNoteRepository) Update(releaseNote *releaseNote.ReleaseNote, tx *pg.Tx) error {
+	return errNotSupportedInTest
+}
+
+func (repo *fakeReleaseNoteRepository) FindActive() (*releaseNote.ReleaseNote, error) {
+	if repo.releaseNote == nil {
+		return nil, pg.ErrNoRows
+	}
+	return repo.releaseNote, nil
+}
+
+// fakeAdminStateRepository keeps the saved state in memory, Get fails with err when set
+type fakeAdminStateRepository struct {
+	state *adminState.AdminState
+	err   error
+}
+
+func (repo *fakeAdminStateRepository) Get() (*adminState.AdminState, error) {
+	if repo.err != nil {
+		return nil, repo.err
+	}
+	if repo.state == nil {
+		return &adminState.AdminState{}, nil
+	}
+	return copyAdminState(repo.state), nil
+}
+
+func (repo *fakeAdminStateRepository) Save(state *adminState.AdminState) error {
+	repo.state = state
+	return nil
+}
+
+// fakeReleaseSource answers every fetch with the next error of errs, then with the releases once errs are used up.
+// A blocking source answers only once the context is done.
+type fakeReleaseSource struct {
+	mutex    sync.Mutex
+	releases []*common.Release
+	errs     []error
+	calls    int
+	blocking bool
+}
+
+func (source *fakeReleaseSource) FetchReleases(ctx context.Context) ([]*common.Release, error) {
+	source.mutex.Lock()
+	source.calls++
+	calls := source.calls
+	source.mutex.Unlock()
+	if source.blocking {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if calls <= len(source.errs) && source.errs[calls-1] != nil {
+		return nil, source.errs[calls-1]
+	}
+	return copyReleases(source.releases), nil
+}
+
+func (source *fakeReleaseSource) getCalls() int {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.calls
+}
+
+// newTestReleaseNoteService builds the service with the default configs, reading the given releases as the
+// active release note of the database and fetching from a fake source which has none
+func newTestReleaseNoteService(t testing.TB, releases ...*common.Release) *ReleaseNoteServiceImpl {
+	t.Helper()
+	logger := zap.NewNop().Sugar()
+	gitHubConfig := &util.GitHubConfig{}
+	err := env.Parse(gitHubConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseCacheConfig, err := util.NewReleaseCacheConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supportPolicyConfig, err := util.NewSupportPolicyConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseLintConfig, err := util.NewReleaseLintConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseSourceConfig, err := util.NewReleaseSourceConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseBodyConfig, err := util.NewReleaseBodyConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseChannelConfig, err := util.NewReleaseChannelConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseAssetConfig, err := util.NewReleaseAssetConfig(logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := &fakeReleaseNoteRepository{}
+	if len(releases) > 0 {
+		repository.releaseNote = &releaseNote.ReleaseNote{Id: 1, ReleaseNote: releases, IsActive: true}
+	}
+	impl := &ReleaseNoteServiceImpl{
+		logger:                logger,
+		client:                &util.GitHubClient{GitHubConfig: gitHubConfig},
+		moduleConfig:          &util.ModuleConfig{ModuleConfig: &util.ModuleConfigVariables{}},
+		releaseNoteRepository: repository,
+		blobConfig:            &util.BlobConfigVariables{},
+		releaseCacheConfig:    releaseCacheConfig,
+		supportPolicyConfig:   supportPolicyConfig,
+		releaseLintConfig:     releaseLintConfig,
+		releaseSourceConfig:   releaseSourceConfig,
+		releaseBodyConfig:     releaseBodyConfig,
+		releaseChannelConfig:  releaseChannelConfig,
+		releaseAssetConfig:    releaseAssetConfig,
+		releaseSource:         &fakeReleaseSource{},
+		adminStateRepository:  &fakeAdminStateRepository{},
+		webhookRateLimiter:    newWebhookRateLimiter(0, 0),
+		metrics:               newReleaseMetrics(),
+	}
+	err = impl.loadAdminState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return impl
+}
@@ -0,0 +1,326 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"github.com/go-pg/pg"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestReleases returns releases ordered by version like the stored ones, the pre-release being the newest by date
+func newTestReleases() []*common.Release {
+	publishedAt := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	return []*common.Release{
+		{TagName: "v0.8.0-rc.1", Prerelease: true, PublishedAt: publishedAt.Add(48 * time.Hour)},
+		{TagName: "v0.7.1", PublishedAt: publishedAt},
+		{TagName: "v0.7.0", PublishedAt: publishedAt.Add(-24 * time.Hour)},
+	}
+}
+
+func TestGetLatestReleaseSkipsNewerPrerelease(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+
+	release, err := impl.GetLatestRelease(false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if release == nil || release.TagName != "v0.7.1" {
+		t.Errorf("expected the stable latest v0.7.1, got %v", release)
+	}
+
+	release, err = impl.GetLatestRelease(true)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if release == nil || release.TagName != "v0.8.0-rc.1" {
+		t.Errorf("expected the pre-release v0.8.0-rc.1 when included, got %v", release)
+	}
+}
+
+func TestGetLatestReleaseSkipsPrereleaseByTag(t *testing.T) {
+	releases := newTestReleases()
+	// a pre-release tag is skipped even when github doesn't flag the release
+	releases[0].Prerelease = false
+	impl := newTestReleaseNoteService(t, releases...)
+
+	release, err := impl.GetLatestRelease(false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if release == nil || release.TagName != "v0.7.1" {
+		t.Errorf("expected the stable latest v0.7.1, got %v", release)
+	}
+}
+
+func TestLatestTagAliasAgreesWithLatestForInstallation(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+
+	latest, err := impl.GetLatestReleaseForInstallation("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	release, err := impl.findCachedRelease("latest")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if latest == nil || release.TagName != latest.TagName {
+		t.Errorf("expected the latest alias to resolve to %v, got %s", latest, release.TagName)
+	}
+	release, err = impl.findCachedRelease("0.7.0")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if release.TagName != "v0.7.0" {
+		t.Errorf("expected v0.7.0 looked up without its leading v, got %s", release.TagName)
+	}
+}
+
+func TestMergeWebhookReleaseReplacesRecreatedRelease(t *testing.T) {
+	releases := []*common.Release{{GithubReleaseID: 2, TagName: "v0.7.1"}, {GithubReleaseID: 1, TagName: "v0.7.0"}}
+	tests := []struct {
+		name    string
+		release *common.Release
+		tags    []string
+		isNew   bool
+	}{
+		{name: "edited tag", release: &common.Release{GithubReleaseID: 2, TagName: "v0.7.2"}, tags: []string{"v0.7.2", "v0.7.0"}},
+		{name: "recreated under the same tag", release: &common.Release{GithubReleaseID: 3, TagName: "v0.7.1"}, tags: []string{"v0.7.1", "v0.7.0"}},
+		{name: "new release", release: &common.Release{GithubReleaseID: 3, TagName: "v0.8.0"}, tags: []string{"v0.8.0", "v0.7.1", "v0.7.0"}, isNew: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, isNew := mergeWebhookRelease(releases, test.release)
+			if isNew != test.isNew {
+				t.Errorf("expected new %v, got %v", test.isNew, isNew)
+			}
+			var tags []string
+			for _, release := range merged {
+				tags = append(tags, release.TagName)
+			}
+			if strings.Join(tags, ",") != strings.Join(test.tags, ",") {
+				t.Errorf("expected tags %v, got %v", test.tags, tags)
+			}
+		})
+	}
+}
+
+func TestUpdateReleasesWithAckRefusesMalformedPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		message string
+	}{
+		{name: "not json", payload: `action=published`, message: "not a json object"},
+		{name: "json array", payload: `[{"action": "published"}]`, message: "not a json object"},
+		{name: "missing action", payload: `{"release": {"tag_name": "v0.7.1"}}`, message: "action missing or not a string"},
+		{name: "action not a string", payload: `{"action": 1, "release": {"tag_name": "v0.7.1"}}`, message: "got float64"},
+		{name: "missing release", payload: `{"action": "published"}`, message: "release missing or not an object"},
+		{name: "release not an object", payload: `{"action": "published", "release": "v0.7.1"}`, message: "got string"},
+		{name: "missing tag", payload: `{"action": "published", "release": {"name": "v0.7.1"}}`, message: "release.tag_name missing"},
+		{name: "tag not a string", payload: `{"action": "published", "release": {"tag_name": 71}}`, message: "release.tag_name missing or not a string"},
+		{name: "body not a string", payload: `{"action": "edited", "release": {"tag_name": "v0.7.1", "body": ["fixes"]}}`, message: "release.body in webhook payload should be a string"},
+		{name: "publish time not a string", payload: `{"action": "published", "release": {"tag_name": "v0.7.1", "published_at": 1710743830}}`, message: "release.published_at"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t, newTestReleases()...)
+			_, err := impl.UpdateReleasesWithAck([]byte(test.payload))
+			apiErr, ok := err.(*util2.ApiError)
+			if !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+				t.Fatalf("expected a bad request, got %v", err)
+			}
+			if !strings.Contains(apiErr.InternalMessage, test.message) {
+				t.Errorf("expected the error to tell %q, got %q", test.message, apiErr.InternalMessage)
+			}
+		})
+	}
+}
+
+func TestUpdateReleasesWithAckAcceptsNullFields(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	ack, err := impl.UpdateReleasesWithAck([]byte(`{"action": "deleted", "release": {"tag_name": "v0.9.0", "name": null, "body": null, "published_at": null}}`))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ack.Action != ActionDeleted || ack.TagName != "v0.9.0" || ack.Processed {
+		t.Errorf("expected the removal of an unknown release to be acknowledged without processing, got %+v", ack)
+	}
+}
+
+func TestRemoveWebhookReleaseOnDeleteEvent(t *testing.T) {
+	for _, action := range []string{ActionDeleted, ActionUnpublished} {
+		t.Run(action, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t, newTestReleases()...)
+			releaseInfo, _, err := impl.parseWebhookRelease([]byte(`{"action": "`+action+`", "release": {"tag_name": "v0.7.1", "body": null}}`), &common.ReleaseWebhookAck{}, normalizeOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			stored, err := impl.getStoredReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			releases, removed := removeWebhookRelease(stored, releaseInfo)
+			if !removed {
+				t.Fatalf("expected v0.7.1 to be removed")
+			}
+			var tags []string
+			for _, release := range releases {
+				tags = append(tags, release.TagName)
+			}
+			if strings.Join(tags, ",") != "v0.8.0-rc.1,v0.7.0" {
+				t.Errorf("expected only v0.7.1 to be removed, got %v", tags)
+			}
+			if len(stored) != 3 {
+				t.Errorf("expected the stored releases to be left as they were")
+			}
+		})
+	}
+}
+
+func TestUpdateReleasesWithAckRemovesStoredRelease(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.releaseCacheConfig.PersistRetries = 0
+	// nothing listens on the port, the removal reaches the write of the releases which then fails
+	db := pg.Connect(&pg.Options{Addr: "127.0.0.1:1"})
+	defer db.Close()
+	impl.releaseNoteRepository.(*fakeReleaseNoteRepository).db = db
+
+	ack, err := impl.UpdateReleasesWithAck([]byte(`{"action": "deleted", "release": {"tag_name": "v0.9.0"}}`))
+	if err != nil || ack.Processed {
+		t.Errorf("expected the removal of an unknown release to be ignored without a write, got %+v, %v", ack, err)
+	}
+	ack, err = impl.UpdateReleasesWithAck([]byte(`{"action": "deleted", "release": {"tag_name": "v0.7.0"}}`))
+	if err == nil || ack.Processed {
+		t.Errorf("expected the removal of v0.7.0 to be written, got %+v, %v", ack, err)
+	}
+}
+
+func TestGetDisableImpact(t *testing.T) {
+	modules := []*common.Module{
+		{Id: 1, Name: "cicd"},
+		{Id: 2, Name: "argo-cd", DependentModules: []int{1}},
+		{Id: 3, Name: "security.clair", DependentModules: []int{2}},
+		{Id: 4, Name: "notifier", DependentModules: []int{1, 3}},
+		{Id: 5, Name: "monitoring.grafana", DependentModules: []int{3}},
+	}
+	tests := []struct {
+		name     string
+		module   string
+		impacted string
+	}{
+		{name: "direct dependents before transitive ones", module: "cicd", impacted: "argo-cd,notifier,security.clair,monitoring.grafana"},
+		{name: "transitive dependents", module: "argo-cd", impacted: "security.clair,notifier,monitoring.grafana"},
+		{name: "dependents ordered by id", module: "security.clair", impacted: "notifier,monitoring.grafana"},
+		{name: "no dependents", module: "monitoring.grafana", impacted: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impacted, err := getDisableImpact(modules, test.module)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			var names []string
+			for _, module := range impacted {
+				names = append(names, module.Name)
+			}
+			if strings.Join(names, ",") != test.impacted {
+				t.Errorf("expected %q, got %v", test.impacted, names)
+			}
+		})
+	}
+
+	t.Run("unknown module", func(t *testing.T) {
+		_, err := getDisableImpact(modules, "security.unknown")
+		apiErr, ok := err.(*util2.ApiError)
+		if !ok || apiErr.HttpStatusCode != http.StatusNotFound {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+}
+
+func TestDisableImpactOfBuiltInCatalog(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+
+	impacted, err := impl.DisableImpact("cicd")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var names []string
+	for _, module := range impacted {
+		names = append(names, module.Name)
+	}
+	if strings.Join(names, ",") != "argo-cd,security.clair,notifier,monitoring.grafana,security.trivy" {
+		t.Errorf("expected every built in module to depend on cicd, got %v", names)
+	}
+
+	impacted, err = impl.DisableImpact("argo-cd")
+	if err != nil || len(impacted) != 0 {
+		t.Errorf("expected nothing to depend on argo-cd, got %v, %v", impacted, err)
+	}
+}
+
+func TestParseWebhookReleaseWithMalformedBody(t *testing.T) {
+	const payload = `{"action": "published", "release": {"tag_name": "v0.9.0", "body": "<!--upgrade-prerequisites-required-->\nrun the migration"}}`
+	tests := []struct {
+		name   string
+		strict bool
+	}{
+		{name: "lenient accepts", strict: false},
+		{name: "strict rejects", strict: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t, newTestReleases()...)
+			impl.releaseLintConfig.StrictWebhook = test.strict
+
+			releaseInfo, warnings, err := impl.parseWebhookRelease([]byte(payload), &common.ReleaseWebhookAck{}, normalizeOptions{})
+			if test.strict {
+				apiErr, ok := err.(*util2.ApiError)
+				if !ok || apiErr.HttpStatusCode != http.StatusUnprocessableEntity {
+					t.Fatalf("expected the malformed body to be refused, got %v", err)
+				}
+				if !strings.Contains(apiErr.InternalMessage, "unclosed prerequisite block") {
+					t.Errorf("expected the error to tell the problem of the body, got %q", apiErr.InternalMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if releaseInfo == nil || releaseInfo.TagName != "v0.9.0" {
+				t.Fatalf("expected v0.9.0 to be accepted, got %v", releaseInfo)
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], "unclosed prerequisite block") {
+				t.Errorf("expected the malformed body to be reported as a warning, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestUpdateReleasesWithAckStrictModeKeepsStoredReleases(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.releaseLintConfig.StrictWebhook = true
+
+	ack, err := impl.UpdateReleasesWithAck([]byte(`{"action": "published", "release": {"tag_name": "v0.9.0", "body": "<!--upgrade-prerequisites-required-->\n<!--upgrade-prerequisites-required-->"}}`))
+	apiErr, ok := err.(*util2.ApiError)
+	if !ok || apiErr.HttpStatusCode != http.StatusUnprocessableEntity || ack.Processed {
+		t.Fatalf("expected the empty prerequisite block to be refused, got %+v, %v", ack, err)
+	}
+	stored, err := impl.getStoredReleases()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(stored) != len(newTestReleases()) {
+		t.Errorf("expected the stored releases to be left as they were, got %d releases", len(stored))
+	}
+
+	// a removal doesn't store the body, it is not refused for it
+	ack, err = impl.UpdateReleasesWithAck([]byte(`{"action": "deleted", "release": {"tag_name": "v0.9.0", "body": "<!--upgrade-prerequisites-required-->"}}`))
+	if err != nil {
+		t.Errorf("expected the removal to be accepted, got %+v, %v", ack, err)
+	}
+}
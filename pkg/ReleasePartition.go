@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+)
+
+// GetReleasesPartitioned covers the path from fromTag (exclusive) to toTag (inclusive)
+func (impl *ReleaseNoteServiceImpl) GetReleasesPartitioned(fromTag string, toTag string, installedModules []string) (*common.PartitionedReleases, error) {
+	from, to, err := parseUpgradeRange(fromTag, toTag)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	installed := newInstalledModuleSet(impl.resolveModuleNames(installedModules))
+	partitioned := &common.PartitionedReleases{
+		ActionRequired: make([]*common.Release, 0),
+		Informational:  make([]*common.Release, 0),
+	}
+	var path []*common.Release
+	for _, item := range parseVersionedReleases(releases) {
+		if !isUpgradeTarget(item.release) || !isInUpgradeRange(item.version, from, to) {
+			continue
+		}
+		path = append(path, item.release)
+		if isPrerequisiteApplicable(item.release, installed) {
+			partitioned.ActionRequired = append(partitioned.ActionRequired, item.release)
+		} else {
+			partitioned.Informational = append(partitioned.Informational, item.release)
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	partitioned.UpgradeEstimate = impl.estimateUpgradeDuration(path)
+	partitioned.RollbackSafe, partitioned.FirstUnsafeHop = getRollbackSafety(path)
+	return partitioned, nil
+}
@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPartitionReleases() []*common.Release {
+	publishedAt := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	return []*common.Release{
+		{TagName: "v0.8.0", PublishedAt: publishedAt.Add(96 * time.Hour), Prerequisite: true, PrerequisiteMessage: "migrate the database"},
+		{TagName: "v0.7.1", PublishedAt: publishedAt.Add(72 * time.Hour), Prerequisite: true, PrerequisiteMessage: "upgrade the helm chart"},
+		{TagName: "v0.7.0", PublishedAt: publishedAt.Add(48 * time.Hour)},
+		{TagName: "v0.6.1", PublishedAt: publishedAt.Add(24 * time.Hour), Prerequisite: true, PrerequisiteMessage: "rotate the secrets"},
+		{TagName: "v0.6.0", PublishedAt: publishedAt},
+		{TagName: "v0.5.0", PublishedAt: publishedAt.Add(-24 * time.Hour), Prerequisite: true, PrerequisiteMessage: "before the range"},
+	}
+}
+
+func getTestTags(releases []*common.Release) string {
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	return strings.Join(tags, ",")
+}
+
+func TestGetReleasesPartitioned(t *testing.T) {
+	tests := []struct {
+		name           string
+		fromTag        string
+		toTag          string
+		actionRequired string
+		informational  string
+	}{
+		{name: "mixed range", fromTag: "v0.5.0", toTag: "v0.7.1", actionRequired: "v0.7.1,v0.6.1", informational: "v0.7.0,v0.6.0"},
+		{name: "up to the latest", fromTag: "v0.6.1", actionRequired: "v0.8.0,v0.7.1", informational: "v0.7.0"},
+		{name: "informational only", fromTag: "v0.6.1", toTag: "v0.7.0", informational: "v0.7.0"},
+		{name: "empty range", fromTag: "v0.7.0", toTag: "v0.7.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t, newTestPartitionReleases()...)
+			partitioned, err := impl.GetReleasesPartitioned(test.fromTag, test.toTag, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tags := getTestTags(partitioned.ActionRequired); tags != test.actionRequired {
+				t.Errorf("expected %q to require action, got %q", test.actionRequired, tags)
+			}
+			if tags := getTestTags(partitioned.Informational); tags != test.informational {
+				t.Errorf("expected %q to be informational, got %q", test.informational, tags)
+			}
+		})
+	}
+}
+
+func TestGetReleasesPartitionedRefusesInvalidRange(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestPartitionReleases()...)
+	for _, versions := range [][2]string{{"latest", "v0.7.1"}, {"v0.6.0", "next"}, {"v0.7.1", "v0.6.0"}} {
+		_, err := impl.GetReleasesPartitioned(versions[0], versions[1], nil)
+		if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+			t.Errorf("expected %s to %s to be refused, got %v", versions[0], versions[1], err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"time"
+)
+
+// persistWithRetry returns service unavailable once the retries are exhausted so that github redelivers
+func (impl *ReleaseNoteServiceImpl) persistWithRetry(store string, persist func() error) error {
+	backoff := impl.releaseCacheConfig.PersistBackoff
+	var err error
+	for attempt := 0; attempt <= impl.releaseCacheConfig.PersistRetries; attempt++ {
+		if attempt > 0 {
+			impl.logger.Warnw("retrying persisting of release update", "store", store, "attempt", attempt, "backoff", backoff, "err", err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		err = persist()
+		if err == nil {
+			return nil
+		}
+	}
+	impl.logger.Errorw("error in persisting release update, retries exhausted", "store", store, "retries", impl.releaseCacheConfig.PersistRetries, "err", err)
+	return &util.ApiError{HttpStatusCode: http.StatusServiceUnavailable, InternalMessage: fmt.Sprintf("release update not persisted to %s, %v", store, err), UserMessage: "release update could not be persisted"}
+}
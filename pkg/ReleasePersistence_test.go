@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/go-pg/pg"
+	"testing"
+	"time"
+)
+
+func TestPersistWithRetryReleasesLockBetweenAttempts(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.releaseCacheConfig.PersistRetries = 1
+	impl.releaseCacheConfig.PersistBackoff = 200 * time.Millisecond
+	// nothing listens on the port, every attempt to store the release fails
+	db := pg.Connect(&pg.Options{Addr: "127.0.0.1:1"})
+	defer db.Close()
+	impl.releaseNoteRepository.(*fakeReleaseNoteRepository).db = db
+
+	done := make(chan error)
+	go func() {
+		_, err := impl.UpdateReleasesWithAck([]byte(`{"action": "edited", "release": {"tag_name": "v0.7.1", "body": "fixes"}}`))
+		done <- err
+	}()
+	locked := false
+	for {
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Errorf("expected the update to fail once the retries are used up")
+			}
+			if !locked {
+				t.Errorf("expected the lock to be free while backing off between attempts")
+			}
+			return
+		default:
+		}
+		if impl.mutex.TryLock() {
+			locked = true
+			impl.mutex.Unlock()
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRevertCachedReleaseKeepsOtherUpdates(t *testing.T) {
+	defer func(releases []*common.Release) { releaseCache[CACHE_KEY] = releases }(releaseCache[CACHE_KEY])
+	impl := newTestReleaseNoteService(t)
+	previousReleases := newTestReleases()
+	edited := &common.Release{TagName: "v0.7.1", Body: "fixes"}
+	created := &common.Release{TagName: "v0.9.0", Body: "features"}
+	// v0.7.2 is published by another event while the edit of v0.7.1 and the creation of v0.9.0 are being persisted
+	current, _ := mergeWebhookRelease(previousReleases, edited)
+	current, _ = mergeWebhookRelease(current, created)
+	current = append(current, &common.Release{TagName: "v0.7.2"})
+	releaseCache[CACHE_KEY] = current
+
+	impl.revertCachedRelease(previousReleases, edited)
+	impl.revertCachedRelease(previousReleases, created)
+
+	tags := make(map[string]*common.Release)
+	for _, release := range releaseCache[CACHE_KEY] {
+		tags[release.TagName] = release
+	}
+	if len(tags) != 4 || tags["v0.7.2"] == nil {
+		t.Fatalf("expected the other update to be kept, got %v", tags)
+	}
+	if tags["v0.7.1"] == nil || tags["v0.7.1"].Body != "" {
+		t.Errorf("expected v0.7.1 to be reverted to the release before the edit, got %v", tags["v0.7.1"])
+	}
+	if tags["v0.9.0"] != nil {
+		t.Errorf("expected the created release to be removed")
+	}
+}
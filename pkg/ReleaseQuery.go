@@ -0,0 +1,206 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReleaseSortPublishedDesc = "published_desc"
+	ReleaseSortPublishedAsc  = "published_asc"
+	ReleaseSortVersionDesc   = "version_desc"
+	ReleaseSortVersionAsc    = "version_asc"
+)
+
+const (
+	DefaultReleaseQueryLimit = 20
+	MaxReleaseQueryLimit     = 100
+)
+
+const ReleaseQueryDateLayout = "2006-01-02"
+
+// releaseQueryBounds is the query once validated
+type releaseQueryBounds struct {
+	publishedFrom time.Time
+	publishedTo   time.Time
+	search        string
+	limit         int
+	offset        int
+}
+
+func (impl *ReleaseNoteServiceImpl) QueryReleases(q common.ReleaseQuery) (*common.ReleaseListPage, error) {
+	bounds, fieldErrors := validateReleaseQuery(q)
+	if len(fieldErrors) > 0 {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "invalid release query", UserMessage: fieldErrors}
+	}
+	// the release list filters the channel and keeps the cache order, newest version first
+	releases, err := impl.GetReleaseList(&common.ReleaseListFilter{Channel: q.Channel})
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		if matchesReleaseQuery(release, q, bounds) {
+			matched = append(matched, release)
+		}
+	}
+	sortReleases(matched, q.Sort)
+
+	if bounds.offset > len(matched) {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("cursor offset %d beyond %d releases", bounds.offset, len(matched)), UserMessage: []*common.FieldError{{Field: "cursor", Message: "cursor is past the last release"}}}
+	}
+	end := bounds.offset + bounds.limit
+	page := &common.ReleaseListPage{Total: len(matched)}
+	if end < len(matched) {
+		page.NextCursor = encodeReleaseCursor(end)
+	} else {
+		end = len(matched)
+	}
+	page.Releases = matched[bounds.offset:end]
+	return page, nil
+}
+
+func validateReleaseQuery(q common.ReleaseQuery) (*releaseQueryBounds, []*common.FieldError) {
+	bounds := &releaseQueryBounds{search: strings.ToLower(strings.TrimSpace(q.Search)), limit: q.Limit}
+	var fieldErrors []*common.FieldError
+	var err error
+	if len(q.PublishedFrom) > 0 {
+		bounds.publishedFrom, err = time.Parse(ReleaseQueryDateLayout, q.PublishedFrom)
+		if err != nil {
+			fieldErrors = append(fieldErrors, &common.FieldError{Field: "publishedFrom", Message: "should be a date like 2024-01-31"})
+		}
+	}
+	if len(q.PublishedTo) > 0 {
+		bounds.publishedTo, err = time.Parse(ReleaseQueryDateLayout, q.PublishedTo)
+		if err != nil {
+			fieldErrors = append(fieldErrors, &common.FieldError{Field: "publishedTo", Message: "should be a date like 2024-01-31"})
+		} else {
+			// the whole day is included
+			bounds.publishedTo = bounds.publishedTo.AddDate(0, 0, 1)
+		}
+	}
+	if !bounds.publishedFrom.IsZero() && !bounds.publishedTo.IsZero() && !bounds.publishedFrom.Before(bounds.publishedTo) {
+		fieldErrors = append(fieldErrors, &common.FieldError{Field: "publishedTo", Message: "should not be before publishedFrom"})
+	}
+	if len(q.Series) > 0 {
+		if _, err = util.ParseSemanticVersion(q.Series + ".0"); err != nil {
+			fieldErrors = append(fieldErrors, &common.FieldError{Field: "series", Message: "should be a minor line like 0.6"})
+		}
+	}
+	switch q.Sort {
+	case "", ReleaseSortPublishedDesc, ReleaseSortPublishedAsc, ReleaseSortVersionDesc, ReleaseSortVersionAsc:
+	default:
+		fieldErrors = append(fieldErrors, &common.FieldError{Field: "sort", Message: fmt.Sprintf("should be one of %s, %s, %s, %s", ReleaseSortPublishedDesc, ReleaseSortPublishedAsc, ReleaseSortVersionDesc, ReleaseSortVersionAsc)})
+	}
+	if bounds.limit == 0 {
+		bounds.limit = DefaultReleaseQueryLimit
+	} else if bounds.limit < 0 || bounds.limit > MaxReleaseQueryLimit {
+		fieldErrors = append(fieldErrors, &common.FieldError{Field: "limit", Message: fmt.Sprintf("should be between 1 and %d", MaxReleaseQueryLimit)})
+	}
+	if len(q.Cursor) > 0 {
+		bounds.offset, err = decodeReleaseCursor(q.Cursor)
+		if err != nil {
+			fieldErrors = append(fieldErrors, &common.FieldError{Field: "cursor", Message: "invalid cursor"})
+		}
+	}
+	return bounds, fieldErrors
+}
+
+func matchesReleaseQuery(release *common.Release, q common.ReleaseQuery, bounds *releaseQueryBounds) bool {
+	if q.Prerelease != nil && release.Prerelease != *q.Prerelease {
+		return false
+	}
+	// drafts are never published through the webhook, so no cached release is a draft
+	if q.Draft != nil && *q.Draft {
+		return false
+	}
+	if !isPublishedBetween(release, bounds.publishedFrom, bounds.publishedTo) {
+		return false
+	}
+	if len(q.Series) > 0 {
+		version, err := util.ParseSemanticVersion(release.TagName)
+		if err != nil || version.MinorLine() != strings.TrimPrefix(q.Series, "v") {
+			return false
+		}
+	}
+	if len(bounds.search) > 0 &&
+		!strings.Contains(strings.ToLower(release.TagName), bounds.search) &&
+		!strings.Contains(strings.ToLower(release.ReleaseName), bounds.search) &&
+		!strings.Contains(strings.ToLower(release.Body), bounds.search) {
+		return false
+	}
+	return true
+}
+
+// sortReleasesByVersion puts the non semver tags last, most recently published first
+func sortReleasesByVersion(releases []*common.Release) {
+	versions := make(map[*common.Release]*util.SemanticVersion, len(releases))
+	for _, item := range parseVersionedReleases(releases) {
+		versions[item.release] = item.version
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		left, right := versions[releases[i]], versions[releases[j]]
+		if left == nil && right == nil {
+			// non semver tags keep the same order whatever order they were fetched in
+			if !releases[i].PublishedAt.Equal(releases[j].PublishedAt) {
+				return releases[i].PublishedAt.After(releases[j].PublishedAt)
+			}
+			return releases[i].TagName < releases[j].TagName
+		}
+		if left == nil || right == nil {
+			return left != nil
+		}
+		return left.Compare(right) > 0
+	})
+}
+
+func sortReleasesByPublished(releases []*common.Release, oldestFirst bool) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		if oldestFirst {
+			return releases[i].PublishedAt.Before(releases[j].PublishedAt)
+		}
+		return releases[i].PublishedAt.After(releases[j].PublishedAt)
+	})
+}
+
+func sortReleases(releases []*common.Release, order string) {
+	switch order {
+	case ReleaseSortVersionDesc:
+		sortReleasesByVersion(releases)
+	case ReleaseSortVersionAsc:
+		sortReleasesByVersion(releases)
+		// the semver releases lead the version order, only they are reversed
+		versioned := len(parseVersionedReleases(releases))
+		for i, j := 0, versioned-1; i < j; i, j = i+1, j-1 {
+			releases[i], releases[j] = releases[j], releases[i]
+		}
+	case ReleaseSortPublishedAsc:
+		sortReleasesByPublished(releases, true)
+	default:
+		sortReleasesByPublished(releases, false)
+	}
+}
+
+// the cursor is the offset of the next page, encoded so that clients treat it as opaque
+func encodeReleaseCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeReleaseCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %s", cursor)
+	}
+	return offset, nil
+}
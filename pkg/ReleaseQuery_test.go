@@ -0,0 +1,177 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getReleaseTags(releases []*common.Release) string {
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	return strings.Join(tags, ",")
+}
+
+func TestSortReleasesByVersion(t *testing.T) {
+	publishedAt := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	tests := []struct {
+		name     string
+		releases []*common.Release
+		tags     string
+	}{
+		{
+			name:     "numeric comparison",
+			releases: []*common.Release{{TagName: "v0.6.9"}, {TagName: "v0.6.10"}, {TagName: "v0.10.0"}, {TagName: "v0.9.1"}},
+			tags:     "v0.10.0,v0.9.1,v0.6.10,v0.6.9",
+		},
+		{
+			name:     "pre-releases below their release",
+			releases: []*common.Release{{TagName: "v0.7.0-rc.1"}, {TagName: "v0.7.0"}, {TagName: "v0.7.0-rc.2"}, {TagName: "v0.6.10"}, {TagName: "v0.7.0-beta.1"}},
+			tags:     "v0.7.0,v0.7.0-rc.2,v0.7.0-rc.1,v0.7.0-beta.1,v0.6.10",
+		},
+		{
+			name:     "without leading v",
+			releases: []*common.Release{{TagName: "0.6.1"}, {TagName: "v0.6.2"}, {TagName: "0.6.3"}},
+			tags:     "0.6.3,v0.6.2,0.6.1",
+		},
+		{
+			name: "non semver tags last",
+			releases: []*common.Release{
+				{TagName: "nightly", PublishedAt: publishedAt},
+				{TagName: "v0.6.0"},
+				{TagName: "hotfix-b", PublishedAt: publishedAt.Add(time.Hour)},
+				{TagName: "v0.7.0-rc.1"},
+				{TagName: "hotfix-a", PublishedAt: publishedAt.Add(time.Hour)},
+				{TagName: "v0.7.0"},
+			},
+			tags: "v0.7.0,v0.7.0-rc.1,v0.6.0,hotfix-a,hotfix-b,nightly",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sortReleasesByVersion(test.releases)
+			if tags := getReleaseTags(test.releases); tags != test.tags {
+				t.Errorf("expected %s, got %s", test.tags, tags)
+			}
+			// the order of non semver tags doesn't depend on the order they were fetched in
+			reversed := make([]*common.Release, 0, len(test.releases))
+			for i := len(test.releases) - 1; i >= 0; i-- {
+				reversed = append(reversed, test.releases[i])
+			}
+			sortReleasesByVersion(reversed)
+			if tags := getReleaseTags(reversed); tags != test.tags {
+				t.Errorf("expected %s whatever the fetch order, got %s", test.tags, tags)
+			}
+		})
+	}
+}
+
+func newTestQueryReleaseNoteService(t *testing.T) *ReleaseNoteServiceImpl {
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 10, 0, 0, 0, time.UTC)
+	}
+	return newTestReleaseNoteService(t,
+		&common.Release{TagName: "v0.8.0-rc.1", Prerelease: true, PublishedAt: day(time.March, 20), Body: "Preview of the helm chart upgrade"},
+		&common.Release{TagName: "v0.7.2", PublishedAt: day(time.March, 25), Body: "Backport of the HELM fixes"},
+		&common.Release{TagName: "v0.7.1", PublishedAt: day(time.February, 10), ReleaseName: "Helm hotfix"},
+		&common.Release{TagName: "v0.7.0", PublishedAt: day(time.February, 1), Body: "New dashboard"},
+		&common.Release{TagName: "v0.6.1", PublishedAt: day(time.March, 1), Body: "Security fixes"},
+		&common.Release{TagName: "v0.6.0", PublishedAt: day(time.January, 5)},
+		&common.Release{TagName: "hotfix-helm", PublishedAt: day(time.March, 5)},
+	)
+}
+
+func TestQueryReleases(t *testing.T) {
+	impl := newTestQueryReleaseNoteService(t)
+	yes, no := true, false
+	tests := []struct {
+		name  string
+		query common.ReleaseQuery
+		tags  string
+		total int
+	}{
+		{name: "no filter", query: common.ReleaseQuery{}, tags: "v0.7.2,v0.8.0-rc.1,hotfix-helm,v0.6.1,v0.7.1,v0.7.0,v0.6.0", total: 7},
+		{name: "search sorted by version", query: common.ReleaseQuery{Search: "helm", Sort: ReleaseSortVersionDesc}, tags: "v0.8.0-rc.1,v0.7.2,v0.7.1,hotfix-helm", total: 4},
+		{name: "stable search in february", query: common.ReleaseQuery{Search: " Helm ", Prerelease: &no, PublishedFrom: "2024-02-01", PublishedTo: "2024-02-29"}, tags: "v0.7.1", total: 1},
+		{name: "series oldest first", query: common.ReleaseQuery{Series: "0.7", Sort: ReleaseSortPublishedAsc}, tags: "v0.7.0,v0.7.1,v0.7.2", total: 3},
+		{name: "prereleases of march", query: common.ReleaseQuery{Prerelease: &yes, PublishedFrom: "2024-03-01"}, tags: "v0.8.0-rc.1", total: 1},
+		{name: "inclusive publish dates", query: common.ReleaseQuery{PublishedFrom: "2024-03-01", PublishedTo: "2024-03-05", Sort: ReleaseSortVersionAsc}, tags: "v0.6.1,hotfix-helm", total: 2},
+		{name: "first page by version", query: common.ReleaseQuery{Prerelease: &no, Sort: ReleaseSortVersionAsc, Limit: 2}, tags: "v0.6.0,v0.6.1", total: 6},
+		{name: "drafts", query: common.ReleaseQuery{Draft: &yes}, tags: "", total: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, err := impl.QueryReleases(test.query)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tags := getReleaseTags(page.Releases); tags != test.tags || page.Total != test.total {
+				t.Errorf("expected %s of %d releases, got %s of %d", test.tags, test.total, tags, page.Total)
+			}
+		})
+	}
+}
+
+func TestQueryReleasesFollowsCursor(t *testing.T) {
+	impl := newTestQueryReleaseNoteService(t)
+	query := common.ReleaseQuery{Sort: ReleaseSortVersionDesc, Limit: 3}
+	var pages []string
+	for {
+		page, err := impl.QueryReleases(query)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		pages = append(pages, getReleaseTags(page.Releases))
+		if len(page.NextCursor) == 0 {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if expected := "v0.8.0-rc.1,v0.7.2,v0.7.1|v0.7.0,v0.6.1,v0.6.0|hotfix-helm"; strings.Join(pages, "|") != expected {
+		t.Errorf("expected pages %s, got %s", expected, strings.Join(pages, "|"))
+	}
+
+	_, err := impl.QueryReleases(common.ReleaseQuery{Cursor: encodeReleaseCursor(8)})
+	if apiErr, ok := err.(*util.ApiError); !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+		t.Errorf("expected a cursor past the last release to be refused, got %v", err)
+	}
+}
+
+func TestQueryReleasesReportsEveryInvalidField(t *testing.T) {
+	impl := newTestQueryReleaseNoteService(t)
+	tests := []struct {
+		name   string
+		query  common.ReleaseQuery
+		fields string
+	}{
+		{name: "malformed dates", query: common.ReleaseQuery{PublishedFrom: "01/02/2024", PublishedTo: "2024-13-01"}, fields: "publishedFrom,publishedTo"},
+		{name: "inverted dates", query: common.ReleaseQuery{PublishedFrom: "2024-03-02", PublishedTo: "2024-03-01"}, fields: "publishedTo"},
+		{name: "everything wrong", query: common.ReleaseQuery{Series: "latest", Sort: "name", Limit: MaxReleaseQueryLimit + 1, Cursor: "%%%"}, fields: "series,sort,limit,cursor"},
+		{name: "negative limit", query: common.ReleaseQuery{Limit: -1, Series: "0.7"}, fields: "limit"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := impl.QueryReleases(test.query)
+			apiErr, ok := err.(*util.ApiError)
+			if !ok || apiErr.HttpStatusCode != http.StatusBadRequest {
+				t.Fatalf("expected a bad request, got %v", err)
+			}
+			fieldErrors, ok := apiErr.UserMessage.([]*common.FieldError)
+			if !ok {
+				t.Fatalf("expected field errors, got %v", apiErr.UserMessage)
+			}
+			var fields []string
+			for _, fieldError := range fieldErrors {
+				fields = append(fields, fieldError.Field)
+			}
+			if strings.Join(fields, ",") != test.fields {
+				t.Errorf("expected errors on %s, got %v", test.fields, fields)
+			}
+		})
+	}
+}
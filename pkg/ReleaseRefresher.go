@@ -0,0 +1,151 @@
+package pkg
+
+import (
+	"context"
+	"github.com/devtron-labs/central-api/internal/util"
+	"sync"
+	"time"
+)
+
+const releaseRefreshRetryDelay = 10 * time.Second
+
+type releaseRefresherState struct {
+	mutex       sync.Mutex
+	cancel      context.CancelFunc
+	done        chan struct{}
+	refreshedAt time.Time
+}
+
+func (impl *ReleaseNoteServiceImpl) Start(ctx context.Context) {
+	impl.refresher.mutex.Lock()
+	defer impl.refresher.mutex.Unlock()
+	if impl.refresher.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	impl.refresher.cancel = cancel
+	impl.refresher.done = done
+	go func() {
+		defer close(done)
+		var recovery sync.WaitGroup
+		recovery.Add(1)
+		go func() {
+			defer recovery.Done()
+			impl.recoverFromFallbackPeriodically(ctx)
+		}()
+		impl.refreshReleasesPeriodically(ctx)
+		recovery.Wait()
+	}()
+}
+
+// Stop waits for the background refresh and recovery to return
+func (impl *ReleaseNoteServiceImpl) Stop() {
+	impl.refresher.mutex.Lock()
+	cancel, done := impl.refresher.cancel, impl.refresher.done
+	impl.refresher.cancel, impl.refresher.done = nil, nil
+	impl.refresher.mutex.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (impl *ReleaseNoteServiceImpl) markReleasesRefreshed(at time.Time) {
+	impl.refresher.mutex.Lock()
+	defer impl.refresher.mutex.Unlock()
+	impl.refresher.refreshedAt = at
+}
+
+func (impl *ReleaseNoteServiceImpl) getReleasesRefreshedAt() time.Time {
+	impl.refresher.mutex.Lock()
+	defer impl.refresher.mutex.Unlock()
+	return impl.refresher.refreshedAt
+}
+
+// nextRefreshDelay is zero when the background refresh is disabled
+func (impl *ReleaseNoteServiceImpl) nextRefreshDelay(now time.Time) time.Duration {
+	ttl := impl.releaseCacheConfig.TTL
+	if ttl <= 0 {
+		return impl.releaseCacheConfig.RefreshInterval
+	}
+	refreshAfter := ttl - impl.releaseCacheConfig.RefreshLeadTime
+	refreshedAt := impl.getReleasesRefreshedAt()
+	if refreshedAt.IsZero() {
+		// the first refresh is due a full period after the fetch on initialisation
+		return refreshAfter
+	}
+	delay := refreshedAt.Add(refreshAfter).Sub(now)
+	if delay < releaseRefreshRetryDelay {
+		// the last refresh is overdue, it failed or the source could not be reached
+		delay = releaseRefreshRetryDelay
+	}
+	return delay
+}
+
+func (impl *ReleaseNoteServiceImpl) refreshReleasesPeriodically(ctx context.Context) {
+	delay := impl.nextRefreshDelay(time.Now())
+	if delay <= 0 {
+		impl.logger.Infow("background refresh of releases disabled")
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			impl.logger.Infow("background refresh of releases stopped")
+			return
+		case <-timer.C:
+		}
+		impl.refreshReleasesWithTimeout(ctx)
+		// the overrides of the other instances are picked up on every refresh
+		_ = impl.loadAdminState()
+		timer.Reset(impl.nextRefreshDelay(time.Now()))
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) refreshReleasesWithTimeout(ctx context.Context) {
+	timeout := impl.releaseCacheConfig.RefreshTimeout
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := impl.refreshReleases(ctx)
+	if err == context.DeadlineExceeded {
+		impl.logger.Warnw("background refresh of releases timed out, abandoned this attempt", "timeout", timeout)
+	} else if err == context.Canceled {
+		impl.logger.Infow("background refresh of releases cancelled")
+	} else if err != nil {
+		impl.logger.Errorw("error in background refresh of releases", "err", err)
+	} else {
+		impl.logModuleLintReport()
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) refreshReleases(ctx context.Context) error {
+	releases, err := impl.getReleasesFromGithubWithRetry(ctx)
+	impl.recordSync(len(releases), err)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+	if impl.blobConfig.CloudConfigured {
+		generation, err := util.GetContentHash(releases)
+		if err != nil {
+			return err
+		}
+		if generation == impl.getReleasesGeneration() {
+			return nil
+		}
+		impl.setCachedReleases(releases)
+		return impl.publishReleasesGeneration(releases)
+	}
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	return impl.updateReleaseNotesInDb(releases, false)
+}
@@ -0,0 +1,143 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/pkg/releaseNote"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRefreshReleasesSkipsUnchangedReleasesInDb(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	impl.releaseSource = &fakeReleaseSource{releases: newTestReleases()}
+	fetched, err := impl.getReleasesFromGithubWithRetry(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// the active row holds the releases as they come back from the database
+	content, err := json.Marshal(fetched)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var stored []*common.Release
+	err = json.Unmarshal(content, &stored)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	impl.releaseNoteRepository = &fakeReleaseNoteRepository{releaseNote: &releaseNote.ReleaseNote{Id: 1, ReleaseNote: stored, IsActive: true}}
+
+	// the fake repository has no connection, a write would fail the refresh
+	err = impl.refreshReleases(context.Background())
+	if err != nil {
+		t.Fatalf("expected the unchanged releases not to be written, got %v", err)
+	}
+}
+
+func TestStartRefreshesReleasesAheadOfExpiry(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	source := &fakeReleaseSource{releases: newTestReleases()}
+	impl.releaseSource = source
+	impl.releaseCacheConfig.TTL = 50 * time.Millisecond
+	impl.releaseCacheConfig.RefreshLeadTime = 20 * time.Millisecond
+	// nothing listens on the port, storing the refreshed releases fails without stopping the refresh
+	db := pg.Connect(&pg.Options{Addr: "127.0.0.1:1"})
+	defer db.Close()
+	impl.releaseNoteRepository = &fakeReleaseNoteRepository{db: db}
+
+	started := time.Now()
+	impl.Start(context.Background())
+	deadline := time.Now().Add(5 * time.Second)
+	for source.getCalls() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	impl.Stop()
+	if source.getCalls() == 0 {
+		t.Fatalf("expected the releases to be fetched without a read of the releases")
+	}
+	if refreshedAt := impl.getReleasesRefreshedAt(); refreshedAt.Before(started) {
+		t.Errorf("expected the refresh to be recorded, got %v", refreshedAt)
+	}
+	calls := source.getCalls()
+	time.Sleep(50 * time.Millisecond)
+	if source.getCalls() != calls {
+		t.Errorf("expected the refresh to stop with the service")
+	}
+}
+
+func TestRefreshReleasesAbandonsFetchPastTimeout(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	source := &fakeReleaseSource{releases: []*common.Release{{TagName: "v0.9.0"}}, blocking: true}
+	impl.releaseSource = source
+	impl.releaseCacheConfig.RefreshTimeout = 20 * time.Millisecond
+	var logs bytes.Buffer
+	impl.logger = zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&logs), zapcore.DebugLevel)).Sugar()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		impl.refreshReleasesWithTimeout(context.Background())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the refresh to be abandoned after the timeout")
+	}
+
+	if source.getCalls() != 1 {
+		t.Errorf("expected the timed out fetch not to be retried, got %d calls", source.getCalls())
+	}
+	if !strings.Contains(logs.String(), "background refresh of releases timed out") {
+		t.Errorf("expected the timeout to be logged, got %s", logs.String())
+	}
+	if refreshedAt := impl.getReleasesRefreshedAt(); !refreshedAt.IsZero() {
+		t.Errorf("expected the refresh not to be recorded, got %v", refreshedAt)
+	}
+	releases, err := impl.getStoredReleases()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	if strings.Join(tags, ",") != "v0.8.0-rc.1,v0.7.1,v0.7.0" {
+		t.Errorf("expected the cached releases to be left as they were, got %v", tags)
+	}
+}
+
+func TestNextRefreshDelay(t *testing.T) {
+	now := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	tests := []struct {
+		name            string
+		ttl             time.Duration
+		leadTime        time.Duration
+		refreshInterval time.Duration
+		refreshedAt     time.Time
+		delay           time.Duration
+	}{
+		{name: "disabled"},
+		{name: "interval without ttl", refreshInterval: time.Hour, refreshedAt: now, delay: time.Hour},
+		{name: "first refresh", ttl: time.Hour, leadTime: 5 * time.Minute, delay: 55 * time.Minute},
+		{name: "ahead of expiry", ttl: time.Hour, leadTime: 5 * time.Minute, refreshedAt: now.Add(-15 * time.Minute), delay: 40 * time.Minute},
+		{name: "overdue", ttl: time.Hour, leadTime: 5 * time.Minute, refreshedAt: now.Add(-2 * time.Hour), delay: releaseRefreshRetryDelay},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			impl.releaseCacheConfig.TTL = test.ttl
+			impl.releaseCacheConfig.RefreshLeadTime = test.leadTime
+			impl.releaseCacheConfig.RefreshInterval = test.refreshInterval
+			impl.markReleasesRefreshed(test.refreshedAt)
+			if delay := impl.nextRefreshDelay(now); delay != test.delay {
+				t.Errorf("expected a delay of %s, got %s", test.delay, delay)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"regexp"
+	"strings"
+)
+
+const (
+	RollbackSafe   = "safe"
+	RollbackUnsafe = "unsafe"
+)
+
+// rollbackRegex matches <!--rollback: unsafe reason="database schema migration"-->
+var rollbackRegex = regexp.MustCompile(`<!--\s*rollback:\s*([^\s>]*)(?:\s+reason="([^"]*)")?\s*-->`)
+
+func (impl *ReleaseNoteServiceImpl) getRollbackGuidance(releaseInfo *common.Release) {
+	releaseInfo.RollbackSafe = true
+	releaseInfo.RollbackNotes = ""
+	match := rollbackRegex.FindStringSubmatch(releaseInfo.Body)
+	if match == nil {
+		return
+	}
+	releaseInfo.RollbackSafe = strings.ToLower(match[1]) != RollbackUnsafe
+	releaseInfo.RollbackNotes = strings.TrimSpace(match[2])
+}
+
+func lintRollbackGuidance(release *common.Release) []string {
+	match := rollbackRegex.FindStringSubmatch(release.Body)
+	if match == nil {
+		return nil
+	}
+	switch strings.ToLower(match[1]) {
+	case RollbackSafe:
+		return nil
+	case RollbackUnsafe:
+		if len(strings.TrimSpace(match[2])) == 0 {
+			return []string{"rollback marker is unsafe without a reason"}
+		}
+		return nil
+	default:
+		return []string{fmt.Sprintf("rollback marker value %q is neither %s nor %s", match[1], RollbackSafe, RollbackUnsafe)}
+	}
+}
+
+// getRollbackSafety returns the first unsafe hop as the point of no return
+func getRollbackSafety(path []*common.Release) (bool, *common.RollbackHop) {
+	for _, release := range path {
+		if !release.RollbackSafe {
+			return false, &common.RollbackHop{TagName: release.TagName, Notes: release.RollbackNotes}
+		}
+	}
+	return true, nil
+}
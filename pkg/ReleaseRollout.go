@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"hash/fnv"
+	"net/http"
+	"sort"
+)
+
+const FullRolloutPercentage = 100
+
+func (impl *ReleaseNoteServiceImpl) getRolloutPercentage(tag string) int {
+	impl.adminStateMutex.RLock()
+	defer impl.adminStateMutex.RUnlock()
+	if percentage, ok := impl.adminState.RolloutPercentages[util.NormalizeVersionTag(tag)]; ok {
+		return percentage
+	}
+	return FullRolloutPercentage
+}
+
+// isRolledOutTo keeps an installation in the same bucket, callers without id get the full rollout
+func (impl *ReleaseNoteServiceImpl) isRolledOutTo(release *common.Release, installationId string) bool {
+	if len(installationId) == 0 {
+		return true
+	}
+	percentage := impl.getRolloutPercentage(release.TagName)
+	if percentage >= FullRolloutPercentage {
+		return true
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(installationId + "/" + util.NormalizeVersionTag(release.TagName)))
+	return int(hash.Sum32()%FullRolloutPercentage) < percentage
+}
+
+func (impl *ReleaseNoteServiceImpl) isUpgradeTargetFor(release *common.Release, installationId string) bool {
+	return isUpgradeTarget(release) && impl.isRolledOutTo(release, installationId)
+}
+
+func (impl *ReleaseNoteServiceImpl) SetRolloutPercentage(tag string, percentage int) error {
+	if percentage < 0 || percentage > FullRolloutPercentage {
+		return &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid rollout percentage %d", percentage), UserMessage: "rollout percentage should be between 0 and 100"}
+	}
+	release, err := impl.findCachedRelease(tag)
+	if err != nil {
+		return err
+	}
+	return impl.updateAdminState(func(state *adminState.AdminState) {
+		normalizedTag := util.NormalizeVersionTag(release.TagName)
+		if percentage == FullRolloutPercentage {
+			delete(state.RolloutPercentages, normalizedTag)
+			return
+		}
+		if state.RolloutPercentages == nil {
+			state.RolloutPercentages = make(map[string]int)
+		}
+		state.RolloutPercentages[normalizedTag] = percentage
+	})
+}
+
+// GetReleaseRollouts returns the releases not fully rolled out, newest version first
+func (impl *ReleaseNoteServiceImpl) GetReleaseRollouts() ([]*common.ReleaseRollout, error) {
+	impl.adminStateMutex.RLock()
+	rollouts := make([]*common.ReleaseRollout, 0, len(impl.adminState.RolloutPercentages))
+	for tag, percentage := range impl.adminState.RolloutPercentages {
+		rollouts = append(rollouts, &common.ReleaseRollout{TagName: tag, Percentage: percentage})
+	}
+	impl.adminStateMutex.RUnlock()
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	tagByVersion := make(map[string]string, len(releases))
+	for _, release := range releases {
+		tagByVersion[util.NormalizeVersionTag(release.TagName)] = release.TagName
+	}
+	for _, rollout := range rollouts {
+		if tag, ok := tagByVersion[rollout.TagName]; ok {
+			rollout.TagName = tag
+		}
+	}
+	sort.SliceStable(rollouts, func(i, j int) bool {
+		iVersion, iErr := util.ParseSemanticVersion(rollouts[i].TagName)
+		jVersion, jErr := util.ParseSemanticVersion(rollouts[j].TagName)
+		if iErr != nil || jErr != nil {
+			return rollouts[i].TagName > rollouts[j].TagName
+		}
+		return iVersion.Compare(jVersion) > 0
+	})
+	return rollouts, nil
+}
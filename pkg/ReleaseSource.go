@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type ReleaseSource interface {
+	FetchReleases(ctx context.Context) ([]*common.Release, error)
+}
+
+func newReleaseSource(logger *zap.SugaredLogger, config *util.ReleaseSourceConfig, service *ReleaseNoteServiceImpl) (ReleaseSource, error) {
+	switch config.ReleaseSource {
+	case util.ReleaseSourceGithub, "":
+		return &githubReleaseSource{service: service}, nil
+	case util.ReleaseSourceStatic:
+		if len(config.StaticReleasesLocation) == 0 {
+			return nil, fmt.Errorf("static release source needs RELEASE_SOURCE_STATIC_LOCATION")
+		}
+		return &staticReleaseSource{logger: logger, location: config.StaticReleasesLocation, httpClient: http.DefaultClient, normalize: service.normalizeRelease}, nil
+	case util.ReleaseSourceGitlab:
+		if len(config.GitlabURL) == 0 || len(config.GitlabProject) == 0 {
+			return nil, fmt.Errorf("gitlab release source needs RELEASE_SOURCE_GITLAB_URL and RELEASE_SOURCE_GITLAB_PROJECT")
+		}
+		return &gitlabReleaseSource{service: service, baseURL: strings.TrimSuffix(config.GitlabURL, "/"), project: config.GitlabProject, token: config.GitlabToken, httpClient: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown release source %s", config.ReleaseSource)
+	}
+}
+
+type githubReleaseSource struct {
+	service *ReleaseNoteServiceImpl
+}
+
+func (source *githubReleaseSource) FetchReleases(ctx context.Context) ([]*common.Release, error) {
+	releases, err := source.service.fetchReleasesFromGithub(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed operation on fetching releases from github, %w", err)
+	}
+	return releases, nil
+}
+
+type staticReleaseSource struct {
+	logger     *zap.SugaredLogger
+	location   string
+	httpClient *http.Client
+	normalize  func(release *common.Release)
+}
+
+func (source *staticReleaseSource) FetchReleases(ctx context.Context) ([]*common.Release, error) {
+	content, err := source.read(ctx)
+	if err != nil {
+		source.logger.Errorw("error in reading static releases", "location", source.location, "err", err)
+		return nil, err
+	}
+	releases, err := parseReleasesJson(content, source.normalize)
+	if err != nil {
+		source.logger.Errorw("malformed static releases", "location", source.location, "err", err)
+		return nil, err
+	}
+	return releases, nil
+}
+
+// parseReleasesJson drops the entries without tag
+func parseReleasesJson(content []byte, normalize func(release *common.Release)) ([]*common.Release, error) {
+	var releases []*common.Release
+	err := json.Unmarshal(content, &releases)
+	if err != nil {
+		return nil, err
+	}
+	validReleases := make([]*common.Release, 0, len(releases))
+	for _, release := range releases {
+		if release == nil || len(release.TagName) == 0 {
+			continue
+		}
+		normalize(release)
+		validReleases = append(validReleases, release)
+	}
+	return validReleases, nil
+}
+
+func (source *staticReleaseSource) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(source.location, "http://") && !strings.HasPrefix(source.location, "https://") {
+		return ioutil.ReadFile(source.location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := source.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
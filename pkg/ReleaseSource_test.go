@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetReleasesFromSourceRetriesAndSorts(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	publishedAt := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	source := &fakeReleaseSource{
+		errs: []error{errors.New("connection reset")},
+		releases: []*common.Release{
+			impl.NormalizeRelease(&RawRelease{TagName: "v0.6.1", Body: "fixes", PublishedAt: publishedAt}),
+			impl.NormalizeRelease(&RawRelease{TagName: "v0.7.0", Body: "<!--upgrade-prerequisites-required-->\nrun the migration\n<!--upgrade-prerequisites-required-->", PublishedAt: publishedAt.Add(time.Hour)}),
+			impl.NormalizeRelease(&RawRelease{TagName: "v0.6.10", Body: "more fixes", PublishedAt: publishedAt.Add(-time.Hour)}),
+		},
+	}
+	impl.releaseSource = source
+
+	releases, err := impl.getReleasesFromGithubWithRetry(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if source.getCalls() != 2 {
+		t.Errorf("expected the failed fetch to be retried once, got %d fetches", source.getCalls())
+	}
+	var tags []string
+	for _, release := range releases {
+		tags = append(tags, release.TagName)
+	}
+	if fmt.Sprint(tags) != "[v0.7.0 v0.6.10 v0.6.1]" {
+		t.Errorf("expected releases sorted by version, got %v", tags)
+	}
+	if !releases[0].Prerequisite || releases[0].PrerequisiteMessage != "run the migration" {
+		t.Errorf("expected the prerequisite of v0.7.0 to be derived, got %v %q", releases[0].Prerequisite, releases[0].PrerequisiteMessage)
+	}
+	if releases[1].Prerequisite {
+		t.Errorf("expected no prerequisite for v0.6.10")
+	}
+}
+
+func TestGetReleasesFromSourceGivesUpAfterRetries(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	failure := errors.New("connection reset")
+	source := &fakeReleaseSource{errs: []error{failure, failure, failure, failure}}
+	impl.releaseSource = source
+
+	_, err := impl.getReleasesFromGithubWithRetry(context.Background())
+	if err == nil {
+		t.Fatal("expected an error once every attempt failed")
+	}
+	if source.getCalls() != 3 {
+		t.Errorf("expected 3 fetches, got %d", source.getCalls())
+	}
+}
+
+func TestGitlabReleaseSourceFetchReleases(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/devtron-labs%2Fdevtron/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[
+				{"tag_name": "v0.8.0", "name": "v0.8.0", "description": "not yet", "created_at": "2024-04-01T00:00:00Z", "upcoming_release": true},
+				{"tag_name": "v0.7.0", "name": "Seven", "description": "<!--upgrade-prerequisites-required-->\nrun the migration\n<!--upgrade-prerequisites-required-->",
+				 "created_at": "2024-03-01T00:00:00Z", "released_at": "2024-03-02T00:00:00Z", "commit": {"id": "abc123"},
+				 "assets": {"links": [{"id": 7, "name": "devtron.spdx.json", "url": "https://gitlab.example.com/devtron.spdx.json"}]},
+				 "_links": {"self": "https://gitlab.example.com/devtron-labs/devtron/-/releases/v0.7.0"}}
+			]`)
+		case "2":
+			w.Header().Set("X-Next-Page", "")
+			fmt.Fprint(w, `[{"tag_name": "v0.6.0", "name": "", "description": "fixes", "created_at": "2024-02-01T00:00:00Z"}]`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer server.Close()
+	source := &gitlabReleaseSource{service: impl, baseURL: server.URL, project: "devtron-labs/devtron", httpClient: server.Client()}
+
+	releases, err := source.FetchReleases(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("expected the upcoming release to be left out, got %d releases", len(releases))
+	}
+	release := releases[0]
+	if release.TagName != "v0.7.0" || release.ReleaseName != "Seven" || release.TargetCommitish != "abc123" {
+		t.Errorf("unexpected release %+v", release)
+	}
+	if !release.PublishedAt.Equal(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the release time as publish time, got %v", release.PublishedAt)
+	}
+	if !release.Prerequisite || release.PrerequisiteMessage != "run the migration" {
+		t.Errorf("expected the prerequisite to be derived, got %v %q", release.Prerequisite, release.PrerequisiteMessage)
+	}
+	if release.TagLink != "https://gitlab.example.com/devtron-labs/devtron/-/releases/v0.7.0" {
+		t.Errorf("expected the self link as tag link, got %s", release.TagLink)
+	}
+	if len(release.Assets) != 1 || release.Assets[0].Name != "devtron.spdx.json" {
+		t.Errorf("expected the asset link to be kept, got %+v", release.Assets)
+	}
+	if len(release.Attestations) != 1 || release.Attestations[0].Kind != AttestationKindSbom {
+		t.Errorf("expected the sbom attestation, got %+v", release.Attestations)
+	}
+	if releases[1].TagName != "v0.6.0" || releases[1].ReleaseName != "v0.6.0" {
+		t.Errorf("expected the release of the second page named after its tag, got %+v", releases[1])
+	}
+	if releases[1].TagLink != server.URL+"/devtron-labs/devtron/-/releases/v0.6.0" {
+		t.Errorf("expected the tag link on the releases page, got %s", releases[1].TagLink)
+	}
+}
+
+func TestGitlabReleaseSourceFailsOnErrorStatus(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	source := &gitlabReleaseSource{service: impl, baseURL: server.URL, project: "42", httpClient: server.Client()}
+
+	_, err := source.FetchReleases(context.Background())
+	if err == nil {
+		t.Fatal("expected an error on an unauthorized response")
+	}
+}
+
+func TestReleaseAssetProxyRefusesNonGithubSource(t *testing.T) {
+	for _, source := range []string{util.ReleaseSourceGitlab, util.ReleaseSourceStatic} {
+		impl := newTestReleaseNoteService(t)
+		impl.releaseSourceConfig.ReleaseSource = source
+		assetService := NewReleaseAssetServiceImpl(impl.logger, impl.client, impl, &util.ReleaseAssetConfig{ProxyEnabled: true}, impl.releaseSourceConfig)
+
+		_, err := assetService.DownloadReleaseAsset(context.Background(), "v0.7.0", "devtron.spdx.json", "")
+		apiErr, ok := err.(*util2.ApiError)
+		if !ok || apiErr.HttpStatusCode != http.StatusNotFound {
+			t.Errorf("expected not found for the %s source, got %v", source, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/internal/util"
+	"sync"
+	"time"
+)
+
+const MaxTagLookupMisses = 1024
+
+// tagLookupMissCache remembers the tags github reported missing
+type tagLookupMissCache struct {
+	mutex       sync.Mutex
+	expiryByTag map[string]time.Time
+}
+
+func (cache *tagLookupMissCache) isMissing(tag string, now time.Time) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	expiry, ok := cache.expiryByTag[util.NormalizeVersionTag(tag)]
+	return ok && now.Before(expiry)
+}
+
+func (cache *tagLookupMissCache) addMissing(tag string, now time.Time, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if len(cache.expiryByTag) >= MaxTagLookupMisses {
+		for missingTag, expiry := range cache.expiryByTag {
+			if !now.Before(expiry) {
+				delete(cache.expiryByTag, missingTag)
+			}
+		}
+	}
+	if cache.expiryByTag == nil || len(cache.expiryByTag) >= MaxTagLookupMisses {
+		cache.expiryByTag = make(map[string]time.Time)
+	}
+	cache.expiryByTag[util.NormalizeVersionTag(tag)] = now.Add(ttl)
+}
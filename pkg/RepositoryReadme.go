@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"context"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/google/go-github/github"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type readmeCache struct {
+	mutex     sync.Mutex
+	content   string
+	fetchedAt time.Time
+}
+
+func (impl *ReleaseNoteServiceImpl) GetRepositoryReadme() (string, error) {
+	config := impl.client.GitHubConfig
+	if !config.GitHubReadmeEnabled {
+		return "", &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: "readme is not enabled", UserMessage: "readme is not enabled"}
+	}
+	impl.readme.mutex.Lock()
+	defer impl.readme.mutex.Unlock()
+	if !impl.readme.fetchedAt.IsZero() && time.Since(impl.readme.fetchedAt) < config.GitHubReadmeTTL {
+		return impl.readme.content, nil
+	}
+	ctx := context.Background()
+	readme, _, err := impl.client.GitHubClient.Repositories.GetReadme(ctx, config.GitHubOrg, config.GitHubRepo, nil)
+	if err != nil {
+		responseErr, ok := err.(*github.ErrorResponse)
+		if ok && responseErr.Response.StatusCode == http.StatusNotFound {
+			impl.logger.Infow("readme not found in repo", "repo", config.GitHubRepo)
+			return "", &util.ApiError{HttpStatusCode: http.StatusNotFound, InternalMessage: err.Error(), UserMessage: "readme not found"}
+		}
+		impl.logger.Errorw("error in fetching readme from github", "repo", config.GitHubRepo, "err", err)
+		return "", err
+	}
+	content, err := readme.GetContent()
+	if err != nil {
+		impl.logger.Errorw("error in decoding readme", "repo", config.GitHubRepo, "err", err)
+		return "", err
+	}
+	rendered, _, err := impl.client.GitHubClient.Markdown(ctx, content, &github.MarkdownOptions{Mode: "gfm", Context: config.GitHubOrg + "/" + config.GitHubRepo})
+	if err != nil {
+		impl.logger.Errorw("error in rendering readme", "repo", config.GitHubRepo, "err", err)
+		return "", err
+	}
+	impl.readme.content = rendered
+	impl.readme.fetchedAt = time.Now()
+	return rendered, nil
+}
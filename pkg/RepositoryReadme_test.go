@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestReadmeService(t *testing.T, readme string) (*ReleaseNoteServiceImpl, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/devtron-labs/devtron/readme":
+			if len(readme) == 0 {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintf(w, `{"name": "README.md", "encoding": "base64", "content": %q}`, base64.StdEncoding.EncodeToString([]byte(readme)))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/markdown":
+			request := struct {
+				Text    string `json:"text"`
+				Mode    string `json:"mode"`
+				Context string `json:"context"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("unexpected markdown request %v", err)
+			}
+			fmt.Fprintf(w, "<article data-mode=%q data-context=%q>%s</article>", request.Mode, request.Context, request.Text)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("GITHUB_HOST", server.URL)
+	t.Setenv("GITHUB_ORG", "devtron-labs")
+	client, err := util.NewGitHubClient(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.GitHubConfig.GitHubReadmeEnabled = true
+	client.GitHubConfig.GitHubReadmeTTL = time.Hour
+	impl := newTestReleaseNoteService(t)
+	impl.client = client
+	return impl, &requests
+}
+
+func TestGetRepositoryReadmeRendersAndCaches(t *testing.T) {
+	impl, requests := newTestReadmeService(t, "# Devtron\nThe software delivery workflow for kubernetes")
+
+	for i := 0; i < 2; i++ {
+		readme, err := impl.GetRepositoryReadme()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if readme != `<article data-mode="gfm" data-context="devtron-labs/devtron"># Devtron`+"\n"+`The software delivery workflow for kubernetes</article>` {
+			t.Errorf("expected the readme rendered by github, got %q", readme)
+		}
+	}
+	if count := atomic.LoadInt32(requests); count != 2 {
+		t.Errorf("expected the readme to be fetched and rendered once, got %d requests", count)
+	}
+}
+
+func TestGetRepositoryReadmeNotFound(t *testing.T) {
+	impl, _ := newTestReadmeService(t, "")
+	_, err := impl.GetRepositoryReadme()
+	if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.HttpStatusCode != http.StatusNotFound || apiErr.UserMessage != "readme not found" {
+		t.Errorf("expected a missing readme to be not found, got %v", err)
+	}
+
+	impl.client.GitHubConfig.GitHubReadmeEnabled = false
+	_, err = impl.GetRepositoryReadme()
+	if apiErr, ok := err.(*util2.ApiError); !ok || apiErr.UserMessage != "readme is not enabled" {
+		t.Errorf("expected the readme to be disabled, got %v", err)
+	}
+}
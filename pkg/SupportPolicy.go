@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"github.com/devtron-labs/central-api/pkg/adminState"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// evaluateSupportPolicy decides support per minor line, admin eol dates take precedence
+func (impl *ReleaseNoteServiceImpl) evaluateSupportPolicy(releases []*common.Release, now time.Time) []*common.SupportedLineInfo {
+	var lines []*versionedRelease
+	seenLines := make(map[string]bool)
+	for _, item := range parseVersionedReleases(releases) {
+		line := item.version.MinorLine()
+		if seenLines[line] {
+			continue
+		}
+		seenLines[line] = true
+		lines = append(lines, item)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i].version.Major() != lines[j].version.Major() {
+			return lines[i].version.Major() > lines[j].version.Major()
+		}
+		return lines[i].version.Minor() > lines[j].version.Minor()
+	})
+
+	policy := impl.supportPolicyConfig.SupportPolicyConfig
+	eolDates := impl.getEolDates()
+	var evaluated []*common.SupportedLineInfo
+	for index, item := range lines {
+		line := item.version.MinorLine()
+		lineInfo := &common.SupportedLineInfo{
+			Line:      line,
+			Supported: index < policy.SupportedMinorLines,
+		}
+		if eolDate, ok := eolDates[line]; ok {
+			eol := eolDate
+			lineInfo.EolDate = &eol
+			lineInfo.Supported = !now.After(eolDate)
+		}
+		evaluated = append(evaluated, lineInfo)
+	}
+	return evaluated
+}
+
+func (impl *ReleaseNoteServiceImpl) applySupportPolicy(releases []*common.Release) {
+	supportedByLine := make(map[string]bool)
+	for _, lineInfo := range impl.evaluateSupportPolicy(releases, time.Now()) {
+		supportedByLine[lineInfo.Line] = lineInfo.Supported
+	}
+	for _, item := range parseVersionedReleases(releases) {
+		item.release.Supported = supportedByLine[item.version.MinorLine()]
+	}
+}
+
+func (impl *ReleaseNoteServiceImpl) GetSupportPolicy() (*common.SupportPolicy, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	supportPolicy := &common.SupportPolicy{
+		SupportedMinorLines: impl.supportPolicyConfig.SupportPolicyConfig.SupportedMinorLines,
+		Lines:               impl.evaluateSupportPolicy(releases, time.Now()),
+	}
+	if supportPolicy.Lines == nil {
+		supportPolicy.Lines = []*common.SupportedLineInfo{}
+	}
+	return supportPolicy, nil
+}
+
+// getEolDates merges the configured eol dates with the ones managed through the admin api
+func (impl *ReleaseNoteServiceImpl) getEolDates() map[string]time.Time {
+	eolDates := make(map[string]time.Time)
+	for line, eolDate := range impl.supportPolicyConfig.EolDates {
+		eolDates[line] = eolDate
+	}
+	impl.adminStateMutex.RLock()
+	defer impl.adminStateMutex.RUnlock()
+	for line, eolDateString := range impl.adminState.EolDates {
+		eolDate, err := time.Parse(util.EolDateLayout, eolDateString)
+		if err != nil {
+			impl.logger.Warnw("ignoring invalid eol date in admin state", "line", line, "eolDate", eolDateString)
+			continue
+		}
+		eolDates[line] = eolDate
+	}
+	return eolDates
+}
+
+func (impl *ReleaseNoteServiceImpl) getEolWarning(lineInfo *common.SupportedLineInfo, now time.Time) string {
+	if lineInfo == nil || lineInfo.EolDate == nil {
+		return ""
+	}
+	horizon := impl.supportPolicyConfig.SupportPolicyConfig.EolWarningHorizon
+	if now.After(*lineInfo.EolDate) {
+		return fmt.Sprintf("version line %s reached end of life on %s", lineInfo.Line, lineInfo.EolDate.Format(util.EolDateLayout))
+	}
+	if lineInfo.EolDate.Sub(now) <= horizon {
+		return fmt.Sprintf("version line %s reaches end of life on %s", lineInfo.Line, lineInfo.EolDate.Format(util.EolDateLayout))
+	}
+	return ""
+}
+
+func parseMinorLine(line string) (string, error) {
+	version, err := util2.ParseSemanticVersion(line)
+	if err != nil || len(version.Segments) != 2 || version.IsPrerelease() {
+		return "", &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid minor line %q", line), UserMessage: "invalid minor line, expected <major>.<minor> like 0.6"}
+	}
+	return version.MinorLine(), nil
+}
+
+// SetLineEolDate overrides the automatic support policy
+func (impl *ReleaseNoteServiceImpl) SetLineEolDate(line string, eolDate string) error {
+	minorLine, err := parseMinorLine(line)
+	if err != nil {
+		return err
+	}
+	parsedEolDate, err := time.Parse(util.EolDateLayout, eolDate)
+	if err != nil {
+		return &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid eol date, expected yyyy-mm-dd"}
+	}
+	return impl.updateAdminState(func(state *adminState.AdminState) {
+		if state.EolDates == nil {
+			state.EolDates = make(map[string]string)
+		}
+		state.EolDates[minorLine] = parsedEolDate.Format(util.EolDateLayout)
+	})
+}
+
+func (impl *ReleaseNoteServiceImpl) RemoveLineEolDate(line string) error {
+	minorLine, err := parseMinorLine(line)
+	if err != nil {
+		return err
+	}
+	return impl.updateAdminState(func(state *adminState.AdminState) {
+		delete(state.EolDates, minorLine)
+	})
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	util "github.com/devtron-labs/central-api/client"
+	"github.com/devtron-labs/central-api/common"
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"go.uber.org/zap"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ModuleStatusInstalled = "installed"
+	ModuleStatusEnabled   = "enabled"
+	// UnknownModuleName counts modules missing from the catalog
+	UnknownModuleName = "unknown"
+)
+
+type TelemetryService interface {
+	CheckIn(checkIn *common.InstallationCheckIn) error
+	GetModuleAdoptionStats(window time.Duration) (*common.ModuleAdoptionStats, error)
+}
+
+type TelemetryServiceImpl struct {
+	logger             *zap.SugaredLogger
+	releaseNoteService ReleaseNoteService
+	telemetryConfig    *util.TelemetryConfig
+	mutex              sync.RWMutex
+	// checkIns holds the last check-in of every installation, keyed by installation id
+	checkIns map[string]*installationCheckIn
+}
+
+type installationCheckIn struct {
+	checkIn  *common.InstallationCheckIn
+	lastSeen time.Time
+}
+
+func NewTelemetryServiceImpl(logger *zap.SugaredLogger, releaseNoteService ReleaseNoteService, telemetryConfig *util.TelemetryConfig) *TelemetryServiceImpl {
+	return &TelemetryServiceImpl{
+		logger:             logger,
+		releaseNoteService: releaseNoteService,
+		telemetryConfig:    telemetryConfig,
+		checkIns:           make(map[string]*installationCheckIn),
+	}
+}
+
+func (impl *TelemetryServiceImpl) CheckIn(checkIn *common.InstallationCheckIn) error {
+	checkIn.InstallationId = strings.TrimSpace(checkIn.InstallationId)
+	if len(checkIn.InstallationId) == 0 {
+		return &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "installation id is required", UserMessage: "installationId is required"}
+	}
+	for _, module := range checkIn.Modules {
+		if module == nil || len(strings.TrimSpace(module.Name)) == 0 {
+			return &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: "module without name in check-in", UserMessage: "module name is required"}
+		}
+		module.Name, _ = impl.releaseNoteService.ResolveModuleName(strings.TrimSpace(module.Name))
+		if module.Status != ModuleStatusInstalled && module.Status != ModuleStatusEnabled {
+			return &util2.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: fmt.Sprintf("invalid module status %q", module.Status), UserMessage: fmt.Sprintf("invalid status of module %s, expected %s or %s", module.Name, ModuleStatusInstalled, ModuleStatusEnabled)}
+		}
+	}
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	if _, ok := impl.checkIns[checkIn.InstallationId]; !ok {
+		impl.evictLeastRecentlySeen()
+	}
+	impl.checkIns[checkIn.InstallationId] = &installationCheckIn{checkIn: checkIn, lastSeen: time.Now()}
+	return nil
+}
+
+// evictLeastRecentlySeen expects the caller to hold the lock
+func (impl *TelemetryServiceImpl) evictLeastRecentlySeen() {
+	maxInstallations := impl.telemetryConfig.MaxInstallations
+	for maxInstallations > 0 && len(impl.checkIns) >= maxInstallations {
+		var oldestId string
+		var oldest time.Time
+		for id, checkIn := range impl.checkIns {
+			if len(oldestId) == 0 || checkIn.lastSeen.Before(oldest) {
+				oldestId, oldest = id, checkIn.lastSeen
+			}
+		}
+		delete(impl.checkIns, oldestId)
+	}
+}
+
+func (impl *TelemetryServiceImpl) GetModuleAdoptionStats(window time.Duration) (*common.ModuleAdoptionStats, error) {
+	if window <= 0 {
+		window = impl.telemetryConfig.AdoptionWindow
+	}
+	modules, err := impl.releaseNoteService.GetModulesV2()
+	if err != nil {
+		impl.logger.Errorw("error on fetching modules", "err", err)
+		return nil, err
+	}
+	adoptionByName := make(map[string]*common.ModuleAdoption, len(modules)+1)
+	stats := &common.ModuleAdoptionStats{Window: window.String(), Modules: make([]*common.ModuleAdoption, 0, len(modules)+1)}
+	for _, module := range modules {
+		adoption := &common.ModuleAdoption{Name: module.Name, Versions: map[string]int{}}
+		adoptionByName[module.Name] = adoption
+		stats.Modules = append(stats.Modules, adoption)
+	}
+	unknown := &common.ModuleAdoption{Name: UnknownModuleName, Versions: map[string]int{}}
+
+	since := time.Now().Add(-window)
+	impl.mutex.RLock()
+	defer impl.mutex.RUnlock()
+	for _, item := range impl.checkIns {
+		if item.lastSeen.Before(since) {
+			continue
+		}
+		stats.ActiveInstallations++
+		for _, module := range item.checkIn.Modules {
+			adoption, ok := adoptionByName[module.Name]
+			if !ok {
+				adoption = unknown
+				unknown.UnknownNames = appendUnique(unknown.UnknownNames, module.Name)
+			}
+			adoption.Installed++
+			if module.Status == ModuleStatusEnabled {
+				adoption.Enabled++
+			}
+			if len(module.Version) > 0 {
+				adoption.Versions[module.Version]++
+			}
+		}
+	}
+	if unknown.Installed > 0 {
+		sort.Strings(unknown.UnknownNames)
+		stats.Modules = append(stats.Modules, unknown)
+	}
+	return stats, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+const GitHubOAuthScopesHeader = "X-OAuth-Scopes"
+
+type tokenScopesCache struct {
+	mutex   sync.Mutex
+	scopes  []string
+	fetched bool
+}
+
+// GetTokenScopes is empty for fine-grained tokens, which report no scopes header
+func (impl *ReleaseNoteServiceImpl) GetTokenScopes() ([]string, error) {
+	impl.tokenScopes.mutex.Lock()
+	defer impl.tokenScopes.mutex.Unlock()
+	if impl.tokenScopes.fetched {
+		return impl.tokenScopes.scopes, nil
+	}
+	_, response, err := impl.client.GitHubClient.RateLimits(context.Background())
+	if err != nil {
+		impl.logger.Errorw("error in fetching github token scopes", "err", err)
+		return nil, err
+	}
+	scopes := []string{}
+	header := response.Header.Values(GitHubOAuthScopesHeader)
+	if len(header) == 0 {
+		impl.logger.Infow("github token scopes not reported, token is fine-grained or missing")
+	}
+	for _, value := range header {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); len(scope) > 0 {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	impl.tokenScopes.scopes = scopes
+	impl.tokenScopes.fetched = true
+	return scopes, nil
+}
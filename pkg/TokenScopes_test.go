@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestTokenScopesService(t *testing.T, scopes []string) (*ReleaseNoteServiceImpl, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/api/v3/rate_limit" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		for _, scope := range scopes {
+			w.Header().Add(GitHubOAuthScopesHeader, scope)
+		}
+		w.Write([]byte(`{"resources": {"core": {"limit": 5000, "remaining": 5000}}}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("GITHUB_HOST", server.URL)
+	client, err := util.NewGitHubClient(zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	impl := newTestReleaseNoteService(t)
+	impl.client = client
+	return impl, &requests
+}
+
+func TestGetTokenScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		scopes string
+	}{
+		{name: "classic token", header: []string{"repo, read:org,  admin:repo_hook"}, scopes: "repo,read:org,admin:repo_hook"},
+		{name: "header repeated", header: []string{"repo", "read:org"}, scopes: "repo,read:org"},
+		{name: "no scope granted", header: []string{""}},
+		{name: "fine-grained token"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl, requests := newTestTokenScopesService(t, test.header)
+			for i := 0; i < 2; i++ {
+				scopes, err := impl.GetTokenScopes()
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if scopes == nil {
+					t.Fatalf("expected an empty list rather than nil")
+				}
+				if strings.Join(scopes, ",") != test.scopes {
+					t.Errorf("expected the scopes %q, got %v", test.scopes, scopes)
+				}
+			}
+			if count := atomic.LoadInt32(requests); count != 1 {
+				t.Errorf("expected the scopes to be fetched once, got %d requests", count)
+			}
+		})
+	}
+}
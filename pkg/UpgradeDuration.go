@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"regexp"
+	"time"
+)
+
+// upgradeDurationRegex matches <!--upgrade-duration: 15m-->
+var upgradeDurationRegex = regexp.MustCompile(`<!--\s*upgrade-duration:\s*(.*?)\s*-->`)
+
+func (impl *ReleaseNoteServiceImpl) getUpgradeDuration(releaseInfo *common.Release) {
+	releaseInfo.EstimatedUpgradeDuration = ""
+	duration, ok, _ := parseUpgradeDuration(releaseInfo.Body)
+	if ok {
+		releaseInfo.EstimatedUpgradeDuration = duration.String()
+	}
+}
+
+func parseUpgradeDuration(body string) (time.Duration, bool, string) {
+	match := upgradeDurationRegex.FindStringSubmatch(body)
+	if match == nil {
+		return 0, false, ""
+	}
+	duration, err := time.ParseDuration(match[1])
+	if err != nil || duration <= 0 {
+		return 0, false, fmt.Sprintf("upgrade-duration marker value %q is not a positive duration like 15m", match[1])
+	}
+	return duration, true, ""
+}
+
+func lintUpgradeDuration(release *common.Release) []string {
+	if _, _, problem := parseUpgradeDuration(release.Body); len(problem) > 0 {
+		return []string{problem}
+	}
+	return nil
+}
+
+func (impl *ReleaseNoteServiceImpl) estimateUpgradeDuration(releases []*common.Release) *common.UpgradeEstimate {
+	estimate := &common.UpgradeEstimate{Hops: make([]*common.UpgradeHopEstimate, 0, len(releases))}
+	var total time.Duration
+	for _, release := range releases {
+		hop := &common.UpgradeHopEstimate{TagName: release.TagName}
+		duration, err := time.ParseDuration(release.EstimatedUpgradeDuration)
+		if err != nil {
+			duration = impl.releaseBodyConfig.DefaultUpgradeDuration
+			hop.Estimated = true
+			estimate.Estimated = true
+		}
+		hop.Duration = duration.String()
+		total += duration
+		estimate.Hops = append(estimate.Hops, hop)
+	}
+	estimate.TotalDuration = total.String()
+	estimate.TotalSeconds = int64(total.Seconds())
+	return estimate
+}
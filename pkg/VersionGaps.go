@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/central-api/internal/util"
+	"sort"
+	"strings"
+)
+
+func (impl *ReleaseNoteServiceImpl) DetectVersionGaps() ([]string, error) {
+	releases, err := impl.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	patchesByLine := make(map[string]map[int]bool)
+	prefixByLine := make(map[string]string)
+	versionByLine := make(map[string]*util.SemanticVersion)
+	var lines []string
+	for _, item := range parseVersionedReleases(releases) {
+		if item.version.IsPrerelease() || item.release.Prerelease {
+			continue
+		}
+		line := item.version.MinorLine()
+		if _, ok := patchesByLine[line]; !ok {
+			patchesByLine[line] = make(map[int]bool)
+			versionByLine[line] = item.version
+			lines = append(lines, line)
+			if strings.HasPrefix(strings.TrimSpace(item.release.TagName), "v") {
+				prefixByLine[line] = "v"
+			}
+		}
+		patchesByLine[line][item.version.Patch()] = true
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		iVersion, jVersion := versionByLine[lines[i]], versionByLine[lines[j]]
+		if iVersion.Major() != jVersion.Major() {
+			return iVersion.Major() < jVersion.Major()
+		}
+		return iVersion.Minor() < jVersion.Minor()
+	})
+	gaps := make([]string, 0)
+	for _, line := range lines {
+		patches := patchesByLine[line]
+		minPatch, maxPatch := -1, -1
+		for patch := range patches {
+			if minPatch < 0 || patch < minPatch {
+				minPatch = patch
+			}
+			if patch > maxPatch {
+				maxPatch = patch
+			}
+		}
+		for patch := minPatch + 1; patch < maxPatch; patch++ {
+			if !patches[patch] {
+				gaps = append(gaps, fmt.Sprintf("%s%s.%d", prefixByLine[line], line, patch))
+			}
+		}
+	}
+	return gaps, nil
+}
@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"strings"
+	"testing"
+)
+
+func TestDetectVersionGaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []*common.Release
+		gaps     string
+	}{
+		{
+			name:     "no gap",
+			releases: []*common.Release{{TagName: "v0.7.1"}, {TagName: "v0.7.0"}, {TagName: "v0.6.0"}},
+		},
+		{
+			name:     "deliberate gap",
+			releases: []*common.Release{{TagName: "v0.6.3"}, {TagName: "v0.6.1"}, {TagName: "v0.6.0"}},
+			gaps:     "v0.6.2",
+		},
+		{
+			name: "gaps of several series in ascending order",
+			releases: []*common.Release{
+				{TagName: "v1.2.4"}, {TagName: "v1.2.1"}, {TagName: "v0.10.2"}, {TagName: "v0.10.0"}, {TagName: "v0.9.3"}, {TagName: "v0.9.1"},
+			},
+			gaps: "v0.9.2,v0.10.1,v1.2.2,v1.2.3",
+		},
+		{
+			name:     "tag without v prefix",
+			releases: []*common.Release{{TagName: "0.6.2"}, {TagName: "0.6.0"}},
+			gaps:     "0.6.1",
+		},
+		{
+			name:     "pre-releases and non semver tags ignored",
+			releases: []*common.Release{{TagName: "v0.8.2"}, {TagName: "v0.8.1-rc.1"}, {TagName: "v0.8.1", Prerelease: true}, {TagName: "v0.8.0"}, {TagName: "nightly"}},
+			gaps:     "v0.8.1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gaps, err := newTestReleaseNoteService(t, test.releases...).DetectVersionGaps()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if strings.Join(gaps, ",") != test.gaps {
+				t.Errorf("expected the gaps %s, got %v", test.gaps, gaps)
+			}
+		})
+	}
+}
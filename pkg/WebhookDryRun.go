@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+)
+
+const (
+	WebhookOutcomeIgnored  = "ignored"
+	WebhookOutcomeRejected = "rejected"
+	WebhookOutcomeCreated  = "created"
+	WebhookOutcomeUpdated  = "updated"
+	WebhookOutcomeRemoved  = "removed"
+)
+
+// DryRunWebhook returns the release UpdateReleasesWithAck would store without writing anything
+func (impl *ReleaseNoteServiceImpl) DryRunWebhook(requestBodyBytes []byte) (*common.WebhookDryRunResult, error) {
+	ack := &common.ReleaseWebhookAck{}
+	result := &common.WebhookDryRunResult{LintFindings: []string{}, Warnings: []string{}}
+	releaseInfo, warnings, err := impl.parseWebhookRelease(requestBodyBytes, ack, normalizeOptions{preview: true})
+	result.Action = ack.Action
+	result.TagName = ack.TagName
+	if apiErr, ok := err.(*util.ApiError); ok && apiErr.HttpStatusCode == http.StatusUnprocessableEntity {
+		result.Outcome = WebhookOutcomeRejected
+		result.Message = apiErr.InternalMessage
+		return result, nil
+	} else if apiErr, ok := err.(*util.ApiError); ok {
+		return nil, apiErr
+	} else if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "invalid webhook payload"}
+	}
+	if releaseInfo == nil {
+		result.Outcome = WebhookOutcomeIgnored
+		return result, nil
+	}
+	result.Warnings = append(result.Warnings, warnings...)
+
+	releaseNotes, err := impl.getStoredReleases()
+	if err != nil {
+		return nil, err
+	}
+	if isReleaseRemovalAction(ack.Action) {
+		result.Outcome = WebhookOutcomeIgnored
+		for _, release := range releaseNotes {
+			if isSameRelease(release, releaseInfo) {
+				removed := *release
+				result.Outcome = WebhookOutcomeRemoved
+				result.Release = &removed
+				break
+			}
+		}
+		return result, nil
+	}
+	result.LintFindings = append(result.LintFindings, impl.lintRelease(releaseInfo)...)
+	releaseList, isNew := mergeWebhookRelease(copyReleases(releaseNotes), releaseInfo)
+	impl.applyCompareURLs(releaseList)
+	result.Outcome = WebhookOutcomeUpdated
+	if isNew {
+		result.Outcome = WebhookOutcomeCreated
+	}
+	for _, release := range releaseList {
+		if util.IsSameVersionTag(release.TagName, releaseInfo.TagName) {
+			result.Release = release
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRunWebhookLeavesServiceUntouched(t *testing.T) {
+	var imageRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&imageRequests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.releaseBodyConfig.ImageDimensionsEnabled = true
+	payload := fmt.Sprintf(`{"action": "published", "release": {"id": 42, "tag_name": "v0.9.0", "name": "v0.9.0",
+		"body": "![dashboard](%s/dashboard.png)\n<!--upgrade-prerequisites-required-->\nrun the migration\n<!--upgrade-prerequisites-required-->",
+		"created_at": "2024-04-01T00:00:00Z", "published_at": "2024-04-01T00:00:00Z"}}`, server.URL)
+
+	result, err := impl.DryRunWebhook([]byte(payload))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result.Outcome != WebhookOutcomeCreated || result.Release == nil || result.Release.TagName != "v0.9.0" {
+		t.Fatalf("expected v0.9.0 to be created, got %+v", result)
+	}
+	if !result.Release.Prerequisite || result.Release.PrerequisiteMessage != "run the migration" {
+		t.Errorf("expected the prerequisite to be derived, got %v %q", result.Release.Prerequisite, result.Release.PrerequisiteMessage)
+	}
+	if requests := atomic.LoadInt32(&imageRequests); requests != 0 {
+		t.Errorf("expected no image to be fetched, got %d requests", requests)
+	}
+	if len(impl.imageDimensions.entries) != 0 {
+		t.Errorf("expected the image cache to be left empty, got %d entries", len(impl.imageDimensions.entries))
+	}
+	stored, err := impl.getStoredReleases()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(stored) != len(newTestReleases()) {
+		t.Errorf("expected the stored releases to be left as they were, got %d releases", len(stored))
+	}
+}
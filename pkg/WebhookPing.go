@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventTypePing is sent by github once a webhook is created, it carries the hook configuration
+const EventTypePing = "ping"
+
+// WebhookContentTypeJson is expected, form deliveries wrap the json in a payload field
+const WebhookContentTypeJson = "json"
+
+// webhookStatusState keeps the last ping received, so that a misconfigured hook is reported
+type webhookStatusState struct {
+	mutex    sync.RWMutex
+	lastPing *common.WebhookPing
+}
+
+type webhookPingPayload struct {
+	Zen    string `json:"zen"`
+	HookId int64  `json:"hook_id"`
+	Hook   *struct {
+		Active bool     `json:"active"`
+		Events []string `json:"events"`
+		Config struct {
+			ContentType string `json:"content_type"`
+			URL         string `json:"url"`
+		} `json:"config"`
+	} `json:"hook"`
+}
+
+func (impl *ReleaseNoteServiceImpl) HandlePing(body []byte, formEncoded bool) (*common.WebhookPing, error) {
+	warnings := make([]string, 0)
+	payload := &webhookPingPayload{}
+	err := json.Unmarshal(body, payload)
+	if err != nil {
+		return nil, &util.ApiError{HttpStatusCode: http.StatusBadRequest, InternalMessage: err.Error(), UserMessage: "malformed ping payload"}
+	}
+	ping := &common.WebhookPing{Zen: payload.Zen, HookId: payload.HookId, ReceivedAt: time.Now()}
+	if payload.Hook == nil {
+		warnings = append(warnings, "ping carries no hook configuration")
+	} else {
+		ping.Events = payload.Hook.Events
+		ping.URL = payload.Hook.Config.URL
+		if !payload.Hook.Active {
+			warnings = append(warnings, "hook is not active")
+		}
+		if !subscribesTo(payload.Hook.Events, EventTypeRelease) {
+			warnings = append(warnings, fmt.Sprintf("hook does not subscribe to %s events, it is subscribed to %s", EventTypeRelease, strings.Join(payload.Hook.Events, ", ")))
+		}
+		if contentType := payload.Hook.Config.ContentType; len(contentType) > 0 && contentType != WebhookContentTypeJson {
+			warnings = append(warnings, fmt.Sprintf("hook content type is %s, it should be %s", contentType, WebhookContentTypeJson))
+		}
+	}
+	if formEncoded && (payload.Hook == nil || len(payload.Hook.Config.ContentType) == 0) {
+		warnings = append(warnings, fmt.Sprintf("hook delivers form encoded payloads, content type should be %s", WebhookContentTypeJson))
+	}
+	ping.Warnings = warnings
+	if len(warnings) > 0 {
+		impl.logger.Warnw("webhook ping shows a misconfigured hook", "hookId", ping.HookId, "warnings", warnings)
+	} else {
+		impl.logger.Infow("webhook ping received", "hookId", ping.HookId)
+	}
+	impl.webhookStatus.mutex.Lock()
+	impl.webhookStatus.lastPing = ping
+	impl.webhookStatus.mutex.Unlock()
+	return ping, nil
+}
+
+// subscribesTo tells whether the hook events include the event, "*" subscribes to every event
+func subscribesTo(events []string, event string) bool {
+	for _, subscribed := range events {
+		if subscribed == event || subscribed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (impl *ReleaseNoteServiceImpl) GetWebhookStatus() *common.WebhookStatus {
+	impl.webhookStatus.mutex.RLock()
+	defer impl.webhookStatus.mutex.RUnlock()
+	return &common.WebhookStatus{
+		LastPing:        impl.webhookStatus.lastPing,
+		EventsProcessed: impl.metrics.getWebhookEventsProcessed(),
+	}
+}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+// readTestPing returns the ping github delivers once a hook is created, changed by the given function
+func readTestPing(t *testing.T, change func(payload map[string]interface{})) []byte {
+	t.Helper()
+	body, err := ioutil.ReadFile("testdata/webhook_ping.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change == nil {
+		return body
+	}
+	payload := make(map[string]interface{})
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change(payload)
+	body, err = json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestHandlePing(t *testing.T) {
+	hookConfig := func(payload map[string]interface{}) map[string]interface{} {
+		return payload["hook"].(map[string]interface{})
+	}
+	tests := []struct {
+		name        string
+		change      func(payload map[string]interface{})
+		formEncoded bool
+		warnings    int
+	}{
+		{name: "configured hook"},
+		{name: "all events", change: func(payload map[string]interface{}) { hookConfig(payload)["events"] = []string{"*"} }},
+		{name: "release events missing", change: func(payload map[string]interface{}) { hookConfig(payload)["events"] = []string{"push", "pull_request"} }, warnings: 1},
+		{name: "inactive hook", change: func(payload map[string]interface{}) { hookConfig(payload)["active"] = false }, warnings: 1},
+		{name: "form content type", change: func(payload map[string]interface{}) {
+			hookConfig(payload)["config"].(map[string]interface{})["content_type"] = "form"
+		}, formEncoded: true, warnings: 1},
+		{name: "form delivery without content type", change: func(payload map[string]interface{}) {
+			delete(hookConfig(payload)["config"].(map[string]interface{}), "content_type")
+		}, formEncoded: true, warnings: 1},
+		{name: "hook missing", change: func(payload map[string]interface{}) { delete(payload, "hook") }, warnings: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := newTestReleaseNoteService(t)
+			ping, err := impl.HandlePing(readTestPing(t, test.change), test.formEncoded)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if ping.Zen != "Design for failure." || ping.HookId != 109948940 {
+				t.Errorf("expected the zen and hook id of the ping, got %q and %d", ping.Zen, ping.HookId)
+			}
+			if len(ping.Warnings) != test.warnings {
+				t.Errorf("expected %d warnings, got %v", test.warnings, ping.Warnings)
+			}
+			if lastPing := impl.GetWebhookStatus().LastPing; lastPing != ping {
+				t.Errorf("expected the webhook status to report the ping")
+			}
+		})
+	}
+}
+
+func TestHandlePingRefusesMalformedPayload(t *testing.T) {
+	impl := newTestReleaseNoteService(t)
+	if _, err := impl.HandlePing([]byte("payload=%7B%22zen%22%3A%22Design+for+failure.%22%7D"), false); err == nil {
+		t.Errorf("expected a payload which isn't json to be refused")
+	}
+	if impl.GetWebhookStatus().LastPing != nil {
+		t.Errorf("expected the refused ping not to be reported")
+	}
+}
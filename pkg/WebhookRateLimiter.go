@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"sync"
+	"time"
+)
+
+// webhookRateLimiter is a token bucket bounding the webhook events processed per second
+type webhookRateLimiter struct {
+	mutex      sync.Mutex
+	ratePerSec float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newWebhookRateLimiter returns nil when the rate is not positive, a nil limiter allows every event
+func newWebhookRateLimiter(ratePerSec float64, burst int) *webhookRateLimiter {
+	if ratePerSec <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &webhookRateLimiter{ratePerSec: ratePerSec, burst: float64(burst), tokens: float64(burst), lastRefill: time.Now()}
+}
+
+func (limiter *webhookRateLimiter) allow(now time.Time) bool {
+	if limiter == nil {
+		return true
+	}
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	if elapsed := now.Sub(limiter.lastRefill).Seconds(); elapsed > 0 {
+		limiter.tokens += elapsed * limiter.ratePerSec
+		if limiter.tokens > limiter.burst {
+			limiter.tokens = limiter.burst
+		}
+		limiter.lastRefill = now
+	}
+	if limiter.tokens < 1 {
+		return false
+	}
+	limiter.tokens--
+	return true
+}
@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	util2 "github.com/devtron-labs/central-api/internal/util"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebhookRateLimiterBursts(t *testing.T) {
+	start := time.Date(2024, 3, 18, 6, 37, 10, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rate    float64
+		burst   int
+		events  int
+		spacing time.Duration
+		allowed int
+	}{
+		{name: "burst below threshold", rate: 5, burst: 5, events: 4, allowed: 4},
+		{name: "burst at threshold", rate: 5, burst: 5, events: 5, allowed: 5},
+		{name: "burst above threshold", rate: 5, burst: 5, events: 8, allowed: 5},
+		{name: "events at the rate", rate: 5, burst: 1, events: 10, spacing: 200 * time.Millisecond, allowed: 10},
+		{name: "events above the rate", rate: 5, burst: 1, events: 10, spacing: 100 * time.Millisecond, allowed: 5},
+		{name: "no limit", events: 100, allowed: 100},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := newWebhookRateLimiter(test.rate, test.burst)
+			if limiter != nil {
+				limiter.lastRefill = start
+			}
+			allowed := 0
+			for i := 0; i < test.events; i++ {
+				if limiter.allow(start.Add(time.Duration(i) * test.spacing)) {
+					allowed++
+				}
+			}
+			if allowed != test.allowed {
+				t.Errorf("expected %d of %d events to be allowed, got %d", test.allowed, test.events, allowed)
+			}
+		})
+	}
+}
+
+func TestUpdateReleasesWithAckThrottlesBurst(t *testing.T) {
+	impl := newTestReleaseNoteService(t, newTestReleases()...)
+	impl.webhookRateLimiter = newWebhookRateLimiter(0.001, 2)
+	payload := []byte(`{"action": "deleted", "release": {"tag_name": "v0.9.0"}}`)
+
+	for i := 0; i < 2; i++ {
+		if _, err := impl.UpdateReleasesWithAck(payload); err != nil {
+			t.Fatalf("expected event %d below the threshold to be accepted, got %v", i+1, err)
+		}
+	}
+	ack, err := impl.UpdateReleasesWithAck(payload)
+	apiErr, ok := err.(*util2.ApiError)
+	if !ok || apiErr.HttpStatusCode != http.StatusTooManyRequests || ack.Processed {
+		t.Errorf("expected the event above the threshold to be throttled, got %+v, %v", ack, err)
+	}
+}
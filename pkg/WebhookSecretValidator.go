@@ -20,15 +20,19 @@ package pkg
 import (
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	util "github.com/devtron-labs/central-api/client"
 	"go.uber.org/zap"
+	"hash"
 	"net/http"
 	"strings"
 )
 
 type WebhookSecretValidator interface {
 	ValidateSecret(r *http.Request, requestBodyBytes []byte) bool
+	ValidateRequest(r *http.Request, requestBodyBytes []byte) error
 }
 
 type WebhookSecretValidatorImpl struct {
@@ -44,14 +48,67 @@ func NewWebhookSecretValidatorImpl(Logger *zap.SugaredLogger, client *util.GitHu
 }
 
 const (
+	SECRET_VALIDATOR_SHA256     string = "SHA-256"
 	SECRET_VALIDATOR_SHA1       string = "SHA-1"
 	SECRET_VALIDATOR_URL_APPEND string = "URL_APPEND"
 	SECRET_VALIDATOR_PLAIN_TEXT string = "PLAIN_TEXT"
 )
 
-// Validate secret for some predefined algorithms : SHA1, URL_APPEND, PLAIN_TEXT
+var (
+	ErrWebhookSecretNotConfigured = errors.New("webhook secret not configured")
+	ErrWebhookSignatureMissing    = errors.New("webhook signature missing")
+	ErrWebhookSignatureMismatch   = errors.New("webhook signature mismatch")
+)
+
+// ValidateRequest validates the webhook request like ValidateSecret, telling apart why a SHA-256 signature is refused
+func (impl *WebhookSecretValidatorImpl) ValidateRequest(r *http.Request, requestBodyBytes []byte) error {
+	gitHubConfig := impl.client.GitHubConfig
+	if gitHubConfig.GitHubSecretValidator == SECRET_VALIDATOR_SHA256 {
+		return VerifyWebhookSignature256(gitHubConfig.GitHubWebhookSecret, requestBodyBytes, r.Header.Get(gitHubConfig.GitHubSignature256Header))
+	}
+	if !impl.ValidateSecret(r, requestBodyBytes) {
+		return ErrWebhookSignatureMismatch
+	}
+	return nil
+}
+
+// VerifyWebhookSignature256 checks a header like "sha256=<hex hmac>" against the raw payload
+func VerifyWebhookSignature256(secret string, requestBodyBytes []byte, signatureHeader string) error {
+	return verifyWebhookSignature(secret, requestBodyBytes, signatureHeader, "sha256", sha256.New)
+}
+
+// VerifyWebhookSignature1 checks a legacy signature header like "sha1=<hex hmac>" the way VerifyWebhookSignature256 does
+func VerifyWebhookSignature1(secret string, requestBodyBytes []byte, signatureHeader string) error {
+	return verifyWebhookSignature(secret, requestBodyBytes, signatureHeader, "sha1", sha1.New)
+}
+
+func verifyWebhookSignature(secret string, requestBodyBytes []byte, signatureHeader string, algorithm string, newHash func() hash.Hash) error {
+	if len(secret) == 0 {
+		return ErrWebhookSecretNotConfigured
+	}
+	if len(signatureHeader) == 0 {
+		return ErrWebhookSignatureMissing
+	}
+	gotHash := strings.SplitN(signatureHeader, "=", 2)
+	if len(gotHash) != 2 || gotHash[0] != algorithm {
+		return ErrWebhookSignatureMismatch
+	}
+	signature, err := hex.DecodeString(gotHash[1])
+	if err != nil {
+		return ErrWebhookSignatureMismatch
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(requestBodyBytes)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrWebhookSignatureMismatch
+	}
+	return nil
+}
+
+// Validate secret for some predefined algorithms : SHA256, SHA1, URL_APPEND, PLAIN_TEXT
 // URL_APPEND : Secret will come in URL (last path param of URL)
 // PLAIN_TEXT : Plain text value in request header
+// SHA256 : SHA256 hmac of the payload in the signature 256 header
 // SHA1 : SHA1 encrypted text in request header
 func (impl *WebhookSecretValidatorImpl) ValidateSecret(r *http.Request, requestBodyBytes []byte) bool {
 
@@ -60,18 +117,13 @@ func (impl *WebhookSecretValidatorImpl) ValidateSecret(r *http.Request, requestB
 
 	switch secretValidator {
 
-	case SECRET_VALIDATOR_SHA1:
+	case SECRET_VALIDATOR_SHA256:
+		err := VerifyWebhookSignature256(impl.client.GitHubConfig.GitHubWebhookSecret, requestBodyBytes, r.Header.Get(impl.client.GitHubConfig.GitHubSignature256Header))
+		return err == nil
 
-		gotHash := strings.SplitN(r.Header.Get(impl.client.GitHubConfig.GitHubSecretHeader), "=", 2)
-		if gotHash[0] != "sha1" {
-			return false
-		}
-		hash := hmac.New(sha1.New, []byte(impl.client.GitHubConfig.GitHubWebhookSecret))
-		if _, err := hash.Write(requestBodyBytes); err != nil {
-			return false
-		}
-		expectedHash := hex.EncodeToString(hash.Sum(nil))
-		return gotHash[1] == expectedHash
+	case SECRET_VALIDATOR_SHA1:
+		err := VerifyWebhookSignature1(impl.client.GitHubConfig.GitHubWebhookSecret, requestBodyBytes, r.Header.Get(impl.client.GitHubConfig.GitHubSecretHeader))
+		return err == nil
 
 	case SECRET_VALIDATOR_URL_APPEND:
 		//secretFromUrlFromDb := gitHost.WebhookUrl[strings.LastIndex(gitHost.WebhookUrl, "/")+1:]
@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	util "github.com/devtron-labs/central-api/client"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// the secret, payload and signature of the example in the github docs on validating webhook deliveries
+const (
+	testWebhookSecret          = "It's a Secret to Everybody"
+	testWebhookPayload         = "Hello, World!"
+	testWebhookSignature       = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	testWebhookSignature1      = "sha1=01dc10d0c83e72ed246219cdd91669667fe2ca59"
+	testWebhookSignatureHeader = "X-Hub-Signature-256"
+)
+
+func TestVerifyWebhookSignature256(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		payload   string
+		signature string
+		err       error
+	}{
+		{name: "known signature", secret: testWebhookSecret, payload: testWebhookPayload, signature: testWebhookSignature},
+		{name: "tampered body", secret: testWebhookSecret, payload: "Hello, World?", signature: testWebhookSignature, err: ErrWebhookSignatureMismatch},
+		{name: "other secret", secret: "another secret", payload: testWebhookPayload, signature: testWebhookSignature, err: ErrWebhookSignatureMismatch},
+		{name: "sha1 signature", secret: testWebhookSecret, payload: testWebhookPayload, signature: testWebhookSignature1, err: ErrWebhookSignatureMismatch},
+		{name: "bare algorithm", secret: testWebhookSecret, payload: testWebhookPayload, signature: "sha256", err: ErrWebhookSignatureMismatch},
+		{name: "invalid hex", secret: testWebhookSecret, payload: testWebhookPayload, signature: "sha256=zz", err: ErrWebhookSignatureMismatch},
+		{name: "missing signature", secret: testWebhookSecret, payload: testWebhookPayload, err: ErrWebhookSignatureMissing},
+		{name: "missing secret", payload: testWebhookPayload, signature: testWebhookSignature, err: ErrWebhookSecretNotConfigured},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyWebhookSignature256(test.secret, []byte(test.payload), test.signature)
+			if err != test.err {
+				t.Errorf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateSecretSha1(t *testing.T) {
+	validator := NewWebhookSecretValidatorImpl(zap.NewNop().Sugar(), &util.GitHubClient{GitHubConfig: &util.GitHubConfig{
+		GitHubSecretValidator: SECRET_VALIDATOR_SHA1,
+		GitHubWebhookSecret:   testWebhookSecret,
+		GitHubSecretHeader:    "X-Hub-Signature",
+	}})
+	tests := []struct {
+		name      string
+		payload   string
+		signature string
+		valid     bool
+	}{
+		{name: "known signature", payload: testWebhookPayload, signature: testWebhookSignature1, valid: true},
+		{name: "tampered body", payload: "Hello, World?", signature: testWebhookSignature1},
+		{name: "bare algorithm", payload: testWebhookPayload, signature: "sha1"},
+		{name: "missing signature", payload: testWebhookPayload},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/release/webhook", strings.NewReader(test.payload))
+			req.Header.Set("X-Hub-Signature", test.signature)
+			if valid := validator.ValidateSecret(req, []byte(test.payload)); valid != test.valid {
+				t.Errorf("expected valid %v, got %v", test.valid, valid)
+			}
+		})
+	}
+}
+
+func TestValidateRequestSha256(t *testing.T) {
+	validator := NewWebhookSecretValidatorImpl(zap.NewNop().Sugar(), &util.GitHubClient{GitHubConfig: &util.GitHubConfig{
+		GitHubSecretValidator:    SECRET_VALIDATOR_SHA256,
+		GitHubWebhookSecret:      testWebhookSecret,
+		GitHubSignature256Header: testWebhookSignatureHeader,
+	}})
+	req := httptest.NewRequest("POST", "/release/webhook", strings.NewReader(testWebhookPayload))
+	req.Header.Set(testWebhookSignatureHeader, testWebhookSignature)
+	if err := validator.ValidateRequest(req, []byte(testWebhookPayload)); err != nil {
+		t.Errorf("expected the known signature to be valid, got %v", err)
+	}
+	if err := validator.ValidateRequest(req, []byte("Hello, World?")); err != ErrWebhookSignatureMismatch {
+		t.Errorf("expected the tampered body to be refused, got %v", err)
+	}
+}
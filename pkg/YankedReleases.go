@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/devtron-labs/central-api/common"
+	"github.com/devtron-labs/central-api/internal/util"
+	"github.com/google/go-github/github"
+	"net/http"
+)
+
+// fetchYankedReleases keeps the known yank set on failure, a missing file means nothing is yanked
+func (impl *ReleaseNoteServiceImpl) fetchYankedReleases(ctx context.Context) {
+	config := impl.client.GitHubConfig
+	if len(config.GitHubYankedFilePath) == 0 {
+		return
+	}
+	fileContent, _, _, err := impl.client.GitHubClient.Repositories.GetContents(ctx, config.GitHubOrg, config.GitHubRepo, config.GitHubYankedFilePath, nil)
+	if err != nil {
+		responseErr, ok := err.(*github.ErrorResponse)
+		if ok && responseErr.Response.StatusCode == http.StatusNotFound {
+			impl.logger.Infow("yanked releases file not found, marking no release as yanked", "path", config.GitHubYankedFilePath)
+			impl.setYankedReleases(map[string]string{})
+			return
+		}
+		impl.logger.Errorw("error in fetching yanked releases file from github, keeping previous yank set", "path", config.GitHubYankedFilePath, "err", err)
+		return
+	}
+	content, err := fileContent.GetContent()
+	if err != nil {
+		impl.logger.Errorw("error in decoding yanked releases file, keeping previous yank set", "path", config.GitHubYankedFilePath, "err", err)
+		return
+	}
+	var yankedReleases []*common.YankedRelease
+	err = json.Unmarshal([]byte(content), &yankedReleases)
+	if err != nil {
+		impl.logger.Errorw("malformed yanked releases file, keeping previous yank set", "path", config.GitHubYankedFilePath, "err", err)
+		return
+	}
+	yanked := make(map[string]string)
+	for _, yankedRelease := range yankedReleases {
+		if yankedRelease == nil || len(yankedRelease.TagName) == 0 {
+			continue
+		}
+		yanked[util.NormalizeVersionTag(yankedRelease.TagName)] = yankedRelease.Reason
+	}
+	impl.setYankedReleases(yanked)
+}
+
+func (impl *ReleaseNoteServiceImpl) setYankedReleases(yanked map[string]string) {
+	impl.yankMutex.Lock()
+	defer impl.yankMutex.Unlock()
+	impl.yankedReleases = yanked
+	impl.invalidateDerivedViews()
+}
+
+func (impl *ReleaseNoteServiceImpl) applyYankedReleases(releases []*common.Release) {
+	impl.yankMutex.RLock()
+	defer impl.yankMutex.RUnlock()
+	for _, release := range releases {
+		reason, yanked := impl.yankedReleases[util.NormalizeVersionTag(release.TagName)]
+		release.Yanked = yanked
+		release.YankedReason = reason
+	}
+}
@@ -0,0 +1,75 @@
+package adminState
+
+import (
+	"encoding/json"
+	util "github.com/devtron-labs/central-api/client"
+	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
+	"go.uber.org/zap"
+	"os"
+	"sync"
+)
+
+const AdminStateBlobKey = "admin-state.json"
+
+// adminStateLocalFile is where the admin state is downloaded to and uploaded from
+const adminStateLocalFile = "/tmp/" + AdminStateBlobKey
+
+type AdminStateBlobRepositoryImpl struct {
+	logger             *zap.SugaredLogger
+	blobConfig         *util.BlobConfigVariables
+	blobStorageService *blob_storage.BlobStorageServiceImpl
+	mutex              sync.Mutex
+}
+
+func NewAdminStateBlobRepositoryImpl(logger *zap.SugaredLogger, blobConfig *util.BlobConfigVariables) *AdminStateBlobRepositoryImpl {
+	return &AdminStateBlobRepositoryImpl{
+		logger:             logger,
+		blobConfig:         blobConfig,
+		blobStorageService: blob_storage.NewBlobStorageServiceImpl(logger),
+	}
+}
+
+// Get returns ErrAdminStateUnavailable for a missing state as well as a failed download
+func (impl *AdminStateBlobRepositoryImpl) Get() (*AdminState, error) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	request := impl.blobConfig.NewBlobStorageRequest(AdminStateBlobKey, adminStateLocalFile)
+	status, _, err := impl.blobStorageService.Get(request)
+	if !status || err != nil {
+		impl.logger.Warnw("admin state could not be downloaded from blob", "key", AdminStateBlobKey, "err", err)
+		return nil, ErrAdminStateUnavailable
+	}
+	content, err := os.ReadFile("/" + adminStateLocalFile)
+	if err != nil {
+		impl.logger.Errorw("error in reading admin state downloaded from blob", "err", err)
+		return nil, err
+	}
+	adminState := &AdminState{}
+	err = json.Unmarshal(content, adminState)
+	if err != nil {
+		impl.logger.Errorw("error in unmarshalling admin state from blob", "err", err)
+		return nil, err
+	}
+	return adminState, nil
+}
+
+func (impl *AdminStateBlobRepositoryImpl) Save(adminState *AdminState) error {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	content, err := json.Marshal(adminState)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(adminStateLocalFile, content, 0644)
+	if err != nil {
+		impl.logger.Errorw("error in writing admin state file", "path", adminStateLocalFile, "err", err)
+		return err
+	}
+	request := impl.blobConfig.NewBlobStorageRequest(adminStateLocalFile, AdminStateBlobKey)
+	err = impl.blobStorageService.UploadToBlobWithSession(request)
+	if err != nil {
+		impl.logger.Errorw("error in uploading admin state to blob", "key", AdminStateBlobKey, "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package adminState
+
+import (
+	"github.com/devtron-labs/central-api/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"time"
+)
+
+// adminStateId is the id of the single row holding the admin state
+const adminStateId = 1
+
+type AdminStateEntity struct {
+	tableName  struct{}    `sql:"admin_state"`
+	Id         int         `sql:"id,pk"`
+	AdminState *AdminState `sql:"admin_state, notnull"`
+	CreatedOn  time.Time   `sql:"created_on,type:timestamptz,notnull"`
+	UpdatedOn  time.Time   `sql:"updated_on,type:timestamptz"`
+}
+
+type AdminStateDbRepositoryImpl struct {
+	logger       *zap.SugaredLogger
+	dbConnection *pg.DB
+}
+
+func NewAdminStateDbRepositoryImpl(logger *zap.SugaredLogger) (*AdminStateDbRepositoryImpl, error) {
+	dbConnection, err := sql.NewDbConnection(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminStateDbRepositoryImpl{logger: logger, dbConnection: dbConnection}, nil
+}
+
+// Get reads the admin state row, no row means no override has been made yet
+func (impl *AdminStateDbRepositoryImpl) Get() (*AdminState, error) {
+	entity := &AdminStateEntity{}
+	err := impl.dbConnection.Model(entity).
+		Where("id = ?", adminStateId).
+		Select()
+	if err == pg.ErrNoRows {
+		return &AdminState{}, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in getting admin state from db", "err", err)
+		return nil, err
+	}
+	if entity.AdminState == nil {
+		return &AdminState{}, nil
+	}
+	return entity.AdminState, nil
+}
+
+// Save upserts in a single statement so that concurrent instances never create a second row
+func (impl *AdminStateDbRepositoryImpl) Save(adminState *AdminState) error {
+	now := time.Now()
+	entity := &AdminStateEntity{
+		Id:         adminStateId,
+		AdminState: adminState,
+		CreatedOn:  now,
+		UpdatedOn:  now,
+	}
+	_, err := impl.dbConnection.Model(entity).
+		OnConflict("(id) DO UPDATE").
+		Set("admin_state = EXCLUDED.admin_state").
+		Set("updated_on = EXCLUDED.updated_on").
+		Insert()
+	if err != nil {
+		impl.logger.Errorw("error in saving admin state to db", "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package adminState
+
+import (
+	"encoding/json"
+	"errors"
+	util "github.com/devtron-labs/central-api/client"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// AdminState holds the overrides managed through the admin apis
+type AdminState struct {
+	// EolDates maps a minor line like "0.5" to its end of life date in yyyy-mm-dd
+	EolDates map[string]string `json:"eolDates,omitempty"`
+	// RolloutPercentages maps a tag without its leading "v" to the share of installations
+	RolloutPercentages map[string]int `json:"rolloutPercentages,omitempty"`
+	// BlockedReleases maps a tag without its leading "v" to the reason
+	BlockedReleases map[string]string `json:"blockedReleases,omitempty"`
+}
+
+type AdminStateRepository interface {
+	Get() (*AdminState, error)
+	Save(adminState *AdminState) error
+}
+
+// ErrAdminStateUnavailable is returned when the stored state could not be read
+var ErrAdminStateUnavailable = errors.New("admin state unavailable")
+
+func NewAdminStateRepository(logger *zap.SugaredLogger, adminConfig *util.AdminConfig, blobConfig *util.BlobConfigVariables) (AdminStateRepository, error) {
+	if len(adminConfig.AdminStateFilePath) > 0 {
+		return NewAdminStateRepositoryImpl(logger, adminConfig), nil
+	}
+	if blobConfig.CloudConfigured {
+		return NewAdminStateBlobRepositoryImpl(logger, blobConfig), nil
+	}
+	return NewAdminStateDbRepositoryImpl(logger)
+}
+
+// AdminStateRepositoryImpl keeps the admin state in a local file
+type AdminStateRepositoryImpl struct {
+	logger        *zap.SugaredLogger
+	stateFilePath string
+	mutex         sync.Mutex
+}
+
+func NewAdminStateRepositoryImpl(logger *zap.SugaredLogger, adminConfig *util.AdminConfig) *AdminStateRepositoryImpl {
+	return &AdminStateRepositoryImpl{
+		logger:        logger,
+		stateFilePath: adminConfig.AdminStateFilePath,
+	}
+}
+
+func (impl *AdminStateRepositoryImpl) Get() (*AdminState, error) {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	adminState := &AdminState{}
+	content, err := os.ReadFile(impl.stateFilePath)
+	if os.IsNotExist(err) {
+		return adminState, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in reading admin state file", "path", impl.stateFilePath, "err", err)
+		return nil, err
+	}
+	err = json.Unmarshal(content, adminState)
+	if err != nil {
+		impl.logger.Errorw("error in unmarshalling admin state file", "path", impl.stateFilePath, "err", err)
+		return nil, err
+	}
+	return adminState, nil
+}
+
+// Save renames a temporary file so that a crash never leaves a partial state
+func (impl *AdminStateRepositoryImpl) Save(adminState *AdminState) error {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	content, err := json.Marshal(adminState)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(impl.stateFilePath), 0755)
+	if err != nil {
+		impl.logger.Errorw("error in creating admin state directory", "path", impl.stateFilePath, "err", err)
+		return err
+	}
+	tempFilePath := impl.stateFilePath + ".tmp"
+	err = os.WriteFile(tempFilePath, content, 0644)
+	if err != nil {
+		impl.logger.Errorw("error in writing admin state file", "path", tempFilePath, "err", err)
+		return err
+	}
+	return os.Rename(tempFilePath, impl.stateFilePath)
+}
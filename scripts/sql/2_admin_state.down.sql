@@ -0,0 +1,2 @@
+---- DROP table
+DROP TABLE IF EXISTS "public"."admin_state";
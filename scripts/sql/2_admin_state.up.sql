@@ -0,0 +1,9 @@
+-- Table Definition, a single row holds the admin state
+CREATE TABLE IF NOT EXISTS "public"."admin_state"
+(
+    "id"          int4        NOT NULL,
+    "admin_state" text        NOT NULL,
+    "created_on"  timestamptz NOT NULL,
+    "updated_on"  timestamptz,
+    PRIMARY KEY ("id")
+);
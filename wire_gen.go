@@ -11,6 +11,7 @@ import (
 	"github.com/devtron-labs/central-api/client"
 	"github.com/devtron-labs/central-api/internal/logger"
 	"github.com/devtron-labs/central-api/pkg"
+	"github.com/devtron-labs/central-api/pkg/adminState"
 	"github.com/devtron-labs/common-lib/blob-storage"
 )
 
@@ -34,14 +35,72 @@ func InitializeApp() (*App, error) {
 		return nil, err
 	}
 	blobStorageServiceImpl := blob_storage.NewBlobStorageServiceImpl(sugaredLogger)
-	releaseNoteServiceImpl, err := pkg.NewReleaseNoteServiceImpl(sugaredLogger, gitHubClient, moduleConfig, blobConfigVariables, blobStorageServiceImpl)
+	releaseCacheConfig, err := util.NewReleaseCacheConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	supportPolicyConfig, err := util.NewSupportPolicyConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	adminConfig, err := util.NewAdminConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	adminStateRepository, err := adminState.NewAdminStateRepository(sugaredLogger, adminConfig, blobConfigVariables)
+	if err != nil {
+		return nil, err
+	}
+	releaseLintConfig, err := util.NewReleaseLintConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	releaseSourceConfig, err := util.NewReleaseSourceConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	releaseBodyConfig, err := util.NewReleaseBodyConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	releaseChannelConfig, err := util.NewReleaseChannelConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	releaseAssetConfig, err := util.NewReleaseAssetConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	releaseNoteServiceImpl, err := pkg.NewReleaseNoteServiceImpl(sugaredLogger, gitHubClient, moduleConfig, blobConfigVariables, blobStorageServiceImpl, releaseCacheConfig, supportPolicyConfig, adminStateRepository, releaseLintConfig, releaseSourceConfig, releaseBodyConfig, releaseChannelConfig, releaseAssetConfig)
 	if err != nil {
 		return nil, err
 	}
 	webhookSecretValidatorImpl := pkg.NewWebhookSecretValidatorImpl(sugaredLogger, gitHubClient)
 	ciBuildMetadataServiceImpl := pkg.NewCiBuildMetadataServiceImpl(sugaredLogger)
-	restHandlerImpl := api.NewRestHandlerImpl(sugaredLogger, releaseNoteServiceImpl, webhookSecretValidatorImpl, gitHubClient, ciBuildMetadataServiceImpl)
-	muxRouter := api.NewMuxRouter(sugaredLogger, restHandlerImpl)
-	app := NewApp(muxRouter, sugaredLogger)
+	telemetryConfig, err := util.NewTelemetryConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	telemetryServiceImpl := pkg.NewTelemetryServiceImpl(sugaredLogger, releaseNoteServiceImpl, telemetryConfig)
+	releaseAssetServiceImpl := pkg.NewReleaseAssetServiceImpl(sugaredLogger, gitHubClient, releaseNoteServiceImpl, releaseAssetConfig, releaseSourceConfig)
+	restHandlerImpl := api.NewRestHandlerImpl(sugaredLogger, releaseNoteServiceImpl, webhookSecretValidatorImpl, gitHubClient, ciBuildMetadataServiceImpl, adminConfig, telemetryServiceImpl, releaseAssetServiceImpl)
+	responseConfig, err := util.NewResponseConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	clientVersionConfig, err := util.NewClientVersionConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	responseSigningConfig, err := util.NewResponseSigningConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	metricsConfig, err := util.NewMetricsConfig(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	muxRouter := api.NewMuxRouter(sugaredLogger, restHandlerImpl, responseConfig, clientVersionConfig, responseSigningConfig, metricsConfig)
+	app := NewApp(muxRouter, sugaredLogger, releaseNoteServiceImpl)
 	return app, nil
 }